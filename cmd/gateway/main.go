@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
@@ -32,23 +34,36 @@ var (
 		"gatewayclass",
 		"",
 		"The name of the GatewayClass resource. Every NGINX Gateway must have a unique corresponding GatewayClass resource")
+
+	gatewayService = flag.String(
+		"service",
+		"",
+		"The Service that exposes NGINX in the form NAMESPACE/NAME. The Gateways report the LoadBalancer ingress IPs and hostnames of the Service as their addresses. If not set, the Gateways report the IP of the pod from the POD_IP environment variable")
 )
 
 func main() {
 	flag.Parse()
 
+	MustValidateArguments(
+		flag.CommandLine,
+		GatewayControllerParam(domain, "nginx-gateway" /* FIXME(f5yacobucci) dynamically set */),
+		GatewayClassParam(),
+		ServiceParam(),
+	)
+
 	logger := zap.New()
 	conf := config.Config{
 		GatewayCtlrName:  *gatewayCtlrName,
 		Logger:           logger,
 		GatewayClassName: *gatewayClassName,
+		PodIP:            os.Getenv("POD_IP"),
 	}
 
-	MustValidateArguments(
-		flag.CommandLine,
-		GatewayControllerParam(domain, "nginx-gateway" /* FIXME(f5yacobucci) dynamically set */),
-		GatewayClassParam(),
-	)
+	if *gatewayService != "" {
+		// the flag is validated to be in the form NAMESPACE/NAME
+		fields := strings.Split(*gatewayService, "/")
+		conf.GatewayServiceName = types.NamespacedName{Namespace: fields[0], Name: fields[1]}
+	}
 
 	logger.Info("Starting NGINX Kubernetes Gateway",
 		"version", version,
@@ -92,6 +92,38 @@ func GatewayClassParam() ValidatorContext {
 	}
 }
 
+func ServiceParam() ValidatorContext {
+	name := "service"
+	return ValidatorContext{
+		name,
+		func(flagset *flag.FlagSet) error {
+			param, err := flagset.GetString(name)
+			if err != nil {
+				return err
+			}
+
+			// the flag is optional
+			if len(param) == 0 {
+				return nil
+			}
+
+			fields := strings.Split(param, "/")
+			if len(fields) != 2 {
+				return errors.New("unsupported path length, must be form NAMESPACE/NAME")
+			}
+
+			// used by Kubernetes to validate namespace and service names
+			messages := append(validation.IsDNS1123Label(fields[0]), validation.IsDNS1035Label(fields[1])...)
+			if len(messages) > 0 {
+				msg := strings.Join(messages, "; ")
+				return fmt.Errorf("invalid format: %s", msg)
+			}
+
+			return nil
+		},
+	}
+}
+
 func ValidateArguments(flagset *flag.FlagSet, validators ...ValidatorContext) []string {
 	var msgs []string
 	for _, v := range validators {
@@ -268,5 +268,68 @@ var _ = Describe("Main", func() {
 				tester(t)
 			}) // should fail with invalid name"
 		}) // gatewayclass validation
+
+		Describe("service validation", func() {
+			prepareTestCase := func(value string, expError bool) testCase {
+				return testCase{
+					Flag:             "service",
+					Value:            value,
+					ValidatorContext: ServiceParam(),
+					ExpError:         expError,
+				}
+			}
+
+			BeforeEach(func() {
+				mockFlags = flag.NewFlagSet("mock", flag.PanicOnError)
+				_ = mockFlags.String("service", "", "mock service")
+				err := mockFlags.Parse([]string{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+			AfterEach(func() {
+				mockFlags = nil
+			})
+
+			It("should succeed on valid or empty service", func() {
+				table := []testCase{
+					prepareTestCase(
+						"nginx-gateway/nginx-gateway",
+						expectSuccess,
+					),
+					prepareTestCase(
+						"",
+						expectSuccess,
+					),
+				}
+
+				runner(table)
+			}) // should succeed on valid or empty service
+
+			It("should fail with invalid service", func() {
+				table := []testCase{
+					prepareTestCase(
+						// no namespace
+						"nginx-gateway",
+						expectError,
+					),
+					prepareTestCase(
+						// too many path elements
+						"nginx-gateway/nginx-gateway/broken",
+						expectError,
+					),
+					prepareTestCase(
+						// bad namespace
+						"$nginx/nginx-gateway",
+						expectError,
+					),
+					prepareTestCase(
+						// bad name
+						"nginx-gateway/",
+						expectError,
+					),
+				}
+
+				runner(table)
+			}) // should fail with invalid service
+		}) // service validation
 	}) // CLI argument validation
 }) // end Main
@@ -8,9 +8,11 @@ import (
 type Config struct {
 	GatewayCtlrName string
 	Logger          logr.Logger
-	// GatewayNsName is the namespaced name of a Gateway resource that the Gateway will use.
-	// The Gateway will ignore all other Gateway resources.
-	GatewayNsName types.NamespacedName
 	// GatewayClassName is the name of the GatewayClass resource that the Gateway will use.
 	GatewayClassName string
+	// GatewayServiceName is the namespaced name of the Service that exposes NGINX. It is empty if the Gateways must
+	// report the PodIP as their address.
+	GatewayServiceName types.NamespacedName
+	// PodIP is the IP of the pod of the Gateway.
+	PodIP string
 }
@@ -1,11 +1,13 @@
 package events
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
@@ -13,24 +15,34 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // EventLoop is the main event loop of the Gateway.
 type EventLoop struct {
 	processor       state.ChangeProcessor
 	serviceStore    state.ServiceStore
+	secretMemoryMgr state.SecretDiskMemoryManager
 	generator       config.Generator
 	eventCh         <-chan interface{}
 	logger          logr.Logger
 	nginxFileMgr    file.Manager
 	nginxRuntimeMgr runtime.Manager
 	statusUpdater   status.Updater
+
+	// conf is the latest configuration from the processor.
+	conf state.Configuration
+	// cfg is the latest generated NGINX configuration of the http servers.
+	cfg []byte
+	// streamCfg is the latest generated NGINX configuration of the stream servers.
+	streamCfg []byte
 }
 
 // NewEventLoop creates a new EventLoop.
 func NewEventLoop(
 	processor state.ChangeProcessor,
 	serviceStore state.ServiceStore,
+	secretMemoryMgr state.SecretDiskMemoryManager,
 	generator config.Generator,
 	eventCh <-chan interface{},
 	logger logr.Logger,
@@ -41,6 +53,7 @@ func NewEventLoop(
 	return &EventLoop{
 		processor:       processor,
 		serviceStore:    serviceStore,
+		secretMemoryMgr: secretMemoryMgr,
 		generator:       generator,
 		eventCh:         eventCh,
 		logger:          logger.WithName("eventLoop"),
@@ -69,32 +82,97 @@ func (el *EventLoop) Start(ctx context.Context) error {
 
 // FIXME(pleshakov): think about how to avoid using an interface{} here
 func (el *EventLoop) handleEvent(ctx context.Context, event interface{}) {
+	var backendsChanged bool
+
 	switch e := event.(type) {
 	case *UpsertEvent:
-		el.propagateUpsert(e)
+		backendsChanged = el.propagateUpsert(e)
 	case *DeleteEvent:
-		el.propagateDelete(e)
+		backendsChanged = el.propagateDelete(e)
 	default:
 		panic(fmt.Errorf("unknown event type %T", e))
 	}
 
-	changed, conf, statuses := el.processor.Process()
-	if !changed {
+	changeType, conf, statuses := el.processor.Process()
+
+	switch changeType {
+	case state.NoChange:
+		if backendsChanged {
+			el.updateBackends(ctx)
+		}
+	case state.StatusOnlyChange:
+		// for example, the addresses of the Gateways changed. The configuration is the same, so we don't
+		// rewrite the Secrets and reload NGINX unless the backends changed
+		if backendsChanged {
+			el.updateBackends(ctx)
+		}
+		el.statusUpdater.Update(ctx, statuses)
+	case state.ConfigChange:
+		el.conf = conf
+
+		err := el.updateNginx(ctx, conf)
+		if err != nil {
+			el.logger.Error(err, "Failed to update NGINX configuration")
+		}
+
+		el.statusUpdater.Update(ctx, statuses)
+	default:
+		panic(fmt.Errorf("unknown change type %d", changeType))
+	}
+}
+
+func (el *EventLoop) updateNginx(ctx context.Context, conf state.Configuration) error {
+	// Write all secrets (nuke and pave).
+	// This will remove all secrets in the secrets directory before writing the requested secrets.
+	// FIXME(pleshakov): We may want to rethink this in the future and write each secret to its own file.
+	err := el.secretMemoryMgr.WriteAllRequestedSecrets()
+	if err != nil {
+		return err
+	}
+
+	// The main config doesn't depend on the Services or their endpoints, so unlike the configs of the servers,
+	// it is only written here and not in updateBackends.
+	mainCfg, mainWarnings := el.generator.GenerateMain(conf)
+
+	err = el.nginxFileMgr.WriteMainConfig(mainCfg)
+	if err != nil {
+		return err
+	}
+
+	cfg, streamCfg, warnings := el.generate(conf)
+	warnings.Add(mainWarnings)
+
+	return el.writeAndReload(ctx, cfg, streamCfg, warnings)
+}
+
+// updateBackends regenerates the NGINX configuration from the latest configuration after the Services or their
+// endpoints change. NGINX is only reloaded if the change affects the backends of the configuration.
+func (el *EventLoop) updateBackends(ctx context.Context) {
+	cfg, streamCfg, warnings := el.generate(el.conf)
+	if bytes.Equal(cfg, el.cfg) && bytes.Equal(streamCfg, el.streamCfg) {
 		return
 	}
 
-	err := el.updateNginx(ctx, conf)
+	err := el.writeAndReload(ctx, cfg, streamCfg, warnings)
 	if err != nil {
 		el.logger.Error(err, "Failed to update NGINX configuration")
 	}
-
-	el.statusUpdater.Update(ctx, statuses)
 }
 
-func (el *EventLoop) updateNginx(ctx context.Context, conf state.Configuration) error {
-	cfg, warnings := el.generator.Generate(conf)
+// generate generates the NGINX configuration of the http and stream servers.
+func (el *EventLoop) generate(conf state.Configuration) (cfg []byte, streamCfg []byte, warnings config.Warnings) {
+	cfg, httpWarnings := el.generator.Generate(conf)
+	streamCfg, streamWarnings := el.generator.GenerateStream(conf)
+
+	warnings = config.Warnings{}
+	warnings.Add(httpWarnings)
+	warnings.Add(streamWarnings)
 
-	// For now, we keep all http servers in one config
+	return cfg, streamCfg, warnings
+}
+
+func (el *EventLoop) writeAndReload(ctx context.Context, cfg []byte, streamCfg []byte, warnings config.Warnings) error {
+	// For now, we keep all http servers in one config and all stream servers in another one.
 	// We might rethink that. For example, we can write each server to its file
 	// or group servers in some way.
 	err := el.nginxFileMgr.WriteHTTPServersConfig("http-servers", cfg)
@@ -102,6 +180,14 @@ func (el *EventLoop) updateNginx(ctx context.Context, conf state.Configuration)
 		return err
 	}
 
+	err = el.nginxFileMgr.WriteStreamServersConfig("stream-servers", streamCfg)
+	if err != nil {
+		return err
+	}
+
+	el.cfg = cfg
+	el.streamCfg = streamCfg
+
 	for obj, objWarnings := range warnings {
 		for _, w := range objWarnings {
 			// FIXME(pleshakov): report warnings via Object status
@@ -116,7 +202,8 @@ func (el *EventLoop) updateNginx(ctx context.Context, conf state.Configuration)
 	return el.nginxRuntimeMgr.Reload(ctx)
 }
 
-func (el *EventLoop) propagateUpsert(e *UpsertEvent) {
+// propagateUpsert propagates the upsert event. It returns true if the event changes the Services or their endpoints.
+func (el *EventLoop) propagateUpsert(e *UpsertEvent) bool {
 	switch r := e.Resource.(type) {
 	case *v1alpha2.GatewayClass:
 		el.processor.CaptureUpsertChange(r)
@@ -124,15 +211,39 @@ func (el *EventLoop) propagateUpsert(e *UpsertEvent) {
 		el.processor.CaptureUpsertChange(r)
 	case *v1alpha2.HTTPRoute:
 		el.processor.CaptureUpsertChange(r)
+	case *v1alpha2.GRPCRoute:
+		el.processor.CaptureUpsertChange(r)
+	case *v1alpha2.TLSRoute:
+		el.processor.CaptureUpsertChange(r)
+	case *v1alpha2.TCPRoute:
+		el.processor.CaptureUpsertChange(r)
+	case *v1alpha2.UDPRoute:
+		el.processor.CaptureUpsertChange(r)
+	case *v1alpha2.ReferenceGrant:
+		el.processor.CaptureUpsertChange(r)
+	case *apiv1.Secret:
+		el.processor.CaptureUpsertChange(r)
+	case *apiv1.Namespace:
+		el.processor.CaptureUpsertChange(r)
+	case *nginxgwv1alpha1.GatewayConfig:
+		el.processor.CaptureUpsertChange(r)
 	case *apiv1.Service:
-		// FIXME(pleshakov): make sure the affected hosts are updated
+		// the processor reports the LoadBalancer ingress of the Service that exposes NGINX as the Gateway addresses
+		el.processor.CaptureUpsertChange(r)
 		el.serviceStore.Upsert(r)
+		return true
+	case *discoveryv1.EndpointSlice:
+		el.serviceStore.UpsertEndpointSlice(r)
+		return true
 	default:
 		panic(fmt.Errorf("unknown resource type %T", e.Resource))
 	}
+
+	return false
 }
 
-func (el *EventLoop) propagateDelete(e *DeleteEvent) {
+// propagateDelete propagates the delete event. It returns true if the event changes the Services or their endpoints.
+func (el *EventLoop) propagateDelete(e *DeleteEvent) bool {
 	switch e.Type.(type) {
 	case *v1alpha2.GatewayClass:
 		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
@@ -140,10 +251,32 @@ func (el *EventLoop) propagateDelete(e *DeleteEvent) {
 		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	case *v1alpha2.HTTPRoute:
 		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.GRPCRoute:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.TLSRoute:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.TCPRoute:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.UDPRoute:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *v1alpha2.ReferenceGrant:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *apiv1.Secret:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *apiv1.Namespace:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
+	case *nginxgwv1alpha1.GatewayConfig:
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 	case *apiv1.Service:
-		// FIXME(pleshakov): make sure the affected hosts are updated
+		el.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 		el.serviceStore.Delete(e.NamespacedName)
+		return true
+	case *discoveryv1.EndpointSlice:
+		el.serviceStore.DeleteEndpointSlice(e.NamespacedName)
+		return true
 	default:
 		panic(fmt.Errorf("unknown resource type %T", e.Type))
 	}
+
+	return false
 }
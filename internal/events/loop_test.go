@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config/configfakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/file/filefakes"
@@ -21,6 +23,7 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status/statusfakes"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 type unsupportedResource struct {
@@ -39,6 +42,7 @@ var _ = Describe("EventLoop", func() {
 	var (
 		fakeProcessor       *statefakes.FakeChangeProcessor
 		fakeServiceStore    *statefakes.FakeServiceStore
+		fakeSecretMemoryMgr *statefakes.FakeSecretDiskMemoryManager
 		fakeGenerator       *configfakes.FakeGenerator
 		fakeNginxFimeMgr    *filefakes.FakeManager
 		fakeNginxRuntimeMgr *runtimefakes.FakeManager
@@ -53,11 +57,22 @@ var _ = Describe("EventLoop", func() {
 		fakeProcessor = &statefakes.FakeChangeProcessor{}
 		eventCh = make(chan interface{})
 		fakeServiceStore = &statefakes.FakeServiceStore{}
+		fakeSecretMemoryMgr = &statefakes.FakeSecretDiskMemoryManager{}
 		fakeGenerator = &configfakes.FakeGenerator{}
 		fakeNginxFimeMgr = &filefakes.FakeManager{}
 		fakeNginxRuntimeMgr = &runtimefakes.FakeManager{}
 		fakeStatusUpdater = &statusfakes.FakeUpdater{}
-		ctrl := events.NewEventLoop(fakeProcessor, fakeServiceStore, fakeGenerator, eventCh, zap.New(), fakeNginxFimeMgr, fakeNginxRuntimeMgr, fakeStatusUpdater)
+		ctrl := events.NewEventLoop(
+			fakeProcessor,
+			fakeServiceStore,
+			fakeSecretMemoryMgr,
+			fakeGenerator,
+			eventCh,
+			zap.New(),
+			fakeNginxFimeMgr,
+			fakeNginxRuntimeMgr,
+			fakeStatusUpdater,
+		)
 
 		var ctx context.Context
 		ctx, cancel = context.WithCancel(context.Background())
@@ -83,12 +98,15 @@ var _ = Describe("EventLoop", func() {
 		DescribeTable("Upsert events",
 			func(e *events.UpsertEvent) {
 				fakeConf := state.Configuration{}
-				changed := true
 				fakeStatuses := state.Statuses{}
-				fakeProcessor.ProcessReturns(changed, fakeConf, fakeStatuses)
+				fakeProcessor.ProcessReturns(state.ConfigChange, fakeConf, fakeStatuses)
 
+				fakeMainCfg := []byte("fake-main")
+				fakeGenerator.GenerateMainReturns(fakeMainCfg, config.Warnings{})
 				fakeCfg := []byte("fake")
 				fakeGenerator.GenerateReturns(fakeCfg, config.Warnings{})
+				fakeStreamCfg := []byte("fake-stream")
+				fakeGenerator.GenerateStreamReturns(fakeStreamCfg, config.Warnings{})
 
 				eventCh <- e
 
@@ -96,6 +114,14 @@ var _ = Describe("EventLoop", func() {
 				Expect(fakeProcessor.CaptureUpsertChangeArgsForCall(0)).Should(Equal(e.Resource))
 				Eventually(fakeProcessor.ProcessCallCount).Should(Equal(1))
 
+				Eventually(fakeSecretMemoryMgr.WriteAllRequestedSecretsCallCount).Should(Equal(1))
+
+				Eventually(fakeGenerator.GenerateMainCallCount).Should(Equal(1))
+				Expect(fakeGenerator.GenerateMainArgsForCall(0)).Should(Equal(fakeConf))
+
+				Eventually(fakeNginxFimeMgr.WriteMainConfigCallCount).Should(Equal(1))
+				Expect(fakeNginxFimeMgr.WriteMainConfigArgsForCall(0)).Should(Equal(fakeMainCfg))
+
 				Eventually(fakeGenerator.GenerateCallCount).Should(Equal(1))
 				Expect(fakeGenerator.GenerateArgsForCall(0)).Should(Equal(fakeConf))
 
@@ -104,6 +130,14 @@ var _ = Describe("EventLoop", func() {
 				Expect(name).Should(Equal("http-servers"))
 				Expect(cfg).Should(Equal(fakeCfg))
 
+				Eventually(fakeGenerator.GenerateStreamCallCount).Should(Equal(1))
+				Expect(fakeGenerator.GenerateStreamArgsForCall(0)).Should(Equal(fakeConf))
+
+				Eventually(fakeNginxFimeMgr.WriteStreamServersConfigCallCount).Should(Equal(1))
+				name, cfg = fakeNginxFimeMgr.WriteStreamServersConfigArgsForCall(0)
+				Expect(name).Should(Equal("stream-servers"))
+				Expect(cfg).Should(Equal(fakeStreamCfg))
+
 				Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
 
 				Eventually(fakeStatusUpdater.UpdateCallCount).Should(Equal(1))
@@ -111,15 +145,22 @@ var _ = Describe("EventLoop", func() {
 				Expect(statuses).Should(Equal(fakeStatuses))
 			},
 			Entry("HTTPRoute", &events.UpsertEvent{Resource: &v1alpha2.HTTPRoute{}}),
+			Entry("GRPCRoute", &events.UpsertEvent{Resource: &v1alpha2.GRPCRoute{}}),
+			Entry("TLSRoute", &events.UpsertEvent{Resource: &v1alpha2.TLSRoute{}}),
+			Entry("TCPRoute", &events.UpsertEvent{Resource: &v1alpha2.TCPRoute{}}),
+			Entry("UDPRoute", &events.UpsertEvent{Resource: &v1alpha2.UDPRoute{}}),
+			Entry("ReferenceGrant", &events.UpsertEvent{Resource: &v1alpha2.ReferenceGrant{}}),
 			Entry("Gateway", &events.UpsertEvent{Resource: &v1alpha2.Gateway{}}),
 			Entry("GatewayClass", &events.UpsertEvent{Resource: &v1alpha2.GatewayClass{}}),
+			Entry("Secret", &events.UpsertEvent{Resource: &apiv1.Secret{}}),
+			Entry("Namespace", &events.UpsertEvent{Resource: &apiv1.Namespace{}}),
+			Entry("GatewayConfig", &events.UpsertEvent{Resource: &nginxgwv1alpha1.GatewayConfig{}}),
 		)
 
 		DescribeTable("Delete events",
 			func(e *events.DeleteEvent) {
 				fakeConf := state.Configuration{}
-				changed := true
-				fakeProcessor.ProcessReturns(changed, fakeConf, state.Statuses{})
+				fakeProcessor.ProcessReturns(state.ConfigChange, fakeConf, state.Statuses{})
 
 				fakeCfg := []byte("fake")
 				fakeGenerator.GenerateReturns(fakeCfg, config.Warnings{})
@@ -141,12 +182,20 @@ var _ = Describe("EventLoop", func() {
 				Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
 			},
 			Entry("HTTPRoute", &events.DeleteEvent{Type: &v1alpha2.HTTPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("GRPCRoute", &events.DeleteEvent{Type: &v1alpha2.GRPCRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("TLSRoute", &events.DeleteEvent{Type: &v1alpha2.TLSRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("TCPRoute", &events.DeleteEvent{Type: &v1alpha2.TCPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("UDPRoute", &events.DeleteEvent{Type: &v1alpha2.UDPRoute{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "route"}}),
+			Entry("ReferenceGrant", &events.DeleteEvent{Type: &v1alpha2.ReferenceGrant{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "policy"}}),
 			Entry("Gateway", &events.DeleteEvent{Type: &v1alpha2.Gateway{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "gateway"}}),
 			Entry("GatewayClass", &events.DeleteEvent{Type: &v1alpha2.GatewayClass{}, NamespacedName: types.NamespacedName{Name: "class"}}),
+			Entry("Secret", &events.DeleteEvent{Type: &apiv1.Secret{}, NamespacedName: types.NamespacedName{Namespace: "test", Name: "secret"}}),
+			Entry("Namespace", &events.DeleteEvent{Type: &apiv1.Namespace{}, NamespacedName: types.NamespacedName{Name: "test"}}),
+			Entry("GatewayConfig", &events.DeleteEvent{Type: &nginxgwv1alpha1.GatewayConfig{}, NamespacedName: types.NamespacedName{Name: "nginx"}}),
 		)
 	})
 
-	Describe("Process Service events", func() {
+	Describe("Process Service and EndpointSlice events", func() {
 		BeforeEach(func() {
 			go start()
 		})
@@ -169,6 +218,9 @@ var _ = Describe("EventLoop", func() {
 			Eventually(fakeServiceStore.UpsertCallCount).Should(Equal(1))
 			Expect(fakeServiceStore.UpsertArgsForCall(0)).Should(Equal(svc))
 
+			Eventually(fakeProcessor.CaptureUpsertChangeCallCount).Should(Equal(1))
+			Expect(fakeProcessor.CaptureUpsertChangeArgsForCall(0)).Should(Equal(svc))
+
 			Eventually(fakeProcessor.ProcessCallCount).Should(Equal(1))
 		})
 
@@ -183,8 +235,136 @@ var _ = Describe("EventLoop", func() {
 			Eventually(fakeServiceStore.DeleteCallCount).Should(Equal(1))
 			Expect(fakeServiceStore.DeleteArgsForCall(0)).Should(Equal(nsname))
 
+			Eventually(fakeProcessor.CaptureDeleteChangeCallCount).Should(Equal(1))
+			passedObj, passedNsName := fakeProcessor.CaptureDeleteChangeArgsForCall(0)
+			Expect(passedObj).Should(Equal(&apiv1.Service{}))
+			Expect(passedNsName).Should(Equal(nsname))
+
+			Eventually(fakeProcessor.ProcessCallCount).Should(Equal(1))
+		})
+
+		It("should process EndpointSlice upsert event", func() {
+			slice := &discoveryv1.EndpointSlice{}
+
+			eventCh <- &events.UpsertEvent{
+				Resource: slice,
+			}
+
+			Eventually(fakeServiceStore.UpsertEndpointSliceCallCount).Should(Equal(1))
+			Expect(fakeServiceStore.UpsertEndpointSliceArgsForCall(0)).Should(Equal(slice))
+
 			Eventually(fakeProcessor.ProcessCallCount).Should(Equal(1))
 		})
+
+		It("should process EndpointSlice delete event", func() {
+			nsname := types.NamespacedName{Namespace: "test", Name: "slice"}
+
+			eventCh <- &events.DeleteEvent{
+				NamespacedName: nsname,
+				Type:           &discoveryv1.EndpointSlice{},
+			}
+
+			Eventually(fakeServiceStore.DeleteEndpointSliceCallCount).Should(Equal(1))
+			Expect(fakeServiceStore.DeleteEndpointSliceArgsForCall(0)).Should(Equal(nsname))
+
+			Eventually(fakeProcessor.ProcessCallCount).Should(Equal(1))
+		})
+
+		It("should reload NGINX only when the endpoints change the configuration", func() {
+			fakeConf := state.Configuration{
+				HTTPServers: []state.HTTPServer{
+					{Hostname: "example.com"},
+				},
+			}
+			fakeProcessor.ProcessReturns(state.ConfigChange, fakeConf, state.Statuses{})
+			fakeGenerator.GenerateReturns([]byte("fake"), config.Warnings{})
+
+			eventCh <- &events.UpsertEvent{Resource: &v1alpha2.HTTPRoute{}}
+
+			Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
+
+			fakeProcessor.ProcessReturns(state.NoChange, state.Configuration{}, state.Statuses{})
+
+			// the endpoints don't affect the configuration
+			eventCh <- &events.UpsertEvent{Resource: &discoveryv1.EndpointSlice{}}
+
+			Eventually(fakeGenerator.GenerateCallCount).Should(Equal(2))
+			Expect(fakeGenerator.GenerateArgsForCall(1)).Should(Equal(fakeConf))
+			Consistently(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
+
+			// the endpoints of a referenced service change
+			fakeGenerator.GenerateReturns([]byte("fake-updated"), config.Warnings{})
+
+			eventCh <- &events.UpsertEvent{Resource: &discoveryv1.EndpointSlice{}}
+
+			Eventually(fakeGenerator.GenerateCallCount).Should(Equal(3))
+			Expect(fakeGenerator.GenerateArgsForCall(2)).Should(Equal(fakeConf))
+
+			Eventually(fakeNginxFimeMgr.WriteHTTPServersConfigCallCount).Should(Equal(2))
+			_, cfg := fakeNginxFimeMgr.WriteHTTPServersConfigArgsForCall(1)
+			Expect(cfg).Should(Equal([]byte("fake-updated")))
+
+			Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(2))
+
+			// the endpoints of a service referenced by a stream server change
+			fakeGenerator.GenerateStreamReturns([]byte("fake-stream-updated"), config.Warnings{})
+
+			eventCh <- &events.UpsertEvent{Resource: &discoveryv1.EndpointSlice{}}
+
+			Eventually(fakeGenerator.GenerateStreamCallCount).Should(Equal(4))
+			Expect(fakeGenerator.GenerateStreamArgsForCall(3)).Should(Equal(fakeConf))
+
+			Eventually(fakeNginxFimeMgr.WriteStreamServersConfigCallCount).Should(Equal(3))
+			_, cfg = fakeNginxFimeMgr.WriteStreamServersConfigArgsForCall(2)
+			Expect(cfg).Should(Equal([]byte("fake-stream-updated")))
+
+			Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(3))
+
+			Expect(fakeSecretMemoryMgr.WriteAllRequestedSecretsCallCount()).Should(Equal(1))
+			Expect(fakeNginxFimeMgr.WriteMainConfigCallCount()).Should(Equal(1))
+			Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(1))
+		})
+
+		It("should only update the statuses when the addresses of the Gateways change", func() {
+			fakeConf := state.Configuration{
+				HTTPServers: []state.HTTPServer{
+					{Hostname: "example.com"},
+				},
+			}
+			fakeProcessor.ProcessReturns(state.ConfigChange, fakeConf, state.Statuses{})
+			fakeGenerator.GenerateReturns([]byte("fake"), config.Warnings{})
+
+			eventCh <- &events.UpsertEvent{Resource: &v1alpha2.HTTPRoute{}}
+
+			Eventually(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
+			Eventually(fakeStatusUpdater.UpdateCallCount).Should(Equal(1))
+
+			fakeStatuses := state.Statuses{
+				GatewayStatuses: state.GatewayStatuses{
+					{Namespace: "test", Name: "gateway"}: {
+						Addresses: []v1alpha2.GatewayAddress{
+							{
+								Type:  (*v1alpha2.AddressType)(helpers.GetStringPointer(string(v1alpha2.IPAddressType))),
+								Value: "10.0.0.1",
+							},
+						},
+					},
+				},
+			}
+			fakeProcessor.ProcessReturns(state.StatusOnlyChange, state.Configuration{}, fakeStatuses)
+
+			eventCh <- &events.UpsertEvent{Resource: &apiv1.Service{}}
+
+			Eventually(fakeStatusUpdater.UpdateCallCount).Should(Equal(2))
+			_, statuses := fakeStatusUpdater.UpdateArgsForCall(1)
+			Expect(statuses).Should(Equal(fakeStatuses))
+
+			// the Service event regenerates the configuration of the backends, which didn't change
+			Expect(fakeGenerator.GenerateArgsForCall(1)).Should(Equal(fakeConf))
+			Consistently(fakeNginxRuntimeMgr.ReloadCallCount).Should(Equal(1))
+			Expect(fakeSecretMemoryMgr.WriteAllRequestedSecretsCallCount()).Should(Equal(1))
+			Expect(fakeNginxFimeMgr.WriteMainConfigCallCount()).Should(Equal(1))
+		})
 	})
 
 	Describe("Edge cases", func() {
@@ -27,6 +27,11 @@ func GetInt32Pointer(i int32) *int32 {
 	return &i
 }
 
+// GetIntPointer takes an int and returns a pointer to it. Useful in unit tests when initializing structs.
+func GetIntPointer(i int) *int {
+	return &i
+}
+
 // GetHTTPMethodPointer takes an HTTPMethod and returns a pointer to it. Useful in unit tests when initializing structs.
 func GetHTTPMethodPointer(m v1alpha2.HTTPMethod) *v1alpha2.HTTPMethod {
 	return &m
@@ -41,3 +46,23 @@ func GetHeaderMatchTypePointer(t v1alpha2.HeaderMatchType) *v1alpha2.HeaderMatch
 func GetQueryParamMatchTypePointer(t v1alpha2.QueryParamMatchType) *v1alpha2.QueryParamMatchType {
 	return &t
 }
+
+// GetPathMatchTypePointer takes a PathMatchType and returns a pointer to it. Useful in unit tests when initializing structs.
+func GetPathMatchTypePointer(t v1alpha2.PathMatchType) *v1alpha2.PathMatchType {
+	return &t
+}
+
+// GetGRPCMethodMatchTypePointer takes a GRPCMethodMatchType and returns a pointer to it. Useful in unit tests when initializing structs.
+func GetGRPCMethodMatchTypePointer(t v1alpha2.GRPCMethodMatchType) *v1alpha2.GRPCMethodMatchType {
+	return &t
+}
+
+// GetTLSModePointer takes a TLSModeType and returns a pointer to it. Useful in unit tests when initializing structs.
+func GetTLSModePointer(t v1alpha2.TLSModeType) *v1alpha2.TLSModeType {
+	return &t
+}
+
+// GetBoolPointer takes a bool and returns a pointer to it. Useful in unit tests when initializing structs.
+func GetBoolPointer(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,49 @@
+package endpointslice
+
+import (
+	"github.com/go-logr/logr"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type endpointSliceImplementation struct {
+	conf    config.Config
+	eventCh chan<- interface{}
+}
+
+// NewEndpointSliceImplementation creates a new EndpointSliceImplementation.
+func NewEndpointSliceImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.EndpointSliceImpl {
+	return &endpointSliceImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *endpointSliceImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *endpointSliceImplementation) Upsert(slice *discoveryv1.EndpointSlice) {
+	impl.Logger().Info("EndpointSlice was upserted",
+		"namespace", slice.Namespace, "name", slice.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: slice,
+	}
+}
+
+func (impl *endpointSliceImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("EndpointSlice resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &discoveryv1.EndpointSlice{},
+	}
+}
@@ -2,19 +2,24 @@ package gatewayconfig
 
 import (
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
 	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
 )
 
 type gatewayConfigImplementation struct {
-	conf config.Config
+	conf    config.Config
+	eventCh chan<- interface{}
 }
 
-func NewGatewayConfigImplementation(conf config.Config) sdk.GatewayConfigImpl {
+// NewGatewayConfigImplementation creates a new GatewayConfigImplementation.
+func NewGatewayConfigImplementation(conf config.Config, eventCh chan<- interface{}) sdk.GatewayConfigImpl {
 	return &gatewayConfigImplementation{
-		conf: conf,
+		conf:    conf,
+		eventCh: eventCh,
 	}
 }
 
@@ -23,26 +28,24 @@ func (impl *gatewayConfigImplementation) Logger() logr.Logger {
 }
 
 func (impl *gatewayConfigImplementation) Upsert(gcfg *nginxgwv1alpha1.GatewayConfig) {
-	impl.Logger().Info("Processing GatewayConfig",
+	impl.Logger().Info("GatewayConfig was upserted",
 		"name", gcfg.Name,
 	)
 
-	if gcfg.Spec.Worker != nil && gcfg.Spec.Worker.Processes != nil {
-		impl.Logger().Info("Worker config",
-			"processes", gcfg.Spec.Worker.Processes)
-	}
-
-	if gcfg.Spec.HTTP != nil {
-		for _, l := range gcfg.Spec.HTTP.AccessLogs {
-			impl.Logger().Info("AccessLog config",
-				"format", l.Format,
-				"destination", l.Destination)
-		}
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: gcfg,
 	}
 }
 
+// Remove handles the removal of the GatewayConfig with the name. GatewayConfig is a cluster-scoped resource, so
+// the name identifies it.
 func (impl *gatewayConfigImplementation) Remove(name string) {
-	impl.Logger().Info("Removing GatewayConfig",
+	impl.Logger().Info("GatewayConfig was removed",
 		"name", name,
 	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: types.NamespacedName{Name: name},
+		Type:           &nginxgwv1alpha1.GatewayConfig{},
+	}
 }
@@ -0,0 +1,49 @@
+package namespace
+
+import (
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type namespaceImplementation struct {
+	conf    config.Config
+	eventCh chan<- interface{}
+}
+
+// NewNamespaceImplementation creates a new NamespaceImplementation.
+func NewNamespaceImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.NamespaceImpl {
+	return &namespaceImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *namespaceImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *namespaceImplementation) Upsert(namespace *apiv1.Namespace) {
+	impl.Logger().Info("Namespace was upserted",
+		"name", namespace.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: namespace,
+	}
+}
+
+func (impl *namespaceImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("Namespace resource was removed",
+		"name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &apiv1.Namespace{},
+	}
+}
@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type referenceGrantImplementation struct {
+	conf    config.Config
+	eventCh chan<- interface{}
+}
+
+// NewReferenceGrantImplementation creates a new ReferenceGrantImplementation.
+func NewReferenceGrantImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.ReferenceGrantImpl {
+	return &referenceGrantImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *referenceGrantImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *referenceGrantImplementation) ControllerName() string {
+	return impl.conf.GatewayCtlrName
+}
+
+func (impl *referenceGrantImplementation) Upsert(rg *v1alpha2.ReferenceGrant) {
+	impl.Logger().Info("ReferenceGrant was upserted",
+		"namespace", rg.Namespace, "name", rg.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: rg,
+	}
+}
+
+func (impl *referenceGrantImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("ReferenceGrant resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &v1alpha2.ReferenceGrant{},
+	}
+}
@@ -0,0 +1,53 @@
+package implementation
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
+)
+
+type tcpRouteImplementation struct {
+	conf    config.Config
+	eventCh chan<- interface{}
+}
+
+// NewTCPRouteImplementation creates a new TCPRouteImplementation.
+func NewTCPRouteImplementation(cfg config.Config, eventCh chan<- interface{}) sdk.TCPRouteImpl {
+	return &tcpRouteImplementation{
+		conf:    cfg,
+		eventCh: eventCh,
+	}
+}
+
+func (impl *tcpRouteImplementation) Logger() logr.Logger {
+	return impl.conf.Logger
+}
+
+func (impl *tcpRouteImplementation) ControllerName() string {
+	return impl.conf.GatewayCtlrName
+}
+
+func (impl *tcpRouteImplementation) Upsert(tr *v1alpha2.TCPRoute) {
+	impl.Logger().Info("TCPRoute was upserted",
+		"namespace", tr.Namespace, "name", tr.Name,
+	)
+
+	impl.eventCh <- &events.UpsertEvent{
+		Resource: tr,
+	}
+}
+
+func (impl *tcpRouteImplementation) Remove(nsname types.NamespacedName) {
+	impl.Logger().Info("TCPRoute resource was removed",
+		"namespace", nsname.Namespace, "name", nsname.Name,
+	)
+
+	impl.eventCh <- &events.DeleteEvent{
+		NamespacedName: nsname,
+		Type:           &v1alpha2.TCPRoute{},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctlr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -12,20 +13,34 @@ import (
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/endpointslice"
 	gw "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gateway"
 	gc "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gatewayclass"
+	gcfg "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/gatewayconfig"
+	grpcr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/grpcroute"
 	hr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/httproute"
+	ns "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/namespace"
+	rg "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/referencegrant"
+	secret "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/secret"
 	svc "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/service"
+	tcpr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/tcproute"
+	tr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/tlsroute"
+	udpr "github.com/nginxinc/nginx-kubernetes-gateway/internal/implementations/udproute"
 	ngxcfg "github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/file"
 	ngxruntime "github.com/nginxinc/nginx-kubernetes-gateway/internal/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/status"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 	"github.com/nginxinc/nginx-kubernetes-gateway/pkg/sdk"
 )
 
-// clusterTimeout is a timeout for connections to the Kubernetes API
-const clusterTimeout = 10 * time.Second
+const (
+	// clusterTimeout is a timeout for connections to the Kubernetes API
+	clusterTimeout = 10 * time.Second
+	// secretsFolder is the folder where the TLS Secrets are written for NGINX
+	secretsFolder = "/etc/nginx/secrets"
+)
 
 var scheme = runtime.NewScheme()
 
@@ -33,6 +48,8 @@ func init() {
 	// FIXME(pleshakov): handle errors returned by the calls bellow
 	_ = gatewayv1alpha2.AddToScheme(scheme)
 	_ = apiv1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+	_ = nginxgwv1alpha1.AddToScheme(scheme)
 }
 
 func Start(cfg config.Config) error {
@@ -60,18 +77,58 @@ func Start(cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("cannot register gateway implementation: %w", err)
 	}
+	err = sdk.RegisterGatewayConfigController(mgr, gcfg.NewGatewayConfigImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register gatewayconfig implementation: %w", err)
+	}
 	err = sdk.RegisterHTTPRouteController(mgr, hr.NewHTTPRouteImplementation(cfg, eventCh))
 	if err != nil {
 		return fmt.Errorf("cannot register httproute implementation: %w", err)
 	}
+	err = sdk.RegisterGRPCRouteController(mgr, grpcr.NewGRPCRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register grpcroute implementation: %w", err)
+	}
+	err = sdk.RegisterTLSRouteController(mgr, tr.NewTLSRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register tlsroute implementation: %w", err)
+	}
+	err = sdk.RegisterTCPRouteController(mgr, tcpr.NewTCPRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register tcproute implementation: %w", err)
+	}
+	err = sdk.RegisterUDPRouteController(mgr, udpr.NewUDPRouteImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register udproute implementation: %w", err)
+	}
+	err = sdk.RegisterReferenceGrantController(mgr, rg.NewReferenceGrantImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register referencegrant implementation: %w", err)
+	}
+	err = sdk.RegisterNamespaceController(mgr, ns.NewNamespaceImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register namespace implementation: %w", err)
+	}
 	err = sdk.RegisterServiceController(mgr, svc.NewServiceImplementation(cfg, eventCh))
 	if err != nil {
 		return fmt.Errorf("cannot register service implementation: %w", err)
 	}
+	err = sdk.RegisterEndpointSliceController(mgr, endpointslice.NewEndpointSliceImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register endpointslice implementation: %w", err)
+	}
+	err = sdk.RegisterSecretController(mgr, secret.NewSecretImplementation(cfg, eventCh))
+	if err != nil {
+		return fmt.Errorf("cannot register secret implementation: %w", err)
+	}
 
+	secretMemoryMgr := state.NewSecretDiskMemoryManager(secretsFolder)
 	processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
-		GatewayCtlrName:  cfg.GatewayCtlrName,
-		GatewayClassName: cfg.GatewayClassName,
+		GatewayCtlrName:     cfg.GatewayCtlrName,
+		GatewayClassName:    cfg.GatewayClassName,
+		SecretMemoryManager: secretMemoryMgr,
+		GatewayServiceName:  cfg.GatewayServiceName,
+		PodIP:               cfg.PodIP,
 	})
 	serviceStore := state.NewServiceStore()
 	configGenerator := ngxcfg.NewGeneratorImpl(serviceStore)
@@ -87,7 +144,17 @@ func Start(cfg config.Config) error {
 		Logger: cfg.Logger.WithName("statusUpdater"),
 		Clock:  status.NewRealClock(),
 	})
-	eventLoop := events.NewEventLoop(processor, serviceStore, configGenerator, eventCh, cfg.Logger, nginxFileMgr, nginxRuntimeMgr, statusUpdater)
+	eventLoop := events.NewEventLoop(
+		processor,
+		serviceStore,
+		secretMemoryMgr,
+		configGenerator,
+		eventCh,
+		cfg.Logger,
+		nginxFileMgr,
+		nginxRuntimeMgr,
+		statusUpdater,
+	)
 
 	err = mgr.Add(eventLoop)
 	if err != nil {
@@ -22,6 +22,32 @@ type FakeGenerator struct {
 		result1 []byte
 		result2 config.Warnings
 	}
+	GenerateMainStub        func(state.Configuration) ([]byte, config.Warnings)
+	generateMainMutex       sync.RWMutex
+	generateMainArgsForCall []struct {
+		arg1 state.Configuration
+	}
+	generateMainReturns struct {
+		result1 []byte
+		result2 config.Warnings
+	}
+	generateMainReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 config.Warnings
+	}
+	GenerateStreamStub        func(state.Configuration) ([]byte, config.Warnings)
+	generateStreamMutex       sync.RWMutex
+	generateStreamArgsForCall []struct {
+		arg1 state.Configuration
+	}
+	generateStreamReturns struct {
+		result1 []byte
+		result2 config.Warnings
+	}
+	generateStreamReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 config.Warnings
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +116,143 @@ func (fake *FakeGenerator) GenerateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *FakeGenerator) GenerateMain(arg1 state.Configuration) ([]byte, config.Warnings) {
+	fake.generateMainMutex.Lock()
+	ret, specificReturn := fake.generateMainReturnsOnCall[len(fake.generateMainArgsForCall)]
+	fake.generateMainArgsForCall = append(fake.generateMainArgsForCall, struct {
+		arg1 state.Configuration
+	}{arg1})
+	stub := fake.GenerateMainStub
+	fakeReturns := fake.generateMainReturns
+	fake.recordInvocation("GenerateMain", []interface{}{arg1})
+	fake.generateMainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGenerator) GenerateMainCallCount() int {
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	return len(fake.generateMainArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateMainCalls(stub func(state.Configuration) ([]byte, config.Warnings)) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = stub
+}
+
+func (fake *FakeGenerator) GenerateMainArgsForCall(i int) state.Configuration {
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	argsForCall := fake.generateMainArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGenerator) GenerateMainReturns(result1 []byte, result2 config.Warnings) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = nil
+	fake.generateMainReturns = struct {
+		result1 []byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateMainReturnsOnCall(i int, result1 []byte, result2 config.Warnings) {
+	fake.generateMainMutex.Lock()
+	defer fake.generateMainMutex.Unlock()
+	fake.GenerateMainStub = nil
+	if fake.generateMainReturnsOnCall == nil {
+		fake.generateMainReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 config.Warnings
+		})
+	}
+	fake.generateMainReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateStream(arg1 state.Configuration) ([]byte, config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	ret, specificReturn := fake.generateStreamReturnsOnCall[len(fake.generateStreamArgsForCall)]
+	fake.generateStreamArgsForCall = append(fake.generateStreamArgsForCall, struct {
+		arg1 state.Configuration
+	}{arg1})
+	stub := fake.GenerateStreamStub
+	fakeReturns := fake.generateStreamReturns
+	fake.recordInvocation("GenerateStream", []interface{}{arg1})
+	fake.generateStreamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGenerator) GenerateStreamCallCount() int {
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
+	return len(fake.generateStreamArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateStreamCalls(stub func(state.Configuration) ([]byte, config.Warnings)) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = stub
+}
+
+func (fake *FakeGenerator) GenerateStreamArgsForCall(i int) state.Configuration {
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
+	argsForCall := fake.generateStreamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGenerator) GenerateStreamReturns(result1 []byte, result2 config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = nil
+	fake.generateStreamReturns = struct {
+		result1 []byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateStreamReturnsOnCall(i int, result1 []byte, result2 config.Warnings) {
+	fake.generateStreamMutex.Lock()
+	defer fake.generateStreamMutex.Unlock()
+	fake.GenerateStreamStub = nil
+	if fake.generateStreamReturnsOnCall == nil {
+		fake.generateStreamReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 config.Warnings
+		})
+	}
+	fake.generateStreamReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 config.Warnings
+	}{result1, result2}
+}
+
 func (fake *FakeGenerator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.generateMutex.RLock()
 	defer fake.generateMutex.RUnlock()
+	fake.generateMainMutex.RLock()
+	defer fake.generateMainMutex.RUnlock()
+	fake.generateStreamMutex.RLock()
+	defer fake.generateStreamMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,23 +4,33 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
-// nginx502Server is used as a backend for services that cannot be resolved (have no IP address).
+// nginx502Server is used as a backend for services that cannot be resolved (have no ready endpoints).
 const nginx502Server = "unix:/var/lib/nginx/nginx-502-server.sock"
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Generator
 
 // Generator generates NGINX configuration.
 type Generator interface {
-	// Generate generates NGINX configuration from internal representation.
+	// GenerateMain generates the main NGINX configuration file from internal representation.
+	GenerateMain(configuration state.Configuration) ([]byte, Warnings)
+	// Generate generates NGINX configuration of the http servers from internal representation.
 	Generate(configuration state.Configuration) ([]byte, Warnings)
+	// GenerateStream generates NGINX configuration of the stream servers from internal representation.
+	GenerateStream(configuration state.Configuration) ([]byte, Warnings)
 }
 
 // GeneratorImpl is an implementation of Generator
@@ -37,76 +47,586 @@ func NewGeneratorImpl(serviceStore state.ServiceStore) *GeneratorImpl {
 	}
 }
 
+func (g *GeneratorImpl) GenerateMain(conf state.Configuration) ([]byte, Warnings) {
+	cfg, warnings := generateMainConfig(conf.GatewayConfig)
+
+	return g.executor.ExecuteForMainConfig(cfg), warnings
+}
+
+// accessLogDestinations maps the special destinations of the access logs to the files that NGINX writes to.
+var accessLogDestinations = map[string]string{
+	"stdout": "/dev/stdout",
+	"stderr": "/dev/stderr",
+}
+
+// generateMainConfig generates the main configuration from the GatewayConfig. If there is no GatewayConfig, NGINX
+// uses its defaults. The invalid settings of the GatewayConfig are ignored and reported as warnings.
+func generateMainConfig(gcfg *nginxgwv1alpha1.GatewayConfig) (mainConfig, Warnings) {
+	warnings := newWarnings()
+
+	var cfg mainConfig
+
+	if gcfg == nil {
+		return cfg, warnings
+	}
+
+	if gcfg.Spec.Worker != nil && gcfg.Spec.Worker.Processes != nil {
+		if processes := *gcfg.Spec.Worker.Processes; processes > 0 {
+			cfg.WorkerProcesses = strconv.Itoa(processes)
+		} else {
+			warnings.AddWarningf(gcfg, "worker processes must be positive, got %d; the default is used", processes)
+		}
+	}
+
+	if gcfg.Spec.HTTP == nil {
+		return cfg, warnings
+	}
+
+	for i, l := range gcfg.Spec.HTTP.AccessLogs {
+		if l.Format == "" || l.Destination == "" {
+			warnings.AddWarningf(gcfg, "access log %d must have a format and a destination; it is ignored", i)
+			continue
+		}
+
+		dest, exist := accessLogDestinations[l.Destination]
+		if !exist {
+			dest = l.Destination
+		}
+
+		cfg.AccessLogs = append(cfg.AccessLogs, accessLog{
+			Name:        fmt.Sprintf("access_log_%d", i),
+			Format:      nginxStringEscaper.Replace(l.Format),
+			Destination: nginxStringEscaper.Replace(dest),
+		})
+	}
+
+	return cfg, warnings
+}
+
 func (g *GeneratorImpl) Generate(conf state.Configuration) ([]byte, Warnings) {
 	warnings := newWarnings()
 
+	httpPorts := getPorts(conf.HTTPServers)
+	sslPorts := getPorts(conf.SSLServers)
+
 	servers := httpServers{
-		Servers: make([]server, 0, len(conf.HTTPServers)),
+		Servers: make([]server, 0, len(httpPorts)+len(conf.HTTPServers)+len(sslPorts)+len(conf.SSLServers)),
+	}
+
+	ups := newUpstreams(g.serviceStore, conf.AllowedCrossNamespaceBackends)
+	splits := newSplitClients()
+	rewrites := newURIRewrites()
+	headerMaps := newResponseHeaderMaps()
+
+	// the default servers respond with 404 to the requests for the hostnames that don't match any HTTP server
+	// of the port
+	for _, port := range httpPorts {
+		servers.Servers = append(servers.Servers, server{IsDefaultHTTP: true, Port: port})
 	}
 
 	for _, s := range conf.HTTPServers {
-		cfg, warns := generate(s, g.serviceStore)
+		cfg, warns := generate(s, ups, splits, rewrites, headerMaps)
+
+		servers.Servers = append(servers.Servers, cfg)
+		warnings.Add(warns)
+	}
+
+	// the default servers reject TLS handshakes for the hostnames that don't match any SSL server of the port
+	for _, port := range sslPorts {
+		servers.Servers = append(servers.Servers, server{IsDefaultSSL: true, Port: port})
+	}
+
+	for _, s := range conf.SSLServers {
+		cfg, warns := generate(s, ups, splits, rewrites, headerMaps)
 
 		servers.Servers = append(servers.Servers, cfg)
 		warnings.Add(warns)
 	}
 
+	servers.Upstreams = ups.blocks
+	servers.Maps = append(createAddHeaderMaps(conf), rewrites.maps...)
+	servers.Maps = append(servers.Maps, headerMaps.maps...)
+	warnings.Add(ups.warnings)
+	servers.SplitClients = splits.blocks
+
 	return g.executor.ExecuteForHTTPServers(servers), warnings
 }
 
-func generate(httpServer state.HTTPServer, serviceStore state.ServiceStore) (server, Warnings) {
+func (g *GeneratorImpl) GenerateStream(conf state.Configuration) ([]byte, Warnings) {
 	warnings := newWarnings()
 
-	locs := make([]location, 0, len(httpServer.PathRules)) // FIXME(pleshakov): expand with rule.Routes
+	ups := newStreamUpstreams(g.serviceStore, conf.AllowedCrossNamespaceBackends)
 
-	for _, rule := range httpServer.PathRules {
-		matches := make([]httpMatch, 0, len(rule.MatchRules))
+	servers, maps, warns := generateTLSPassthroughServers(conf.TLSPassthroughServers, ups)
+	warnings.Add(warns)
 
-		for ruleIdx, r := range rule.MatchRules {
+	l4Servers, warns := generateL4Servers(conf.L4Servers, ups)
+	servers = append(servers, l4Servers...)
+	warnings.Add(warns)
+	warnings.Add(ups.warnings)
 
-			address, err := getBackendAddress(r.Source.Spec.Rules[r.RuleIdx].BackendRefs, r.Source.Namespace, serviceStore)
+	streams := streamServers{
+		Upstreams: ups.blocks,
+		Maps:      maps,
+		Servers:   servers,
+	}
 
-			if err != nil {
-				warnings.AddWarning(r.Source, err.Error())
-			}
+	return g.executor.ExecuteForStreamServers(streams), warnings
+}
 
-			m := r.GetMatch()
+// generateTLSPassthroughServers generates a stream server for every port of the TLS passthrough servers.
+// The stream server reads the SNI hostname of a connection and passes the connection to the upstream that the map of
+// the port chooses for the hostname. If the map doesn't include the hostname or the backend of the hostname cannot be
+// resolved, the variable is empty, so that NGINX closes the connection.
+func generateTLSPassthroughServers(
+	tlsServers []state.TLSPassthroughServer,
+	ups *upstreams,
+) ([]streamServer, []nginxMap, Warnings) {
+	warnings := newWarnings()
 
-			// handle case where the only route is a path-only match
-			// generate a standard location block without http_matches.
-			if len(rule.MatchRules) == 1 && isPathOnlyMatch(m) {
-				locs = append(locs, location{
-					Path:      rule.Path,
-					ProxyPass: generateProxyPass(address),
-				})
-			} else {
-				path := createPathForMatch(rule.Path, ruleIdx)
-				locs = append(locs, generateMatchLocation(path, address))
-				matches = append(matches, createHTTPMatch(m, path))
-			}
+	var (
+		servers []streamServer
+		maps    []nginxMap
+	)
+
+	mapsForPorts := make(map[int32]int)
+
+	for _, s := range tlsServers {
+		idx, exist := mapsForPorts[s.Port]
+		if !exist {
+			variable := fmt.Sprintf("tls_passthrough_backend_%d", s.Port)
+
+			servers = append(servers, streamServer{
+				Port:       s.Port,
+				SSLPreread: true,
+				ProxyPass:  "$" + variable,
+			})
+			maps = append(maps, nginxMap{
+				Source:    "$ssl_preread_server_name",
+				Variable:  variable,
+				Hostnames: true,
+			})
+
+			idx = len(maps) - 1
+			mapsForPorts[s.Port] = idx
+		}
+
+		address, err := getBackendAddress(s.BackendRef, s.Source, ups)
+		if err != nil {
+			warnings.AddWarningf(s.Source, "%v; the connections will be closed", err)
+			address = `""`
+		}
+
+		hostname := s.Hostname
+		switch hostname {
+		case "":
+			// the empty hostname matches any hostname
+			hostname = "default"
+		case "default", "hostnames", "include", "volatile":
+			// NGINX requires escaping the values that match the names of the special parameters of the map
+			hostname = `\` + hostname
+		}
+
+		maps[idx].Parameters = append(maps[idx].Parameters, mapParameter{Value: hostname, Result: address})
+	}
+
+	return servers, maps, warnings
+}
+
+// generateL4Servers generates the stream servers that pass the TCP or UDP connections for a port to the upstream of
+// the backend. If the backend cannot be resolved, the server is not generated, so that NGINX doesn't accept
+// the connections for the port.
+func generateL4Servers(l4Servers []state.L4Server, ups *upstreams) ([]streamServer, Warnings) {
+	warnings := newWarnings()
+
+	servers := make([]streamServer, 0, len(l4Servers))
+
+	for _, s := range l4Servers {
+		address, err := getBackendAddress(s.BackendRef, s.Source, ups)
+		if err != nil {
+			warnings.AddWarningf(s.Source, "%v; NGINX will not accept the %s connections for port %d",
+				err, s.Protocol, s.Port)
+			continue
+		}
+
+		servers = append(servers, streamServer{
+			Port:      s.Port,
+			UDP:       s.Protocol == v1beta1.UDPProtocolType,
+			ProxyPass: address,
+		})
+	}
+
+	return servers, warnings
+}
+
+// getPorts returns the unique ports of the servers in the order of their first appearance.
+func getPorts(httpServers []state.HTTPServer) []int32 {
+	ports := make([]int32, 0, len(httpServers))
+	seen := make(map[int32]struct{})
+
+	for _, s := range httpServers {
+		if _, exist := seen[s.Port]; exist {
+			continue
+		}
+
+		seen[s.Port] = struct{}{}
+		ports = append(ports, s.Port)
+	}
+
+	return ports
+}
+
+func generate(
+	httpServer state.HTTPServer,
+	ups *upstreams,
+	splits *splitClients,
+	rewrites *uriRewrites,
+	headerMaps *responseHeaderMaps,
+) (server, Warnings) {
+	warnings := newWarnings()
+
+	// The exact location of an exact rule takes precedence over the prefix locations, so the requests for its path
+	// that don't satisfy the matches of the exact rule must fall back to the prefix rules that cover the path.
+	// Such rules share the exact location, which evaluates the matches of the exact rule first, followed by
+	// the matches of the prefix rules from the longest prefix to the shortest one.
+	exactRules := make(map[string]int)
+	coveringPrefixRules := make(map[int][]int)
+	sharedRules := make(map[int]struct{})
+
+	for exactIdx, exactRule := range httpServer.PathRules {
+		if exactRule.PathType != state.PathTypeExact {
+			continue
 		}
 
-		if len(matches) > 0 {
-			b, err := json.Marshal(matches)
+		exactRules[exactRule.Path] = exactIdx
 
-			if err != nil {
-				// panic is safe here because we should never fail to marshal the match unless we constructed it incorrectly.
-				panic(fmt.Errorf("could not marshal http match: %w", err))
+		for prefixIdx, prefixRule := range httpServer.PathRules {
+			if prefixRule.PathType != state.PathTypePrefix || !prefixCoversPath(prefixRule.Path, exactRule.Path) {
+				continue
 			}
 
-			pathLoc := location{
-				Path:         rule.Path,
-				HTTPMatchVar: string(b),
+			coveringPrefixRules[exactIdx] = append(coveringPrefixRules[exactIdx], prefixIdx)
+			sharedRules[exactIdx] = struct{}{}
+			sharedRules[prefixIdx] = struct{}{}
+		}
+
+		sort.SliceStable(coveringPrefixRules[exactIdx], func(i, j int) bool {
+			return len(httpServer.PathRules[coveringPrefixRules[exactIdx][i]].Path) >
+				len(httpServer.PathRules[coveringPrefixRules[exactIdx][j]].Path)
+		})
+	}
+
+	origin := serverOrigin{scheme: "http", port: httpServer.Port}
+	if httpServer.SSL != nil {
+		origin.scheme = "https"
+	}
+
+	rulesLocs := make([]pathRuleLocations, 0, len(httpServer.PathRules))
+
+	for pathRuleIdx, rule := range httpServer.PathRules {
+		_, shared := sharedRules[pathRuleIdx]
+
+		rl, warns := generatePathRuleLocations(rule, pathRuleIdx, shared, origin, ups, splits, rewrites, headerMaps)
+
+		rulesLocs = append(rulesLocs, rl)
+		warnings.Add(warns)
+	}
+
+	locs := make([]location, 0, len(httpServer.PathRules)) // FIXME(pleshakov): expand with rule.Routes
+
+	for pathRuleIdx, rule := range httpServer.PathRules {
+		rl := rulesLocs[pathRuleIdx]
+
+		locs = append(locs, rl.internal...)
+
+		switch rule.PathType {
+		case state.PathTypeExact:
+			matches := append([]httpMatch(nil), rl.matches...)
+			for _, prefixIdx := range coveringPrefixRules[pathRuleIdx] {
+				matches = append(matches, rulesLocs[prefixIdx].matches...)
 			}
 
-			locs = append(locs, pathLoc)
+			locs = append(locs, createLocation("= "+rule.Path, rl.direct, matches))
+		case state.PathTypeRegex:
+			// NGINX checks the regex locations after the exact ones and the prefix ones with the ^~ modifier, in
+			// the order they appear in the server
+			locs = append(locs, createLocation("~ "+quoteRegex(rule.Path), rl.direct, rl.matches))
+		default:
+			for _, path := range getPrefixLocationPaths(rule.Path) {
+				// the exact rule for the path generates the exact location
+				if _, exist := exactRules[rule.Path]; exist && path == "= "+rule.Path {
+					continue
+				}
+
+				locs = append(locs, createLocation(path, rl.direct, rl.matches))
+			}
 		}
 	}
 
-	return server{
+	locs = append(locs, generateMirrorLocations(locs)...)
+
+	s := server{
 		ServerName: httpServer.Hostname,
+		Port:       httpServer.Port,
 		Locations:  locs,
-	}, warnings
+	}
+
+	if httpServer.SSL != nil {
+		s.SSL = &ssl{
+			Certificate:    httpServer.SSL.CertificatePath,
+			CertificateKey: httpServer.SSL.CertificatePath,
+		}
+	}
+
+	return s, warnings
+}
+
+// pathRuleLocations holds the locations generated for a PathRule.
+type pathRuleLocations struct {
+	// internal holds the internal locations for the matches of the rule.
+	internal []location
+	// matches holds the matches of the rule, which redirect the requests to the internal locations.
+	matches []httpMatch
+	// direct is set instead of matches when the rule has a single path-only match, so that the location of
+	// the rule can pass the requests to the backends without evaluating any matches. Its path is not set.
+	direct *location
+}
+
+// generatePathRuleLocations generates the locations for the rule.
+// If the rule shares its location with another rule, the rule always gets matches, so that the matches of both rules
+// can be evaluated in the shared location.
+func generatePathRuleLocations(
+	rule state.PathRule,
+	pathRuleIdx int,
+	shared bool,
+	origin serverOrigin,
+	ups *upstreams,
+	splits *splitClients,
+	rewrites *uriRewrites,
+	headerMaps *responseHeaderMaps,
+) (pathRuleLocations, Warnings) {
+	warnings := newWarnings()
+
+	var rl pathRuleLocations
+
+	for ruleIdx, r := range rule.MatchRules {
+		backendLoc, warns := generateRuleLocation(
+			r.Source,
+			r.RuleIdx,
+			rule.Path,
+			origin,
+			ups,
+			splits,
+			rewrites,
+			headerMaps,
+		)
+		warnings.Add(warns)
+
+		if r.GRPC {
+			backendLoc = convertToGRPCLocation(backendLoc)
+		}
+
+		m := r.GetMatch()
+
+		// handle case where the only route is a path-only match
+		// generate a standard location block without http_matches.
+		if len(rule.MatchRules) == 1 && isPathOnlyMatch(m) && !shared {
+			rl.direct = &backendLoc
+			continue
+		}
+
+		path := createPathForMatch(rule.Path, rule.PathType, pathRuleIdx, ruleIdx)
+		rl.internal = append(rl.internal, generateMatchLocation(path, backendLoc))
+		rl.matches = append(rl.matches, createHTTPMatch(m, path))
+	}
+
+	return rl, warnings
+}
+
+// convertToGRPCLocation converts the location that passes the requests to the backends of a GRPCRoute, so that
+// the location passes them using gRPC. The GRPCRoutes don't support the filters that redirect, rewrite or mirror
+// the requests, so the location only needs the address of the backends.
+func convertToGRPCLocation(loc location) location {
+	if loc.ProxyPass == "" {
+		return loc
+	}
+
+	loc.GRPCPass = "grpc://" + strings.TrimPrefix(loc.ProxyPass, "http://")
+	loc.ProxyPass = ""
+
+	return loc
+}
+
+// serverOrigin is the scheme and the port of the requests that a server handles.
+type serverOrigin struct {
+	scheme string
+	port   int32
+}
+
+// generateRuleLocation generates a location without a path for the rule. If the rule has a RequestRedirect filter,
+// the location redirects the requests, so the rule doesn't need any backends. Otherwise, the location passes
+// the requests to the backends of the rule, rewriting them according to the URLRewrite filter, and mirrors them to
+// the backends of the RequestMirror filters. The path is the path of the PathRule of the location.
+func generateRuleLocation(
+	source *v1alpha2.HTTPRoute,
+	ruleIdx int,
+	path string,
+	origin serverOrigin,
+	ups *upstreams,
+	splits *splitClients,
+	rewrites *uriRewrites,
+	headerMaps *responseHeaderMaps,
+) (location, Warnings) {
+	filters := source.Spec.Rules[ruleIdx].Filters
+
+	for _, f := range filters {
+		if f.Type == v1beta1.HTTPRouteFilterRequestRedirect && f.RequestRedirect != nil {
+			loc := generateRedirectLocation(*f.RequestRedirect, origin)
+			loc.HideResponseHeaders, loc.AddResponseHeaders = generateResponseHeaders(filters, nil, "", headerMaps)
+			loc.ServerTokensOff = hidesServerHeader(loc.HideResponseHeaders)
+
+			return loc, newWarnings()
+		}
+	}
+
+	loc, warnings := generateBackendLocation(source, ruleIdx, ups, splits, headerMaps)
+	loc.ProxySetHeaders = generateProxySetHeaders(filters)
+
+	for _, f := range filters {
+		if f.Type != v1beta1.HTTPRouteFilterURLRewrite || f.URLRewrite == nil || f.URLRewrite.Path == nil {
+			continue
+		}
+
+		var rewrite uriRewrite
+		modifier := f.URLRewrite.Path
+
+		switch {
+		case modifier.Type == v1beta1.FullPathHTTPPathModifier && modifier.ReplaceFullPath != nil:
+			rewrite = uriRewrite{replacement: *modifier.ReplaceFullPath}
+		case modifier.Type == v1beta1.PrefixMatchHTTPPathModifier && modifier.ReplacePrefixMatch != nil:
+			// a rule that replaces the prefix only has prefix matches, so the path is the prefix of the location
+			rewrite = uriRewrite{prefix: path, replacement: *modifier.ReplacePrefixMatch}
+		default:
+			continue
+		}
+
+		loc.RewrittenURI = "$" + rewrites.add(rewrite)
+	}
+
+	for _, f := range filters {
+		if f.Type != v1beta1.HTTPRouteFilterRequestMirror || f.RequestMirror == nil {
+			continue
+		}
+
+		ref := v1alpha2.BackendRef{BackendObjectReference: f.RequestMirror.BackendRef}
+
+		address, err := getBackendAddress(ref, source, ups)
+		if err != nil {
+			warnings.AddWarningf(source, "%v; the requests will not be mirrored", err)
+			continue
+		}
+
+		loc.Mirrors = append(loc.Mirrors, mirror{
+			Path:      "/_mirror_" + address,
+			ProxyPass: generateProxyPass(address),
+		})
+	}
+
+	return loc, warnings
+}
+
+// generateMirrorLocations generates the internal locations that pass the mirrored requests of the locations to
+// the mirror backends. The locations that mirror the requests to the same backend share the mirror location.
+func generateMirrorLocations(locs []location) []location {
+	var mirrorLocs []location
+	seen := make(map[string]struct{})
+
+	for _, l := range locs {
+		for _, m := range l.Mirrors {
+			if _, exist := seen[m.Path]; exist {
+				continue
+			}
+			seen[m.Path] = struct{}{}
+
+			mirrorLocs = append(mirrorLocs, location{
+				Path:      "= " + m.Path,
+				ProxyPass: m.ProxyPass,
+				Internal:  true,
+			})
+		}
+	}
+
+	return mirrorLocs
+}
+
+// generateRedirectLocation generates a location without a path that redirects the requests according to
+// the RequestRedirect filter. The fields of the filter are validated when the graph is built.
+// The redirect preserves the scheme, the hostname and the port of the request unless the filter overrides them.
+// If the filter only overrides the scheme, the redirect uses the default port of the scheme.
+func generateRedirectLocation(redirect v1alpha2.HTTPRequestRedirectFilter, origin serverOrigin) location {
+	code := http.StatusFound
+	if redirect.StatusCode != nil {
+		code = *redirect.StatusCode
+	}
+
+	scheme := origin.scheme
+	port := origin.port
+
+	if redirect.Scheme != nil {
+		scheme = *redirect.Scheme
+		port = getDefaultPort(scheme)
+	}
+
+	if redirect.Port != nil {
+		port = int32(*redirect.Port)
+	}
+
+	hostname := "$host"
+	if redirect.Hostname != nil {
+		hostname = string(*redirect.Hostname)
+	}
+
+	url := scheme + "://" + hostname
+	if port != getDefaultPort(scheme) {
+		url += fmt.Sprintf(":%d", port)
+	}
+
+	return location{
+		Return: &returnVal{
+			Code: code,
+			URL:  url + "$request_uri",
+		},
+	}
+}
+
+func getDefaultPort(scheme string) int32 {
+	if scheme == "https" {
+		return 443
+	}
+	return 80
+}
+
+// createLocation creates a location that either passes the requests to the backends or evaluates the matches.
+func createLocation(path string, direct *location, matches []httpMatch) location {
+	if direct != nil {
+		loc := *direct
+		loc.Path = path
+		return loc
+	}
+
+	b, err := json.Marshal(matches)
+	if err != nil {
+		// panic is safe here because we should never fail to marshal the match unless we constructed it incorrectly.
+		panic(fmt.Errorf("could not marshal http match: %w", err))
+	}
+
+	// NGINX compiles the value of the http_matches variable as a script, where '$' starts a variable, so '$' of
+	// the regular expressions and the values of the matches is escaped as a JSON unicode escape sequence, which
+	// the httpmatches NJS module decodes back to '$'. '$' can only appear in the strings of the JSON.
+	return location{
+		Path:         path,
+		HTTPMatchVar: strings.ReplaceAll(string(b), "$", `\u0024`),
+	}
 }
 
 func generateProxyPass(address string) string {
@@ -116,49 +636,335 @@ func generateProxyPass(address string) string {
 	return "http://" + address
 }
 
-func getBackendAddress(
-	refs []v1alpha2.HTTPBackendRef,
-	parentNS string,
-	serviceStore state.ServiceStore,
-) (string, error) {
+// generateBackendLocation generates a location without a path that passes the requests to the backends of the rule.
+// The requests are distributed among the backends according to their weights. A backend with zero weight doesn't get
+// any requests. If all backends have zero weight, the location responds with 500, as required by the Gateway API.
+// The requests for the backends that cannot be resolved fail.
+// The location modifies the headers of the responses according to the ResponseHeaderModifier filters of the rule and
+// of its backends.
+func generateBackendLocation(
+	source *v1alpha2.HTTPRoute,
+	ruleIdx int,
+	ups *upstreams,
+	splits *splitClients,
+	headerMaps *responseHeaderMaps,
+) (location, Warnings) {
+	warnings := newWarnings()
+
+	rule := source.Spec.Rules[ruleIdx]
+
+	withResponseHeaders := func(loc location, backends []responseBackend, backendVariable string) location {
+		loc.HideResponseHeaders, loc.AddResponseHeaders = generateResponseHeaders(
+			rule.Filters,
+			backends,
+			backendVariable,
+			headerMaps,
+		)
+		loc.ServerTokensOff = hidesServerHeader(loc.HideResponseHeaders)
+		return loc
+	}
+
+	refs := rule.BackendRefs
 	if len(refs) == 0 {
-		return "", errors.New("empty backend refs")
+		warnings.AddWarning(source, "empty backend refs")
+		return withResponseHeaders(location{ProxyPass: generateProxyPass("")}, nil, ""), warnings
+	}
+
+	type backend struct {
+		address string
+		weight  int32
+		filters []v1alpha2.HTTPRouteFilter
+		err     error
+	}
+
+	backends := make([]backend, 0, len(refs))
+
+	for _, ref := range refs {
+		// the weight defaults to 1
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+
+		if weight == 0 {
+			continue
+		}
+
+		address, err := getBackendAddress(ref.BackendRef, source, ups)
+
+		backends = append(backends, backend{address: address, weight: weight, filters: ref.Filters, err: err})
+	}
+
+	if len(backends) == 0 {
+		return withResponseHeaders(location{Return: &returnVal{Code: http.StatusInternalServerError}}, nil, ""), warnings
+	}
+
+	responseBackends := make([]responseBackend, 0, len(backends))
+	for _, b := range backends {
+		responseBackends = append(responseBackends, responseBackend{address: b.address, filters: b.filters})
 	}
 
-	// FIXME(pleshakov): for now, we only support a single backend reference
-	ref := refs[0].BackendRef
+	if len(backends) == 1 {
+		if backends[0].err != nil {
+			warnings.AddWarning(source, backends[0].err.Error())
+		}
+
+		loc := location{ProxyPass: generateProxyPass(backends[0].address)}
+
+		return withResponseHeaders(loc, responseBackends, ""), warnings
+	}
+
+	weights := make([]int32, 0, len(backends))
+	for _, b := range backends {
+		weights = append(weights, b.weight)
+	}
+
+	percents := calculatePercents(weights)
+
+	distributions := make([]splitClientDistribution, 0, len(backends))
+
+	for i, b := range backends {
+		if b.err != nil {
+			warnings.AddWarningf(source, "%v; %s of the requests will fail", b.err, formatPercent(percents[i]))
+		}
+
+		value := b.address
+		if value == "" {
+			value = nginx502Server
+		}
+
+		// the last backend gets the rest of the requests, which includes the requests lost to the rounding
+		percent := "*"
+		if i < len(backends)-1 {
+			percent = formatPercent(percents[i])
+		}
+
+		distributions = append(distributions, splitClientDistribution{Percent: percent, Value: value})
+	}
+
+	id := ruleID{
+		source:  source,
+		ruleIdx: ruleIdx,
+	}
 
+	variable := splits.add(id, distributions)
+
+	loc := location{ProxyPass: generateProxyPass("$" + variable)}
+
+	return withResponseHeaders(loc, responseBackends, variable), warnings
+}
+
+// getBackendAddress returns the name of the upstream with the endpoints of the backend of the route source.
+// The backends in other namespaces are only resolved if a ReferenceGrant allows the route to reference them.
+func getBackendAddress(
+	ref v1alpha2.BackendRef,
+	source client.Object,
+	ups *upstreams,
+) (string, error) {
 	if ref.Kind != nil && *ref.Kind != "Service" {
 		return "", fmt.Errorf("unsupported kind %s", *ref.Kind)
 	}
 
-	ns := parentNS
+	ns := source.GetNamespace()
 	if ref.Namespace != nil {
 		ns = string(*ref.Namespace)
 	}
 
-	address, err := serviceStore.Resolve(types.NamespacedName{Namespace: ns, Name: string(ref.Name)})
+	if ref.Port == nil {
+		return "", errors.New("port is nil")
+	}
+
+	nsname := types.NamespacedName{Namespace: ns, Name: string(ref.Name)}
+
+	if !ups.isAllowed(source, nsname) {
+		return "", fmt.Errorf("service %s is in a different namespace and no ReferenceGrant allows the reference", nsname)
+	}
+
+	name, err := ups.resolve(nsname, int32(*ref.Port))
 	if err != nil {
 		return "", fmt.Errorf("service %s/%s cannot be resolved: %w", ns, ref.Name, err)
 	}
 
-	if ref.Port == nil {
-		return "", errors.New("port is nil")
+	return name, nil
+}
+
+// generateMatchLocation generates the internal location for the match from the location that passes the requests to
+// the backends.
+// The location is exact, so that no regex location can capture the internal redirects to it.
+func generateMatchLocation(path string, backendLoc location) location {
+	backendLoc.Path = "= " + path
+	backendLoc.Internal = true
+
+	return backendLoc
+}
+
+// getPrefixLocationPaths returns the paths of the location blocks for a prefix path, including the modifiers of
+// the locations.
+// The Gateway API matches a prefix element by element, so the prefix /foo matches /foo and /foo/bar, but not /foobar.
+// Unlike a plain NGINX location /foo, the exact location /foo and the prefix location /foo/ respect the boundaries of
+// the path elements. Because the exact location handles /foo, NGINX doesn't redirect /foo to /foo/.
+// NGINX gives the exact locations precedence over the prefix ones, as required by the Gateway API.
+// The ^~ modifier makes NGINX skip the regex locations when the prefix location is the longest matching prefix, so
+// that a regex rule like /.* doesn't shadow a prefix rule. The root prefix stays a plain location, so that the regex
+// rules still apply to the paths that no other prefix rule matches.
+// prefixCoversPath returns true if the prefix matches the path. Like the prefix locations, the prefix matches the paths
+// element by element.
+func prefixCoversPath(prefix string, path string) bool {
+	return prefix == "/" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+func getPrefixLocationPaths(path string) []string {
+	if path == "/" {
+		return []string{path}
 	}
+	return []string{"= " + path, "^~ " + path + "/"}
+}
 
-	return fmt.Sprintf("%s:%d", address, *ref.Port), nil
+// nginxStringEscaper escapes a string for a quoted NGINX string. NGINX unescapes '\\' and '\"' in quoted strings, so we
+// escape them to preserve the string.
+var nginxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quoteRegex quotes the regex for the NGINX configuration, so that the characters like '{', ';' and whitespace don't
+// break the configuration.
+func quoteRegex(regex string) string {
+	return `"` + nginxStringEscaper.Replace(regex) + `"`
 }
 
-func generateMatchLocation(path, address string) location {
-	return location{
-		Path:      path,
-		ProxyPass: generateProxyPass(address),
-		Internal:  true,
+// generateProxySetHeaders generates the headers of the proxied requests from the RequestHeaderModifier filters and
+// the hostname of the URLRewrite filter of the rule. It returns nil if the rule doesn't modify any headers, so that
+// the location uses the default headers.
+// The names and the values of the headers are validated when the graph is built.
+// As required by the Gateway API, only the first modification of a header (case-insensitive) is applied.
+func generateProxySetHeaders(filters []v1alpha2.HTTPRouteFilter) []httpHeader {
+	var headers []httpHeader
+	modified := make(map[string]struct{})
+
+	addHeader := func(name string, value string) {
+		lowerName := strings.ToLower(name)
+		if _, exist := modified[lowerName]; exist {
+			return
+		}
+		modified[lowerName] = struct{}{}
+
+		headers = append(headers, httpHeader{Name: name, Value: value})
+	}
+
+	// the hostname of the URLRewrite filter takes precedence over the Host header of the RequestHeaderModifier filter
+	for _, f := range filters {
+		if f.Type == v1beta1.HTTPRouteFilterURLRewrite && f.URLRewrite != nil && f.URLRewrite.Hostname != nil {
+			addHeader("Host", string(*f.URLRewrite.Hostname))
+		}
+	}
+
+	for _, f := range filters {
+		if f.Type != v1beta1.HTTPRouteFilterRequestHeaderModifier || f.RequestHeaderModifier == nil {
+			continue
+		}
+
+		for _, h := range f.RequestHeaderModifier.Set {
+			addHeader(string(h.Name), nginxStringEscaper.Replace(h.Value))
+		}
+
+		for _, h := range f.RequestHeaderModifier.Add {
+			addHeader(string(h.Name), "${"+getAddHeaderVariable(string(h.Name))+"}"+nginxStringEscaper.Replace(h.Value))
+		}
+
+		// NGINX doesn't pass a header with an empty value
+		for _, name := range f.RequestHeaderModifier.Remove {
+			addHeader(name, "")
+		}
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+
+	// NGINX sets the Host header of the proxied requests to the address of the backend unless the location sets it
+	if _, exist := modified["host"]; !exist {
+		headers = append([]httpHeader{{Name: "Host", Value: "$host"}}, headers...)
+	}
+
+	return headers
+}
+
+// createAddHeaderMaps creates the maps for the headers that the RequestHeaderModifier filters of the rules add values
+// to. The variable of a map holds the value of the header of the request followed by a comma, or an empty string if
+// the request doesn't have the header, so that the added value can be appended to it.
+func createAddHeaderMaps(conf state.Configuration) []nginxMap {
+	servers := make([]state.HTTPServer, 0, len(conf.HTTPServers)+len(conf.SSLServers))
+	servers = append(servers, conf.HTTPServers...)
+	servers = append(servers, conf.SSLServers...)
+
+	var maps []nginxMap
+	seen := make(map[string]struct{})
+
+	for _, s := range servers {
+		for _, rule := range s.PathRules {
+			for _, r := range rule.MatchRules {
+				for _, name := range getAddedHeaderNames(r.Source.Spec.Rules[r.RuleIdx].Filters) {
+					variable := getAddHeaderVariable(name)
+					if _, exist := seen[variable]; exist {
+						continue
+					}
+					seen[variable] = struct{}{}
+
+					// NGINX exposes the header as the variable $http_<name>, where the name is lowercased and
+					// '-' is replaced with '_'
+					source := "$http_" + strings.ReplaceAll(strings.ToLower(name), "-", "_")
+
+					maps = append(maps, nginxMap{
+						Source:   source,
+						Variable: variable,
+						Parameters: []mapParameter{
+							{Value: "default", Result: `""`},
+							{Value: "~.+", Result: `"` + source + `,"`},
+						},
+					})
+				}
+			}
+		}
+	}
+
+	return maps
+}
+
+// getAddedHeaderNames returns the names of the headers that the RequestHeaderModifier filters add values to.
+func getAddedHeaderNames(filters []v1alpha2.HTTPRouteFilter) []string {
+	var names []string
+
+	for _, f := range filters {
+		if f.Type != v1beta1.HTTPRouteFilterRequestHeaderModifier || f.RequestHeaderModifier == nil {
+			continue
+		}
+
+		for _, h := range f.RequestHeaderModifier.Add {
+			names = append(names, string(h.Name))
+		}
 	}
+
+	return names
 }
 
-func createPathForMatch(path string, routeIdx int) string {
-	return fmt.Sprintf("%s_route%d", path, routeIdx)
+// getAddHeaderVariable returns the name of the variable of the map for the header that a RequestHeaderModifier filter
+// adds a value to. The header names consist of letters, digits and '-', so the name is unique for the header name
+// (case-insensitive).
+func getAddHeaderVariable(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "-", "_") + "_header_var"
+}
+
+// createPathForMatch creates the path of the internal location for the match.
+// The path includes the path type, so that the internal locations of the exact and the prefix rules for the same path
+// don't collide.
+// A regex path is not a valid URI, so the path for a regex rule is created from the index of the rule instead.
+func createPathForMatch(path string, pathType state.PathType, pathRuleIdx, routeIdx int) string {
+	switch pathType {
+	case state.PathTypeExact:
+		return fmt.Sprintf("%s_exact_route%d", path, routeIdx)
+	case state.PathTypeRegex:
+		return fmt.Sprintf("/_regex%d_route%d", pathRuleIdx, routeIdx)
+	default:
+		return fmt.Sprintf("%s_route%d", path, routeIdx)
+	}
 }
 
 // httpMatch is an internal representation of an HTTPRouteMatch.
@@ -172,8 +978,12 @@ type httpMatch struct {
 	Method v1alpha2.HTTPMethod `json:"method,omitempty"`
 	// Headers is a list of HTTPHeaders name value pairs with the format "{name}:{value}".
 	Headers []string `json:"headers,omitempty"`
+	// HeadersRegex is a list of HTTPHeaders name regex pairs with the format "{name}:{regex}".
+	HeadersRegex []string `json:"headersRegex,omitempty"`
 	// QueryParams is a list of HTTPQueryParams name value pairs with the format "{name}={value}".
 	QueryParams []string `json:"params,omitempty"`
+	// QueryParamsRegex is a list of HTTPQueryParams name regex pairs with the format "{name}={regex}".
+	QueryParamsRegex []string `json:"paramsRegex,omitempty"`
 	// RedirectPath is the path to redirect the request to if the request satisfies the match conditions.
 	RedirectPath string `json:"redirectPath,omitempty"`
 }
@@ -194,33 +1004,43 @@ func createHTTPMatch(match v1alpha2.HTTPRouteMatch, redirectPath string) httpMat
 
 	if match.Headers != nil {
 		headers := make([]string, 0, len(match.Headers))
+		var headersRegex []string
 		headerNames := make(map[string]struct{})
 
-		// FIXME(kate-osborn): For now we only support type "Exact".
+		// The types of the matches are validated when the graph is built, so a route with an unsupported type
+		// never reaches the generator.
 		for _, h := range match.Headers {
-			if *h.Type == v1alpha2.HeaderMatchExact {
-				// duplicate header names are not permitted by the spec
-				// only configure the first entry for every header name (case-insensitive)
-				lowerName := strings.ToLower(string(h.Name))
-				if _, ok := headerNames[lowerName]; !ok {
-					headers = append(headers, createHeaderKeyValString(h))
-					headerNames[lowerName] = struct{}{}
-				}
+			// duplicate header names are not permitted by the spec
+			// only configure the first entry for every header name (case-insensitive)
+			lowerName := strings.ToLower(string(h.Name))
+			if _, ok := headerNames[lowerName]; ok {
+				continue
+			}
+			headerNames[lowerName] = struct{}{}
+
+			if h.Type != nil && *h.Type == v1beta1.HeaderMatchRegularExpression {
+				headersRegex = append(headersRegex, createHeaderKeyValString(h))
+			} else {
+				headers = append(headers, createHeaderKeyValString(h))
 			}
 		}
 		hm.Headers = headers
+		hm.HeadersRegex = headersRegex
 	}
 
 	if match.QueryParams != nil {
 		params := make([]string, 0, len(match.QueryParams))
+		var paramsRegex []string
 
-		// FIXME(kate-osborn): For now we only support type "Exact".
 		for _, p := range match.QueryParams {
-			if *p.Type == v1alpha2.QueryParamMatchExact {
+			if p.Type != nil && *p.Type == v1beta1.QueryParamMatchRegularExpression {
+				paramsRegex = append(paramsRegex, createQueryParamKeyValString(p))
+			} else {
 				params = append(params, createQueryParamKeyValString(p))
 			}
 		}
 		hm.QueryParams = params
+		hm.QueryParamsRegex = paramsRegex
 	}
 
 	return hm
@@ -3,18 +3,148 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
+func TestGenerateMain(t *testing.T) {
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	conf := state.Configuration{
+		GatewayConfig: &nginxgwv1alpha1.GatewayConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec: nginxgwv1alpha1.GatewayConfigSpec{
+				Worker: &nginxgwv1alpha1.Worker{
+					Processes: helpers.GetIntPointer(2),
+				},
+				HTTP: &nginxgwv1alpha1.HTTP{
+					AccessLogs: []nginxgwv1alpha1.AccessLog{
+						{Format: `$remote_addr "$request" $status`, Destination: "stdout"},
+					},
+				},
+			},
+		},
+	}
+
+	cfg, warnings := generator.GenerateMain(conf)
+
+	if len(warnings) > 0 {
+		t.Errorf("GenerateMain() returned unexpected warnings: %v", warnings)
+	}
+
+	// we only do a sanity check of the settings of the GatewayConfig and the includes here.
+	for _, expected := range []string{
+		"worker_processes 2;",
+		"pid /etc/nginx/nginx.pid;",
+		`log_format access_log_0 "$remote_addr \"$request\" $status";`,
+		`access_log "/dev/stdout" access_log_0;`,
+		"include /etc/nginx/conf.d/*.conf;",
+		"js_import /usr/lib/nginx/modules/njs/httpmatches.js;",
+		"include /etc/nginx/stream-conf.d/*.conf;",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("GenerateMain() generated config without %q", expected)
+		}
+	}
+}
+
+func TestGenerateMainConfig(t *testing.T) {
+	createGatewayConfig := func(processes *int, accessLogs ...nginxgwv1alpha1.AccessLog) *nginxgwv1alpha1.GatewayConfig {
+		return &nginxgwv1alpha1.GatewayConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec: nginxgwv1alpha1.GatewayConfigSpec{
+				Worker: &nginxgwv1alpha1.Worker{
+					Processes: processes,
+				},
+				HTTP: &nginxgwv1alpha1.HTTP{
+					AccessLogs: accessLogs,
+				},
+			},
+		}
+	}
+
+	validConfig := createGatewayConfig(
+		helpers.GetIntPointer(4),
+		nginxgwv1alpha1.AccessLog{Format: "$remote_addr $status", Destination: "stdout"},
+		nginxgwv1alpha1.AccessLog{Format: `$request "\d"`, Destination: "/var/log/nginx/access.log"},
+	)
+	invalidConfig := createGatewayConfig(
+		helpers.GetIntPointer(0),
+		nginxgwv1alpha1.AccessLog{Destination: "stdout"},
+		nginxgwv1alpha1.AccessLog{Format: "$status", Destination: "stderr"},
+	)
+
+	tests := []struct {
+		gcfg             *nginxgwv1alpha1.GatewayConfig
+		expected         mainConfig
+		expectedWarnings Warnings
+		msg              string
+	}{
+		{
+			gcfg:             nil,
+			expected:         mainConfig{},
+			expectedWarnings: Warnings{},
+			msg:              "no GatewayConfig",
+		},
+		{
+			gcfg:             &nginxgwv1alpha1.GatewayConfig{},
+			expected:         mainConfig{},
+			expectedWarnings: Warnings{},
+			msg:              "empty GatewayConfig",
+		},
+		{
+			gcfg: validConfig,
+			expected: mainConfig{
+				WorkerProcesses: "4",
+				AccessLogs: []accessLog{
+					{Name: "access_log_0", Format: "$remote_addr $status", Destination: "/dev/stdout"},
+					{Name: "access_log_1", Format: `$request \"\\d\"`, Destination: "/var/log/nginx/access.log"},
+				},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "valid GatewayConfig",
+		},
+		{
+			gcfg: invalidConfig,
+			expected: mainConfig{
+				AccessLogs: []accessLog{
+					{Name: "access_log_1", Format: "$status", Destination: "/dev/stderr"},
+				},
+			},
+			expectedWarnings: Warnings{
+				invalidConfig: []string{
+					"worker processes must be positive, got 0; the default is used",
+					"access log 0 must have a format and a destination; it is ignored",
+				},
+			},
+			msg: "invalid settings",
+		},
+	}
+
+	for _, test := range tests {
+		result, warnings := generateMainConfig(test.gcfg)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateMainConfig() %q mismatch on config (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings); diff != "" {
+			t.Errorf("generateMainConfig() %q mismatch on warnings (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestGenerateForHost(t *testing.T) {
 	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
 
@@ -22,6 +152,20 @@ func TestGenerateForHost(t *testing.T) {
 		HTTPServers: []state.HTTPServer{
 			{
 				Hostname: "example.com",
+				Port:     80,
+			},
+			{
+				Hostname: "example.com",
+				Port:     8080,
+			},
+		},
+		SSLServers: []state.HTTPServer{
+			{
+				Hostname: "example.com",
+				Port:     443,
+				SSL: &state.SSL{
+					CertificatePath: "/etc/nginx/secrets/test_secret.pem",
+				},
 			},
 		},
 	}
@@ -34,6 +178,297 @@ func TestGenerateForHost(t *testing.T) {
 	if len(warnings) > 0 {
 		t.Errorf("Generate() returned unexpected warnings: %v", warnings)
 	}
+
+	// we only do a sanity check of the listen directives and the SSL servers here.
+	for _, expected := range []string{
+		"listen 80 default_server;",
+		"listen 80;",
+		"listen 8080 default_server;",
+		"listen 8080;",
+		"listen 443 ssl http2 default_server;",
+		"listen 443 ssl http2;",
+		"ssl_reject_handshake on;",
+		"ssl_certificate /etc/nginx/secrets/test_secret.pem;",
+		"ssl_certificate_key /etc/nginx/secrets/test_secret.pem;",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("Generate() generated config without %q", expected)
+		}
+	}
+}
+
+func TestGenerateStream(t *testing.T) {
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns([]state.Endpoint{{Address: "10.0.0.1", Port: 8443}}, nil)
+
+	generator := NewGeneratorImpl(fakeServiceStore)
+
+	tr := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route",
+		},
+	}
+
+	conf := state.Configuration{
+		TLSPassthroughServers: []state.TLSPassthroughServer{
+			{
+				Hostname: "example.com",
+				Port:     443,
+				BackendRef: v1alpha2.BackendRef{
+					BackendObjectReference: v1alpha2.BackendObjectReference{
+						Name: "app",
+						Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(443)),
+					},
+				},
+				Source: tr,
+			},
+		},
+		L4Servers: []state.L4Server{
+			{
+				Port:     53,
+				Protocol: v1beta1.UDPProtocolType,
+				BackendRef: v1alpha2.BackendRef{
+					BackendObjectReference: v1alpha2.BackendObjectReference{
+						Name: "dns",
+						Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(53)),
+					},
+				},
+				Source: &v1alpha2.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "dns",
+					},
+				},
+			},
+		},
+	}
+
+	cfg, warnings := generator.GenerateStream(conf)
+
+	if len(warnings) > 0 {
+		t.Errorf("GenerateStream() returned unexpected warnings: %v", warnings)
+	}
+
+	// we only do a sanity check of the stream server here.
+	for _, expected := range []string{
+		"upstream test_app_443 {",
+		"server 10.0.0.1:8443;",
+		"map $ssl_preread_server_name $tls_passthrough_backend_443 {",
+		"hostnames;",
+		"example.com test_app_443;",
+		"listen 443;",
+		"ssl_preread on;",
+		"proxy_pass $tls_passthrough_backend_443;",
+		"upstream test_dns_53 {",
+		"listen 53 udp;",
+		"proxy_pass test_dns_53;",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("GenerateStream() generated config without %q", expected)
+		}
+	}
+}
+
+func TestGenerateTLSPassthroughServers(t *testing.T) {
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName, _ int32) ([]state.Endpoint, error) {
+		if nsname.Name == "unknown" {
+			return nil, errors.New("service doesn't exist")
+		}
+		return []state.Endpoint{{Address: "10.0.0.1", Port: 8443}}, nil
+	}
+
+	tr := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route",
+		},
+	}
+
+	createServer := func(hostname string, port int32, backend string) state.TLSPassthroughServer {
+		return state.TLSPassthroughServer{
+			Hostname: hostname,
+			Port:     port,
+			BackendRef: v1alpha2.BackendRef{
+				BackendObjectReference: v1alpha2.BackendObjectReference{
+					Name: v1alpha2.ObjectName(backend),
+					Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(443)),
+				},
+			},
+			Source: tr,
+		}
+	}
+
+	tlsServers := []state.TLSPassthroughServer{
+		createServer("", 443, "app"),
+		createServer("*.example.com", 443, "unknown"),
+		createServer("default", 443, "app"),
+		createServer("foo.example.com", 8443, "app"),
+	}
+
+	expectedServers := []streamServer{
+		{
+			Port:       443,
+			SSLPreread: true,
+			ProxyPass:  "$tls_passthrough_backend_443",
+		},
+		{
+			Port:       8443,
+			SSLPreread: true,
+			ProxyPass:  "$tls_passthrough_backend_8443",
+		},
+	}
+
+	expectedMaps := []nginxMap{
+		{
+			Source:    "$ssl_preread_server_name",
+			Variable:  "tls_passthrough_backend_443",
+			Hostnames: true,
+			Parameters: []mapParameter{
+				{Value: "default", Result: "test_app_443"},
+				{Value: "*.example.com", Result: `""`},
+				{Value: `\default`, Result: "test_app_443"},
+			},
+		},
+		{
+			Source:    "$ssl_preread_server_name",
+			Variable:  "tls_passthrough_backend_8443",
+			Hostnames: true,
+			Parameters: []mapParameter{
+				{Value: "foo.example.com", Result: "test_app_443"},
+			},
+		},
+	}
+
+	expectedWarnings := Warnings{
+		tr: []string{
+			"service test/unknown cannot be resolved: service doesn't exist; the connections will be closed",
+		},
+	}
+
+	servers, maps, warnings := generateTLSPassthroughServers(tlsServers, newStreamUpstreams(fakeServiceStore, nil))
+
+	if diff := cmp.Diff(expectedServers, servers); diff != "" {
+		t.Errorf("generateTLSPassthroughServers() mismatch on servers (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedMaps, maps); diff != "" {
+		t.Errorf("generateTLSPassthroughServers() mismatch on maps (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateTLSPassthroughServers() mismatch on warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateL4Servers(t *testing.T) {
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName, _ int32) ([]state.Endpoint, error) {
+		if nsname.Name == "unknown" {
+			return nil, errors.New("service doesn't exist")
+		}
+		return []state.Endpoint{{Address: "10.0.0.1", Port: 5353}}, nil
+	}
+
+	tcpRoute := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "tcp",
+		},
+	}
+	udpRoute := &v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "udp",
+		},
+	}
+
+	createServer := func(
+		port int32,
+		protocol v1alpha2.ProtocolType,
+		backend string,
+		source client.Object,
+	) state.L4Server {
+		return state.L4Server{
+			Port:     port,
+			Protocol: protocol,
+			BackendRef: v1alpha2.BackendRef{
+				BackendObjectReference: v1alpha2.BackendObjectReference{
+					Name: v1alpha2.ObjectName(backend),
+					Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(53)),
+				},
+			},
+			Source: source,
+		}
+	}
+
+	l4Servers := []state.L4Server{
+		createServer(53, v1beta1.TCPProtocolType, "dns", tcpRoute),
+		createServer(53, v1beta1.UDPProtocolType, "dns", udpRoute),
+		createServer(5432, v1beta1.TCPProtocolType, "unknown", tcpRoute),
+	}
+
+	expectedServers := []streamServer{
+		{
+			Port:      53,
+			ProxyPass: "test_dns_53",
+		},
+		{
+			Port:      53,
+			UDP:       true,
+			ProxyPass: "test_dns_53",
+		},
+	}
+
+	expectedWarnings := Warnings{
+		tcpRoute: []string{
+			"service test/unknown cannot be resolved: service doesn't exist; " +
+				"NGINX will not accept the TCP connections for port 5432",
+		},
+	}
+
+	servers, warnings := generateL4Servers(l4Servers, newStreamUpstreams(fakeServiceStore, nil))
+
+	if diff := cmp.Diff(expectedServers, servers); diff != "" {
+		t.Errorf("generateL4Servers() mismatch on servers (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateL4Servers() mismatch on warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSSL(t *testing.T) {
+	host := state.HTTPServer{
+		Hostname: "example.com",
+		Port:     443,
+		SSL: &state.SSL{
+			CertificatePath: "/etc/nginx/secrets/test_secret.pem",
+		},
+	}
+
+	expected := server{
+		ServerName: "example.com",
+		Port:       443,
+		SSL: &ssl{
+			Certificate:    "/etc/nginx/secrets/test_secret.pem",
+			CertificateKey: "/etc/nginx/secrets/test_secret.pem",
+		},
+		Locations: []location{},
+	}
+
+	result, warnings := generate(
+		host,
+		newUpstreams(&statefakes.FakeServiceStore{}, nil),
+		newSplitClients(),
+		newURIRewrites(),
+		newResponseHeaderMaps(),
+	)
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("generate() returned unexpected warnings: %v", warnings)
+	}
 }
 
 func TestGenerate(t *testing.T) {
@@ -53,13 +488,13 @@ func TestGenerate(t *testing.T) {
 							Path: &v1alpha2.HTTPPathMatch{
 								Value: helpers.GetStringPointer("/"),
 							},
-							Method: helpers.GetHTTPMethodPointer(v1alpha2.HTTPMethodPost),
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPost),
 						},
 						{
 							Path: &v1alpha2.HTTPPathMatch{
 								Value: helpers.GetStringPointer("/"),
 							},
-							Method: helpers.GetHTTPMethodPointer(v1alpha2.HTTPMethodPatch),
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPatch),
 						},
 						{
 							Path: &v1alpha2.HTTPPathMatch{
@@ -85,32 +520,32 @@ func TestGenerate(t *testing.T) {
 							Path: &v1alpha2.HTTPPathMatch{
 								Value: helpers.GetStringPointer("/test"),
 							},
-							Method: helpers.GetHTTPMethodPointer(v1alpha2.HTTPMethodGet),
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
 							Headers: []v1alpha2.HTTPHeaderMatch{
 								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 									Name:  "Version",
 									Value: "V1",
 								},
 								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 									Name:  "test",
 									Value: "foo",
 								},
 								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 									Name:  "my-header",
 									Value: "my-value",
 								},
 							},
 							QueryParams: []v1alpha2.HTTPQueryParamMatch{
 								{
-									Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchExact),
+									Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
 									Name:  "GrEat", // query names and values should not be normalized to lowercase
 									Value: "EXAMPLE",
 								},
 								{
-									Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchExact),
+									Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
 									Name:  "test",
 									Value: "foo=bar",
 								},
@@ -139,368 +574,2017 @@ func TestGenerate(t *testing.T) {
 						},
 					},
 				},
-			},
-		},
-	}
-
-	host := state.HTTPServer{
-		Hostname: "example.com",
-		PathRules: []state.PathRule{
-			{
-				Path: "/",
-				MatchRules: []state.MatchRule{
-					{
-						MatchIdx: 0,
-						RuleIdx:  0,
-						Source:   hr,
-					},
-					{
-						MatchIdx: 1,
-						RuleIdx:  0,
-						Source:   hr,
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+								Value: helpers.GetStringPointer("/path-only"),
+							},
+						},
 					},
-					{
-						MatchIdx: 2,
-						RuleIdx:  0,
-						Source:   hr,
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name:      "service2",
+									Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+									Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
 					},
 				},
-			},
-			{
-				Path: "/test",
-				MatchRules: []state.MatchRule{
-					{
-						MatchIdx: 0,
-						RuleIdx:  1,
-						Source:   hr,
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+								Value: helpers.GetStringPointer("/test"),
+							},
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPost),
+						},
 					},
-				},
-			},
-			{
-				Path: "/path-only",
-				MatchRules: []state.MatchRule{
-					{
-						MatchIdx: 0,
-						RuleIdx:  2,
-						Source:   hr,
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name:      "service2",
+									Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+									Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+								Value: helpers.GetStringPointer(`^/regex/[0-9]+$`),
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name:      "service2",
+									Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+									Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+								Value: helpers.GetStringPointer(`^/regex`),
+							},
+							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPost),
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name:      "service2",
+									Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+									Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	host := state.HTTPServer{
+		Hostname: "example.com",
+		PathRules: []state.PathRule{
+			{
+				Path:     "/",
+				PathType: state.PathTypePrefix,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  0,
+						Source:   hr,
+					},
+					{
+						MatchIdx: 1,
+						RuleIdx:  0,
+						Source:   hr,
+					},
+					{
+						MatchIdx: 2,
+						RuleIdx:  0,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     "/test",
+				PathType: state.PathTypePrefix,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  1,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     "/path-only",
+				PathType: state.PathTypePrefix,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  2,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     "/path-only",
+				PathType: state.PathTypeExact,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  3,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     "/test",
+				PathType: state.PathTypeExact,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  4,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     `^/regex/[0-9]+$`,
+				PathType: state.PathTypeRegex,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  5,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     `^/regex`,
+				PathType: state.PathTypeRegex,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  6,
+						Source:   hr,
+					},
+				},
+			},
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns([]state.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+
+	expectedMatchString := func(m []httpMatch) string {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Errorf("error marshaling test match: %v", err)
+		}
+		return string(b)
+	}
+
+	slashMatches := []httpMatch{
+		{Method: v1beta1.HTTPMethodPost, RedirectPath: "/_route0"},
+		{Method: v1beta1.HTTPMethodPatch, RedirectPath: "/_route1"},
+		{Any: true, RedirectPath: "/_route2"},
+	}
+	testMatches := []httpMatch{
+		{
+			Method:       v1beta1.HTTPMethodGet,
+			Headers:      []string{"Version:V1", "test:foo", "my-header:my-value"},
+			QueryParams:  []string{"GrEat=EXAMPLE", "test=foo=bar"},
+			RedirectPath: "/test_route0",
+		},
+	}
+	// the exact location for /test evaluates the matches of the exact rule first, followed by the matches of
+	// the prefix rules that cover /test from the longest prefix to the shortest one
+	exactTestMatches := []httpMatch{
+		{
+			Method:       v1beta1.HTTPMethodPost,
+			RedirectPath: "/test_exact_route0",
+		},
+		testMatches[0],
+		slashMatches[0],
+		slashMatches[1],
+		slashMatches[2],
+	}
+	pathOnlyMatches := []httpMatch{
+		{
+			Any:          true,
+			RedirectPath: "/path-only_route0",
+		},
+	}
+	exactPathOnlyMatches := []httpMatch{
+		{
+			Any:          true,
+			RedirectPath: "/path-only_exact_route0",
+		},
+		pathOnlyMatches[0],
+		slashMatches[0],
+		slashMatches[1],
+		slashMatches[2],
+	}
+	regexMatches := []httpMatch{
+		{
+			Method:       v1beta1.HTTPMethodPost,
+			RedirectPath: "/_regex6_route0",
+		},
+	}
+
+	const (
+		service1Addr = "http://test_service1_80"
+		service2Addr = "http://test_service2_80"
+	)
+
+	expected := server{
+		ServerName: "example.com",
+		Locations: []location{
+			{
+				Path:      "= /_route0",
+				Internal:  true,
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:      "= /_route1",
+				Internal:  true,
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:      "= /_route2",
+				Internal:  true,
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:         "/",
+				HTTPMatchVar: expectedMatchString(slashMatches),
+			},
+			{
+				Path:      "= /test_route0",
+				Internal:  true,
+				ProxyPass: "http://" + nginx502Server,
+			},
+			{
+				Path:         "^~ /test/",
+				HTTPMatchVar: expectedMatchString(testMatches),
+			},
+			{
+				Path:      "= /path-only_route0",
+				Internal:  true,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path:         "^~ /path-only/",
+				HTTPMatchVar: expectedMatchString(pathOnlyMatches),
+			},
+			{
+				Path:      "= /path-only_exact_route0",
+				Internal:  true,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path:         "= /path-only",
+				HTTPMatchVar: expectedMatchString(exactPathOnlyMatches),
+			},
+			{
+				Path:      "= /test_exact_route0",
+				Internal:  true,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path:         "= /test",
+				HTTPMatchVar: expectedMatchString(exactTestMatches),
+			},
+			{
+				Path:      `~ "^/regex/[0-9]+$"`,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path:      "= /_regex6_route0",
+				Internal:  true,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path:         `~ "^/regex"`,
+				HTTPMatchVar: expectedMatchString(regexMatches),
+			},
+		},
+	}
+	expectedWarnings := Warnings{
+		hr: []string{"empty backend refs"},
+	}
+
+	ups := newUpstreams(fakeServiceStore, nil)
+
+	result, warnings := generate(host, ups, newSplitClients(), newURIRewrites(), newResponseHeaderMaps())
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generate() mismatch on warnings (-want +got):\n%s", diff)
+	}
+
+	// the rules that reference the same backend share the upstream
+	expectedUpstreams := []upstream{
+		{Name: "test_service1_80", Servers: []upstreamServer{{Address: "10.0.0.1:8080"}}, Keepalive: upstreamKeepalive},
+		{Name: "test_service2_80", Servers: []upstreamServer{{Address: "10.0.0.1:8080"}}, Keepalive: upstreamKeepalive},
+	}
+	if diff := cmp.Diff(expectedUpstreams, ups.blocks); diff != "" {
+		t.Errorf("generate() mismatch on upstreams (-want +got):\n%s", diff)
+	}
+}
+
+func TestGeneratePrefixLocations(t *testing.T) {
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+						},
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/foo"),
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	host := state.HTTPServer{
+		Hostname: "example.com",
+		PathRules: []state.PathRule{
+			{
+				Path:     "/",
+				PathType: state.PathTypePrefix,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 0,
+						RuleIdx:  0,
+						Source:   hr,
+					},
+				},
+			},
+			{
+				Path:     "/foo",
+				PathType: state.PathTypePrefix,
+				MatchRules: []state.MatchRule{
+					{
+						MatchIdx: 1,
+						RuleIdx:  0,
+						Source:   hr,
+					},
+				},
+			},
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns([]state.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+
+	const backendAddr = "http://test_service1_80"
+
+	// the prefix /foo must not match /foobar, which is served by the root location instead
+	expected := server{
+		ServerName: "example.com",
+		Locations: []location{
+			{
+				Path:      "/",
+				ProxyPass: backendAddr,
+			},
+			{
+				Path:      "= /foo",
+				ProxyPass: backendAddr,
+			},
+			{
+				Path:      "^~ /foo/",
+				ProxyPass: backendAddr,
+			},
+		},
+	}
+
+	result, warnings := generate(
+		host,
+		newUpstreams(fakeServiceStore, nil),
+		newSplitClients(),
+		newURIRewrites(),
+		newResponseHeaderMaps(),
+	)
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("generate() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateExactLocationWithCoveringPrefixes(t *testing.T) {
+	createBackendRefs := func(name string) []v1alpha2.HTTPBackendRef {
+		return []v1alpha2.HTTPBackendRef{
+			{
+				BackendRef: v1alpha2.BackendRef{
+					BackendObjectReference: v1alpha2.BackendObjectReference{
+						Name: v1alpha2.ObjectName(name),
+						Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+					},
+				},
+			},
+		}
+	}
+
+	createPathMatch := func(path string, pathType v1alpha2.PathMatchType) v1alpha2.HTTPRouteMatch {
+		return v1alpha2.HTTPRouteMatch{
+			Path: &v1alpha2.HTTPPathMatch{
+				Type:  helpers.GetPathMatchTypePointer(pathType),
+				Value: helpers.GetStringPointer(path),
+			},
+		}
+	}
+
+	exactMatch := createPathMatch("/foo/bar", v1beta1.PathMatchExact)
+	exactMatch.Headers = []v1alpha2.HTTPHeaderMatch{
+		{
+			Name:  "version",
+			Value: "v2",
+		},
+	}
+
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches:     []v1alpha2.HTTPRouteMatch{exactMatch},
+					BackendRefs: createBackendRefs("service2"),
+				},
+				{
+					Matches:     []v1alpha2.HTTPRouteMatch{createPathMatch("/foo", v1beta1.PathMatchPathPrefix)},
+					BackendRefs: createBackendRefs("service1"),
+				},
+				{
+					Matches:     []v1alpha2.HTTPRouteMatch{createPathMatch("/", v1beta1.PathMatchPathPrefix)},
+					BackendRefs: createBackendRefs("service1"),
+				},
+				{
+					Matches:     []v1alpha2.HTTPRouteMatch{createPathMatch("/foo/b", v1beta1.PathMatchPathPrefix)},
+					BackendRefs: createBackendRefs("service1"),
+				},
+			},
+		},
+	}
+
+	createPathRuleForRule := func(path string, pathType state.PathType, ruleIdx int) state.PathRule {
+		return state.PathRule{
+			Path:     path,
+			PathType: pathType,
+			MatchRules: []state.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  ruleIdx,
+					Source:   hr,
+				},
+			},
+		}
+	}
+
+	host := state.HTTPServer{
+		Hostname: "example.com",
+		PathRules: []state.PathRule{
+			createPathRuleForRule("/", state.PathTypePrefix, 2),
+			createPathRuleForRule("/foo", state.PathTypePrefix, 1),
+			createPathRuleForRule("/foo/b", state.PathTypePrefix, 3),
+			createPathRuleForRule("/foo/bar", state.PathTypeExact, 0),
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveReturns([]state.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+
+	marshalMatches := func(m []httpMatch) string {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Errorf("error marshaling test match: %v", err)
+		}
+		return string(b)
+	}
+
+	slashMatch := httpMatch{Any: true, RedirectPath: "/_route0"}
+	fooMatch := httpMatch{Any: true, RedirectPath: "/foo_route0"}
+
+	const (
+		service1Addr = "http://test_service1_80"
+		service2Addr = "http://test_service2_80"
+	)
+
+	// the requests for /foo/bar without the header fall back to the prefix /foo and then to the prefix /.
+	// The prefix /foo/b doesn't cover /foo/bar
+	expected := server{
+		ServerName: "example.com",
+		Locations: []location{
+			{
+				Path:      "= /_route0",
+				Internal:  true,
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:         "/",
+				HTTPMatchVar: marshalMatches([]httpMatch{slashMatch}),
+			},
+			{
+				Path:      "= /foo_route0",
+				Internal:  true,
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:         "= /foo",
+				HTTPMatchVar: marshalMatches([]httpMatch{fooMatch}),
+			},
+			{
+				Path:         "^~ /foo/",
+				HTTPMatchVar: marshalMatches([]httpMatch{fooMatch}),
+			},
+			{
+				Path:      "= /foo/b",
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:      "^~ /foo/b/",
+				ProxyPass: service1Addr,
+			},
+			{
+				Path:      "= /foo/bar_exact_route0",
+				Internal:  true,
+				ProxyPass: service2Addr,
+			},
+			{
+				Path: "= /foo/bar",
+				HTTPMatchVar: marshalMatches([]httpMatch{
+					{Headers: []string{"version:v2"}, RedirectPath: "/foo/bar_exact_route0"},
+					fooMatch,
+					slashMatch,
+				}),
+			},
+		},
+	}
+
+	result, warnings := generate(
+		host,
+		newUpstreams(fakeServiceStore, nil),
+		newSplitClients(),
+		newURIRewrites(),
+		newResponseHeaderMaps(),
+	)
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("generate() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestPrefixCoversPath(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		path     string
+		expected bool
+	}{
+		{prefix: "/", path: "/foo", expected: true},
+		{prefix: "/foo", path: "/foo", expected: true},
+		{prefix: "/foo", path: "/foo/bar", expected: true},
+		{prefix: "/foo", path: "/foobar", expected: false},
+		{prefix: "/foo/bar", path: "/foo", expected: false},
+	}
+
+	for _, test := range tests {
+		result := prefixCoversPath(test.prefix, test.path)
+		if result != test.expected {
+			t.Errorf("prefixCoversPath() returned %v but expected %v for %q and %q", result, test.expected,
+				test.prefix, test.path)
+		}
+	}
+}
+
+func TestGenerateRegexAndPrefixLocations(t *testing.T) {
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+								Value: helpers.GetStringPointer("/.*"),
+							},
+						},
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/foo"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conf := state.Configuration{
+		HTTPServers: []state.HTTPServer{
+			{
+				Hostname: "example.com",
+				Port:     80,
+				PathRules: []state.PathRule{
+					{
+						Path:     "/.*",
+						PathType: state.PathTypeRegex,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 0,
+								RuleIdx:  0,
+								Source:   hr,
+							},
+						},
+					},
+					{
+						Path:     "/foo",
+						PathType: state.PathTypePrefix,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 1,
+								RuleIdx:  0,
+								Source:   hr,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	cfg, _ := generator.Generate(conf)
+
+	// the regex /.* must not shadow the prefix /foo, so the prefix location uses the ^~ modifier
+	for _, expected := range []string{
+		`location ~ "/.*" {`,
+		"location = /foo {",
+		"location ^~ /foo/ {",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("Generate() generated config without %q", expected)
+		}
+	}
+}
+
+func TestGenerateHTTPMatchVarWithDollar(t *testing.T) {
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+							Headers: []v1alpha2.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+									Name:  "version",
+									Value: "^v[0-9]+$",
+								},
+							},
+							QueryParams: []v1alpha2.HTTPQueryParamMatch{
+								{
+									Name:  "price",
+									Value: "$5",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conf := state.Configuration{
+		HTTPServers: []state.HTTPServer{
+			{
+				Hostname: "example.com",
+				Port:     80,
+				PathRules: []state.PathRule{
+					{
+						Path:     "/",
+						PathType: state.PathTypePrefix,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 0,
+								RuleIdx:  0,
+								Source:   hr,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	cfg, _ := generator.Generate(conf)
+
+	// '$' is escaped, so that NGINX doesn't interpret it as the start of a variable, while the JSON of the matches
+	// still decodes to the original values
+	expected := `set $http_matches "[{\"headersRegex\":[\"version:^v[0-9]+\\u0024\"],` +
+		`\"params\":[\"price=\\u00245\"],\"redirectPath\":\"/_route0\"}]";`
+
+	if !strings.Contains(string(cfg), expected) {
+		t.Errorf("Generate() generated config without %q:\n%s", expected, cfg)
+	}
+
+	var matches []httpMatch
+	if err := json.Unmarshal([]byte(createLocation("/", nil, []httpMatch{
+		{HeadersRegex: []string{"version:^v[0-9]+$"}},
+	}).HTTPMatchVar), &matches); err != nil {
+		t.Fatalf("failed to unmarshal the http matches: %v", err)
+	}
+	if matches[0].HeadersRegex[0] != "version:^v[0-9]+$" {
+		t.Errorf("createLocation() generated http matches that decode to %q", matches[0].HeadersRegex[0])
+	}
+}
+
+func TestGenerateServerHeaderRemoval(t *testing.T) {
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/"),
+							},
+						},
+					},
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+							ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+								Remove: []string{"Server"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conf := state.Configuration{
+		HTTPServers: []state.HTTPServer{
+			{
+				Hostname: "example.com",
+				Port:     80,
+				PathRules: []state.PathRule{
+					{
+						Path:     "/",
+						PathType: state.PathTypePrefix,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 0,
+								RuleIdx:  0,
+								Source:   hr,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	cfg, _ := generator.Generate(conf)
+
+	for _, expected := range []string{
+		"proxy_hide_header Server;",
+		"server_tokens off;",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("Generate() generated config without %q", expected)
+		}
+	}
+}
+
+func TestGenerateGRPCLocations(t *testing.T) {
+	// the equivalent HTTPRoute of a GRPCRoute
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+								Value: helpers.GetStringPointer("/helloworld.Greeter/SayHello"),
+							},
+						},
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Value: helpers.GetStringPointer("/helloworld.Greeter"),
+							},
+							Headers: []v1alpha2.HTTPHeaderMatch{
+								{
+									Name:  "version",
+									Value: "2",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conf := state.Configuration{
+		SSLServers: []state.HTTPServer{
+			{
+				Hostname: "example.com",
+				Port:     443,
+				SSL:      &state.SSL{CertificatePath: "/etc/nginx/secrets/test_secret.pem"},
+				PathRules: []state.PathRule{
+					{
+						Path:     "/helloworld.Greeter/SayHello",
+						PathType: state.PathTypeExact,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 0,
+								RuleIdx:  0,
+								Source:   hr,
+								GRPC:     true,
+							},
+						},
+					},
+					{
+						Path:     "/helloworld.Greeter",
+						PathType: state.PathTypePrefix,
+						MatchRules: []state.MatchRule{
+							{
+								MatchIdx: 1,
+								RuleIdx:  0,
+								Source:   hr,
+								GRPC:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generator := NewGeneratorImpl(&statefakes.FakeServiceStore{})
+
+	cfg, _ := generator.Generate(conf)
+
+	// the route doesn't have any backends, so the requests are passed to the 502 server
+	for _, expected := range []string{
+		"listen 443 ssl http2;",
+		"grpc_set_header Host $host;",
+		"grpc_pass grpc://" + nginx502Server + ";",
+		// the match location of the header match restores the URI changed by the internal redirect
+		"rewrite ^ $request_uri break;",
+	} {
+		if !strings.Contains(string(cfg), expected) {
+			t.Errorf("Generate() generated config without %q", expected)
+		}
+	}
+
+	if strings.Contains(string(cfg), "proxy_pass") {
+		t.Errorf("Generate() generated config with proxy_pass for a GRPCRoute")
+	}
+}
+
+func TestGetPorts(t *testing.T) {
+	servers := []state.HTTPServer{
+		{Hostname: "bar.example.com", Port: 80},
+		{Hostname: "foo.example.com", Port: 80},
+		{Hostname: "foo.example.com", Port: 8080},
+		{Hostname: "bar.example.com", Port: 80},
+	}
+
+	expected := []int32{80, 8080}
+
+	result := getPorts(servers)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("getPorts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateProxyPass(t *testing.T) {
+	expected := "http://10.0.0.1:80"
+
+	result := generateProxyPass("10.0.0.1:80")
+	if result != expected {
+		t.Errorf("generateProxyPass() returned %s but expected %s", result, expected)
+	}
+
+	expected = "http://" + nginx502Server
+
+	result = generateProxyPass("")
+	if result != expected {
+		t.Errorf("generateProxyPass() returned %s but expected %s", result, expected)
+	}
+}
+
+func TestGenerateBackendLocation(t *testing.T) {
+	createBackendRef := func(name string, weight *int32) v1alpha2.HTTPBackendRef {
+		return v1alpha2.HTTPBackendRef{
+			BackendRef: v1alpha2.BackendRef{
+				BackendObjectReference: v1alpha2.BackendObjectReference{
+					Name: v1alpha2.ObjectName(name),
+					Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+				},
+				Weight: weight,
+			},
+		}
+	}
+
+	createRoute := func(refs ...v1alpha2.HTTPBackendRef) *v1alpha2.HTTPRoute {
+		return &v1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "route1",
+			},
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{
+					{
+						BackendRefs: refs,
+					},
+				},
+			},
+		}
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName, _ int32) ([]state.Endpoint, error) {
+		switch nsname.Name {
+		case "service1":
+			return []state.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil
+		case "service2":
+			return []state.Endpoint{{Address: "10.0.0.2", Port: 8080}}, nil
+		default:
+			return nil, errors.New("service doesn't exist")
+		}
+	}
+
+	hrNoRefs := createRoute()
+	hrSingle := createRoute(createBackendRef("service1", nil))
+	hrZeroWeight := createRoute(
+		createBackendRef("service1", helpers.GetInt32Pointer(0)),
+		createBackendRef("service2", helpers.GetInt32Pointer(10)),
+	)
+	hrAllZeroWeights := createRoute(
+		createBackendRef("service1", helpers.GetInt32Pointer(0)),
+		createBackendRef("service2", helpers.GetInt32Pointer(0)),
+	)
+	hrWeighted := createRoute(
+		createBackendRef("service1", helpers.GetInt32Pointer(1)),
+		createBackendRef("service2", helpers.GetInt32Pointer(1)),
+		createBackendRef("service3", helpers.GetInt32Pointer(1)),
+	)
+
+	backendWithFilter := createBackendRef("service2", nil)
+	backendWithFilter.Filters = []v1alpha2.HTTPRouteFilter{
+		{
+			Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+				Set: []v1alpha2.HTTPHeader{{Name: "X-Backend", Value: "two"}},
+			},
+		},
+	}
+	hrResponseHeaders := createRoute(createBackendRef("service1", nil), backendWithFilter)
+	hrResponseHeaders.Spec.Rules[0].Filters = []v1alpha2.HTTPRouteFilter{
+		{
+			Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+				Remove: []string{"X-Powered-By"},
+			},
+		},
+	}
+
+	tests := []struct {
+		hr                   *v1alpha2.HTTPRoute
+		expected             location
+		expectedSplitClients []splitClient
+		expectedUpstreams    []upstream
+		expectedWarnings     Warnings
+		msg                  string
+	}{
+		{
+			hr: hrNoRefs,
+			expected: location{
+				ProxyPass: "http://" + nginx502Server,
+			},
+			expectedWarnings: Warnings{
+				hrNoRefs: []string{"empty backend refs"},
+			},
+			msg: "no backend refs",
+		},
+		{
+			hr: hrSingle,
+			expected: location{
+				ProxyPass: "http://test_service1_80",
+			},
+			expectedUpstreams: []upstream{
+				{Name: "test_service1_80", Servers: []upstreamServer{{Address: "10.0.0.1:8080"}}, Keepalive: upstreamKeepalive},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "single backend ref",
+		},
+		{
+			hr: hrZeroWeight,
+			expected: location{
+				ProxyPass: "http://test_service2_80",
+			},
+			expectedUpstreams: []upstream{
+				{Name: "test_service2_80", Servers: []upstreamServer{{Address: "10.0.0.2:8080"}}, Keepalive: upstreamKeepalive},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "backend ref with zero weight",
+		},
+		{
+			hr: hrAllZeroWeights,
+			expected: location{
+				Return: &returnVal{Code: 500},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "all backend refs with zero weight",
+		},
+		{
+			hr: hrWeighted,
+			expected: location{
+				ProxyPass: "http://$backend_group_0",
+			},
+			expectedSplitClients: []splitClient{
+				{
+					VariableName: "backend_group_0",
+					Distributions: []splitClientDistribution{
+						{Percent: "33.33%", Value: "test_service1_80"},
+						{Percent: "33.33%", Value: "test_service2_80"},
+						{Percent: "*", Value: nginx502Server},
+					},
+				},
+			},
+			expectedUpstreams: []upstream{
+				{Name: "test_service1_80", Servers: []upstreamServer{{Address: "10.0.0.1:8080"}}, Keepalive: upstreamKeepalive},
+				{Name: "test_service2_80", Servers: []upstreamServer{{Address: "10.0.0.2:8080"}}, Keepalive: upstreamKeepalive},
+			},
+			expectedWarnings: Warnings{
+				hrWeighted: []string{
+					"service test/service3 cannot be resolved: service doesn't exist; 33.33% of the requests will fail",
+				},
+			},
+			msg: "weighted backend refs",
+		},
+		{
+			hr: hrResponseHeaders,
+			expected: location{
+				ProxyPass:           "http://$backend_group_0",
+				HideResponseHeaders: []string{"X-Powered-By", "X-Backend"},
+				AddResponseHeaders: []httpHeader{
+					{Name: "X-Backend", Value: "${response_header_0}"},
+				},
+			},
+			expectedSplitClients: []splitClient{
+				{
+					VariableName: "backend_group_0",
+					Distributions: []splitClientDistribution{
+						{Percent: "50.00%", Value: "test_service1_80"},
+						{Percent: "*", Value: "test_service2_80"},
+					},
+				},
+			},
+			expectedUpstreams: []upstream{
+				{Name: "test_service1_80", Servers: []upstreamServer{{Address: "10.0.0.1:8080"}}, Keepalive: upstreamKeepalive},
+				{Name: "test_service2_80", Servers: []upstreamServer{{Address: "10.0.0.2:8080"}}, Keepalive: upstreamKeepalive},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "response header modifiers",
+		},
+	}
+
+	for _, test := range tests {
+		ups := newUpstreams(fakeServiceStore, nil)
+		splits := newSplitClients()
+
+		result, warnings := generateBackendLocation(test.hr, 0, ups, splits, newResponseHeaderMaps())
+
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateBackendLocation() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedSplitClients, splits.blocks); diff != "" {
+			t.Errorf("generateBackendLocation() %q mismatch on split clients (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedUpstreams, ups.blocks); diff != "" {
+			t.Errorf("generateBackendLocation() %q mismatch on upstreams (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings); diff != "" {
+			t.Errorf("generateBackendLocation() %q mismatch on warnings (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetBackendAddress(t *testing.T) {
+	getNormalRef := func() v1alpha2.BackendRef {
+		return v1alpha2.BackendRef{
+			BackendObjectReference: v1alpha2.BackendObjectReference{
+				Group:     (*v1alpha2.Group)(helpers.GetStringPointer("networking.k8s.io")),
+				Kind:      (*v1alpha2.Kind)(helpers.GetStringPointer("Service")),
+				Name:      "service1",
+				Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+				Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+			},
+		}
+	}
+
+	getModifiedRef := func(mod func(v1alpha2.BackendRef) v1alpha2.BackendRef) v1alpha2.BackendRef {
+		return mod(getNormalRef())
+	}
+
+	tests := []struct {
+		ref                       v1alpha2.BackendRef
+		parentNS                  string
+		allowedBackends           map[types.NamespacedName]struct{}
+		storeEndpoints            []state.Endpoint
+		storeErr                  error
+		expectedResolverCallCount int
+		expectedNsName            types.NamespacedName
+		expectedPort              int32
+		expectedAddress           string
+		expectErr                 bool
+		msg                       string
+	}{
+		{
+			ref:                       getNormalRef(),
+			parentNS:                  "test",
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 1,
+			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
+			expectedPort:              80,
+			expectedAddress:           "test_service1_80",
+			expectErr:                 false,
+			msg:                       "normal case",
+		},
+		{
+			ref: getModifiedRef(
+				func(ref v1alpha2.BackendRef) v1alpha2.BackendRef {
+					ref.Namespace = nil
+					return ref
+				},
+			),
+			parentNS:                  "test",
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 1,
+			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
+			expectedPort:              80,
+			expectedAddress:           "test_service1_80",
+			expectErr:                 false,
+			msg:                       "normal case with implicit namespace",
+		},
+		{
+			ref: getModifiedRef(
+				func(ref v1alpha2.BackendRef) v1alpha2.BackendRef {
+					ref.Group = nil
+					ref.Kind = nil
+					return ref
+				},
+			),
+			parentNS:                  "test",
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 1,
+			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
+			expectedPort:              80,
+			expectedAddress:           "test_service1_80",
+			expectErr:                 false,
+			msg:                       "normal case with implicit service",
+		},
+		{
+			ref: getModifiedRef(
+				func(ref v1alpha2.BackendRef) v1alpha2.BackendRef {
+					ref.Kind = (*v1alpha2.Kind)(helpers.GetStringPointer("NotService"))
+					return ref
+				},
+			),
+			parentNS:                  "test",
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 0,
+			expectedNsName:            types.NamespacedName{},
+			expectedAddress:           "",
+			expectErr:                 true,
+			msg:                       "not a service Kind",
+		},
+		{
+			ref: getModifiedRef(
+				func(ref v1alpha2.BackendRef) v1alpha2.BackendRef {
+					ref.Port = nil
+					return ref
+				},
+			),
+			parentNS:                  "test",
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 0,
+			expectedNsName:            types.NamespacedName{},
+			expectedAddress:           "",
+			expectErr:                 true,
+			msg:                       "no port",
+		},
+		{
+			ref:                       getNormalRef(),
+			parentNS:                  "test",
+			storeEndpoints:            nil,
+			storeErr:                  errors.New(""),
+			expectedResolverCallCount: 1,
+			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
+			expectedPort:              80,
+			expectedAddress:           "",
+			expectErr:                 true,
+			msg:                       "service doesn't exist",
+		},
+		{
+			ref:      getNormalRef(),
+			parentNS: "other",
+			allowedBackends: map[types.NamespacedName]struct{}{
+				{Namespace: "test", Name: "service1"}: {},
+			},
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 1,
+			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
+			expectedPort:              80,
+			expectedAddress:           "test_service1_80",
+			expectErr:                 false,
+			msg:                       "service in another namespace allowed by ReferenceGrant",
+		},
+		{
+			ref:      getNormalRef(),
+			parentNS: "other",
+			allowedBackends: map[types.NamespacedName]struct{}{
+				{Namespace: "test", Name: "service2"}: {},
+			},
+			storeEndpoints:            []state.Endpoint{{Address: "10.0.0.1", Port: 8080}},
+			storeErr:                  nil,
+			expectedResolverCallCount: 0,
+			expectedNsName:            types.NamespacedName{},
+			expectedAddress:           "",
+			expectErr:                 true,
+			msg:                       "service in another namespace not allowed by ReferenceGrant",
+		},
+	}
+
+	for _, test := range tests {
+		fakeServiceStore := &statefakes.FakeServiceStore{}
+		fakeServiceStore.ResolveReturns(test.storeEndpoints, test.storeErr)
+
+		source := &v1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: test.parentNS,
+				Name:      "route1",
+			},
+		}
+
+		var allowed map[client.Object]map[types.NamespacedName]struct{}
+		if test.allowedBackends != nil {
+			allowed = map[client.Object]map[types.NamespacedName]struct{}{source: test.allowedBackends}
+		}
+
+		result, err := getBackendAddress(test.ref, source, newUpstreams(fakeServiceStore, allowed))
+		if result != test.expectedAddress {
+			t.Errorf(
+				"getBackendAddress() returned %s but expected %s for case %q",
+				result,
+				test.expectedAddress,
+				test.msg,
+			)
+		}
+
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("getBackendAddress() didn't return any error for case %q", test.msg)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("getBackendAddress() returned unexpected error %v for case %q", err, test.msg)
+			}
+		}
+
+		callCount := fakeServiceStore.ResolveCallCount()
+		if callCount != test.expectedResolverCallCount {
+			t.Errorf(
+				"getBackendAddress() called fakeServiceStore.Resolve %d times but expected %d for case %q",
+				callCount,
+				test.expectedResolverCallCount,
+				test.msg,
+			)
+		}
+
+		if test.expectedResolverCallCount == 0 {
+			continue
+		}
+
+		nsname, port := fakeServiceStore.ResolveArgsForCall(0)
+		if nsname != test.expectedNsName || port != test.expectedPort {
+			t.Errorf(
+				"getBackendAddress() called fakeServiceStore.Resolve with %v, %d but expected %v, %d for case %q",
+				nsname,
+				port,
+				test.expectedNsName,
+				test.expectedPort,
+				test.msg,
+			)
+		}
+	}
+}
+
+func TestGenerateRuleLocation(t *testing.T) {
+	hr := &v1alpha2.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+								Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value"}},
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+				{
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterRequestRedirect,
+							RequestRedirect: &v1alpha2.HTTPRequestRedirectFilter{
+								Scheme: helpers.GetStringPointer("https"),
+							},
+						},
+					},
+					BackendRefs: nil, // a redirect doesn't need backend refs
+				},
+				{
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &v1alpha2.HTTPRequestMirrorFilter{
+								BackendRef: v1alpha2.BackendObjectReference{
+									Name: "staging",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+						{
+							Type: v1beta1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &v1alpha2.HTTPRequestMirrorFilter{
+								BackendRef: v1alpha2.BackendObjectReference{
+									Name: "unknown",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+				{
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterURLRewrite,
+							URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+								Hostname: (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("backend.example.com")),
+								Path: &v1alpha2.HTTPPathModifier{
+									Type:               v1beta1.PrefixMatchHTTPPathModifier,
+									ReplacePrefixMatch: helpers.GetStringPointer("/"),
+								},
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+				{
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterURLRewrite,
+							URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+								Path: &v1alpha2.HTTPPathModifier{
+									Type:            v1beta1.FullPathHTTPPathModifier,
+									ReplaceFullPath: helpers.GetStringPointer("/full"),
+								},
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: v1alpha2.BackendRef{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "service1",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName, _ int32) ([]state.Endpoint, error) {
+		if nsname.Name == "unknown" {
+			return nil, errors.New("service doesn't exist")
+		}
+		return []state.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil
+	}
+
+	origin := serverOrigin{scheme: "http", port: 80}
+
+	tests := []struct {
+		ruleIdx          int
+		expected         location
+		expectedWarnings Warnings
+		expectedRewrites []uriRewrite
+		msg              string
+	}{
+		{
+			ruleIdx: 0,
+			expected: location{
+				ProxyPass: "http://test_service1_80",
+				ProxySetHeaders: []httpHeader{
+					{Name: "Host", Value: "$host"},
+					{Name: "My-Header", Value: "value"},
+				},
+			},
+			msg: "backends",
+		},
+		{
+			ruleIdx: 1,
+			expected: location{
+				Return: &returnVal{
+					Code: 302,
+					URL:  "https://$host$request_uri",
+				},
+			},
+			msg: "redirect",
+		},
+		{
+			ruleIdx: 2,
+			expected: location{
+				ProxyPass: "http://test_service1_80",
+				Mirrors: []mirror{
+					{
+						Path:      "/_mirror_test_staging_80",
+						ProxyPass: "http://test_staging_80",
+					},
+				},
+			},
+			expectedWarnings: Warnings{
+				hr: []string{
+					"service test/unknown cannot be resolved: service doesn't exist; the requests will not be mirrored",
+				},
+			},
+			msg: "mirrors",
+		},
+		{
+			ruleIdx: 3,
+			expected: location{
+				ProxyPass: "http://test_service1_80",
+				ProxySetHeaders: []httpHeader{
+					{Name: "Host", Value: "backend.example.com"},
+				},
+				RewrittenURI: "$rewritten_uri_0",
+			},
+			expectedRewrites: []uriRewrite{{prefix: "/api", replacement: "/"}},
+			msg:              "prefix and hostname rewrite",
+		},
+		{
+			ruleIdx: 4,
+			expected: location{
+				ProxyPass:    "http://test_service1_80",
+				RewrittenURI: "$rewritten_uri_0",
+			},
+			expectedRewrites: []uriRewrite{{replacement: "/full"}},
+			msg:              "full path rewrite",
+		},
+	}
+
+	for _, test := range tests {
+		rewrites := newURIRewrites()
+
+		result, warnings := generateRuleLocation(
+			hr,
+			test.ruleIdx,
+			"/api",
+			origin,
+			newUpstreams(fakeServiceStore, nil),
+			newSplitClients(),
+			rewrites,
+			newResponseHeaderMaps(),
+		)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateRuleLocation() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("generateRuleLocation() %q mismatch on warnings (-want +got):\n%s", test.msg, diff)
+		}
+
+		var rewritesKeys []uriRewrite
+		for rewrite := range rewrites.variables {
+			rewritesKeys = append(rewritesKeys, rewrite)
+		}
+		if diff := cmp.Diff(test.expectedRewrites, rewritesKeys, cmp.AllowUnexported(uriRewrite{})); diff != "" {
+			t.Errorf("generateRuleLocation() %q mismatch on rewrites (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateMirrorLocations(t *testing.T) {
+	stagingMirror := mirror{Path: "/_mirror_test_staging_80", ProxyPass: "http://test_staging_80"}
+	canaryMirror := mirror{Path: "/_mirror_test_canary_80", ProxyPass: "http://test_canary_80"}
+
+	locs := []location{
+		{Path: "/", Mirrors: []mirror{stagingMirror}},
+		{Path: "^~ /foo/"},
+		{Path: "= /bar_route0", Internal: true, Mirrors: []mirror{stagingMirror, canaryMirror}},
+	}
+
+	expected := []location{
+		{Path: "= /_mirror_test_staging_80", ProxyPass: "http://test_staging_80", Internal: true},
+		{Path: "= /_mirror_test_canary_80", ProxyPass: "http://test_canary_80", Internal: true},
+	}
+
+	result := generateMirrorLocations(locs)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateMirrorLocations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateRedirectLocation(t *testing.T) {
+	httpOrigin := serverOrigin{scheme: "http", port: 80}
+	httpsOrigin := serverOrigin{scheme: "https", port: 8443}
+
+	tests := []struct {
+		redirect     v1alpha2.HTTPRequestRedirectFilter
+		origin       serverOrigin
+		expectedURL  string
+		expectedCode int
+		msg          string
+	}{
+		{
+			redirect:     v1alpha2.HTTPRequestRedirectFilter{},
+			origin:       httpOrigin,
+			expectedURL:  "http://$host$request_uri",
+			expectedCode: 302,
+			msg:          "empty redirect",
+		},
+		{
+			redirect:     v1alpha2.HTTPRequestRedirectFilter{},
+			origin:       httpsOrigin,
+			expectedURL:  "https://$host:8443$request_uri",
+			expectedCode: 302,
+			msg:          "empty redirect for a non-default port",
+		},
+		{
+			redirect: v1alpha2.HTTPRequestRedirectFilter{
+				Scheme:     helpers.GetStringPointer("https"),
+				StatusCode: helpers.GetIntPointer(301),
+			},
+			origin:       httpOrigin,
+			expectedURL:  "https://$host$request_uri",
+			expectedCode: 301,
+			msg:          "http to https",
+		},
+		{
+			redirect: v1alpha2.HTTPRequestRedirectFilter{
+				Scheme: helpers.GetStringPointer("https"),
+				Port:   (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(8443)),
+			},
+			origin:       httpOrigin,
+			expectedURL:  "https://$host:8443$request_uri",
+			expectedCode: 302,
+			msg:          "http to https with port",
+		},
+		{
+			redirect: v1alpha2.HTTPRequestRedirectFilter{
+				Hostname: (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("new.example.com")),
+			},
+			origin:       httpsOrigin,
+			expectedURL:  "https://new.example.com:8443$request_uri",
+			expectedCode: 302,
+			msg:          "hostname",
+		},
+		{
+			redirect: v1alpha2.HTTPRequestRedirectFilter{
+				Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+			},
+			origin:       httpOrigin,
+			expectedURL:  "http://$host$request_uri",
+			expectedCode: 302,
+			msg:          "default port",
+		},
+	}
+
+	for _, test := range tests {
+		expected := location{
+			Return: &returnVal{
+				Code: test.expectedCode,
+				URL:  test.expectedURL,
+			},
+		}
+
+		result := generateRedirectLocation(test.redirect, test.origin)
+		if diff := cmp.Diff(expected, result); diff != "" {
+			t.Errorf("generateRedirectLocation() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateProxySetHeaders(t *testing.T) {
+	tests := []struct {
+		filters  []v1alpha2.HTTPRouteFilter
+		expected []httpHeader
+		msg      string
+	}{
+		{
+			filters:  nil,
+			expected: nil,
+			msg:      "no filters",
+		},
+		{
+			filters: []v1alpha2.HTTPRouteFilter{
+				{
+					Type: v1beta1.HTTPRouteFilterRequestMirror,
+				},
+			},
+			expected: nil,
+			msg:      "no request header modifier",
+		},
+		{
+			filters: []v1alpha2.HTTPRouteFilter{
+				{
+					Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+						Set: []v1alpha2.HTTPHeader{
+							{Name: "My-Header", Value: `value with "quotes" and \`},
+							{Name: "my-header", Value: "ignored"},
+						},
+						Add: []v1alpha2.HTTPHeader{
+							{Name: "X-Forwarded-For", Value: "10.0.0.1"},
+							{Name: "MY-HEADER", Value: "ignored"},
+						},
+						Remove: []string{"User-Agent", "x-forwarded-for"},
+					},
+				},
+			},
+			expected: []httpHeader{
+				{Name: "Host", Value: "$host"},
+				{Name: "My-Header", Value: `value with \"quotes\" and \\`},
+				{Name: "X-Forwarded-For", Value: "${x_forwarded_for_header_var}10.0.0.1"},
+				{Name: "User-Agent", Value: ""},
+			},
+			msg: "set, add and remove",
+		},
+		{
+			filters: []v1alpha2.HTTPRouteFilter{
+				{
+					Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+						Set: []v1alpha2.HTTPHeader{{Name: "host", Value: "example.com"}},
+					},
+				},
+			},
+			expected: []httpHeader{
+				{Name: "host", Value: "example.com"},
+			},
+			msg: "set host",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxySetHeaders(test.filters)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateProxySetHeaders() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestCreateAddHeaderMaps(t *testing.T) {
+	createRoute := func(names ...v1alpha2.HTTPHeaderName) *v1alpha2.HTTPRoute {
+		var headers []v1alpha2.HTTPHeader
+		for _, n := range names {
+			headers = append(headers, v1alpha2.HTTPHeader{Name: n, Value: "value"})
+		}
+
+		return &v1alpha2.HTTPRoute{
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{
+					{
+						Filters: []v1alpha2.HTTPRouteFilter{
+							{
+								Type:                  v1beta1.HTTPRouteFilterRequestHeaderModifier,
+								RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{Add: headers},
+							},
+						},
 					},
 				},
 			},
-		},
+		}
 	}
 
-	fakeServiceStore := &statefakes.FakeServiceStore{}
-	fakeServiceStore.ResolveReturns("10.0.0.1", nil)
-
-	expectedMatchString := func(m []httpMatch) string {
-		b, err := json.Marshal(m)
-		if err != nil {
-			t.Errorf("error marshaling test match: %v", err)
+	createServer := func(hr *v1alpha2.HTTPRoute) state.HTTPServer {
+		return state.HTTPServer{
+			PathRules: []state.PathRule{
+				{
+					MatchRules: []state.MatchRule{{Source: hr}},
+				},
+			},
 		}
-		return string(b)
 	}
 
-	slashMatches := []httpMatch{
-		{Method: v1alpha2.HTTPMethodPost, RedirectPath: "/_route0"},
-		{Method: v1alpha2.HTTPMethodPatch, RedirectPath: "/_route1"},
-		{Any: true, RedirectPath: "/_route2"},
-	}
-	testMatches := []httpMatch{
-		{
-			Method:       v1alpha2.HTTPMethodGet,
-			Headers:      []string{"Version:V1", "test:foo", "my-header:my-value"},
-			QueryParams:  []string{"GrEat=EXAMPLE", "test=foo=bar"},
-			RedirectPath: "/test_route0",
+	conf := state.Configuration{
+		HTTPServers: []state.HTTPServer{
+			createServer(createRoute("My-Header", "X-Forwarded-For")),
+			createServer(createRoute()),
+		},
+		SSLServers: []state.HTTPServer{
+			createServer(createRoute("my-header", "X-Trace")),
 		},
 	}
 
-	const backendAddr = "http://10.0.0.1:80"
-
-	expected := server{
-		ServerName: "example.com",
-		Locations: []location{
-			{
-				Path:      "/_route0",
-				Internal:  true,
-				ProxyPass: backendAddr,
-			},
-			{
-				Path:      "/_route1",
-				Internal:  true,
-				ProxyPass: backendAddr,
-			},
-			{
-				Path:      "/_route2",
-				Internal:  true,
-				ProxyPass: backendAddr,
-			},
-			{
-				Path:         "/",
-				HTTPMatchVar: expectedMatchString(slashMatches),
-			},
-			{
-				Path:      "/test_route0",
-				Internal:  true,
-				ProxyPass: "http://" + nginx502Server,
+	expected := []nginxMap{
+		{
+			Source:   "$http_my_header",
+			Variable: "my_header_header_var",
+			Parameters: []mapParameter{
+				{Value: "default", Result: `""`},
+				{Value: "~.+", Result: `"$http_my_header,"`},
 			},
-			{
-				Path:         "/test",
-				HTTPMatchVar: expectedMatchString(testMatches),
+		},
+		{
+			Source:   "$http_x_forwarded_for",
+			Variable: "x_forwarded_for_header_var",
+			Parameters: []mapParameter{
+				{Value: "default", Result: `""`},
+				{Value: "~.+", Result: `"$http_x_forwarded_for,"`},
 			},
-			{
-				Path:      "/path-only",
-				ProxyPass: backendAddr,
+		},
+		{
+			Source:   "$http_x_trace",
+			Variable: "x_trace_header_var",
+			Parameters: []mapParameter{
+				{Value: "default", Result: `""`},
+				{Value: "~.+", Result: `"$http_x_trace,"`},
 			},
 		},
 	}
-	expectedWarnings := Warnings{
-		hr: []string{"empty backend refs"},
-	}
-
-	result, warnings := generate(host, fakeServiceStore)
 
+	result := createAddHeaderMaps(conf)
 	if diff := cmp.Diff(expected, result); diff != "" {
-		t.Errorf("generate() mismatch (-want +got):\n%s", diff)
-	}
-	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
-		t.Errorf("generate() mismatch on warnings (-want +got):\n%s", diff)
+		t.Errorf("createAddHeaderMaps() mismatch (-want +got):\n%s", diff)
 	}
 }
 
-func TestGenerateProxyPass(t *testing.T) {
-	expected := "http://10.0.0.1:80"
-
-	result := generateProxyPass("10.0.0.1:80")
-	if result != expected {
-		t.Errorf("generateProxyPass() returned %s but expected %s", result, expected)
+func TestGenerateMatchLocation(t *testing.T) {
+	expected := location{
+		Path:      "= /path",
+		Internal:  true,
+		ProxyPass: "http://10.0.0.1:80",
 	}
 
-	expected = "http://" + nginx502Server
-
-	result = generateProxyPass("")
-	if result != expected {
-		t.Errorf("generateProxyPass() returned %s but expected %s", result, expected)
+	result := generateMatchLocation("/path", location{ProxyPass: "http://10.0.0.1:80"})
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateMatchLocation() mismatch (-want +got):\n%s", diff)
 	}
 }
 
-func TestGetBackendAddress(t *testing.T) {
-	getNormalRefs := func() []v1alpha2.HTTPBackendRef {
-		return []v1alpha2.HTTPBackendRef{
-			{
-				BackendRef: v1alpha2.BackendRef{
-					BackendObjectReference: v1alpha2.BackendObjectReference{
-						Group:     (*v1alpha2.Group)(helpers.GetStringPointer("networking.k8s.io")),
-						Kind:      (*v1alpha2.Kind)(helpers.GetStringPointer("Service")),
-						Name:      "service1",
-						Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
-						Port:      (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
-					},
-				},
+func TestConvertToGRPCLocation(t *testing.T) {
+	tests := []struct {
+		loc      location
+		expected location
+		msg      string
+	}{
+		{
+			loc: location{
+				ProxyPass:       "http://10.0.0.1:80",
+				ProxySetHeaders: []httpHeader{{Name: "My-Header", Value: "value"}},
 			},
-		}
+			expected: location{
+				GRPCPass:        "grpc://10.0.0.1:80",
+				ProxySetHeaders: []httpHeader{{Name: "My-Header", Value: "value"}},
+			},
+			msg: "backend",
+		},
+		{
+			loc: location{
+				ProxyPass: "http://$backend_group_0",
+			},
+			expected: location{
+				GRPCPass: "grpc://$backend_group_0",
+			},
+			msg: "split clients",
+		},
+		{
+			loc: location{
+				Return: &returnVal{Code: 500},
+			},
+			expected: location{
+				Return: &returnVal{Code: 500},
+			},
+			msg: "return",
+		},
 	}
 
-	getModifiedRefs := func(mod func([]v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-		return mod(getNormalRefs())
+	for _, test := range tests {
+		result := convertToGRPCLocation(test.loc)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("convertToGRPCLocation() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
 	}
+}
 
+func TestCreatePathForMatch(t *testing.T) {
 	tests := []struct {
-		refs                      []v1alpha2.HTTPBackendRef
-		parentNS                  string
-		storeAddress              string
-		storeErr                  error
-		expectedResolverCallCount int
-		expectedNsName            types.NamespacedName
-		expectedAddress           string
-		expectErr                 bool
-		msg                       string
+		pathType state.PathType
+		expected string
 	}{
 		{
-			refs:                      getNormalRefs(),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "10.0.0.1:80",
-			expectErr:                 false,
-			msg:                       "normal case",
-		},
-		{
-			refs: getModifiedRefs(
-				func(refs []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-					refs[0].BackendRef.Namespace = nil
-					return refs
-				},
-			),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "10.0.0.1:80",
-			expectErr:                 false,
-			msg:                       "normal case with implicit namespace",
-		},
-		{
-			refs: getModifiedRefs(
-				func(refs []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-					refs[0].BackendRef.Group = nil
-					refs[0].BackendRef.Kind = nil
-					return refs
-				},
-			),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "10.0.0.1:80",
-			expectErr:                 false,
-			msg:                       "normal case with implicit service",
+			pathType: state.PathTypePrefix,
+			expected: "/path_route1",
 		},
 		{
-			refs: getModifiedRefs(
-				func(refs []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-					secondRef := refs[0].DeepCopy()
-					secondRef.Name = "service2"
-					return append(refs, *secondRef)
-				},
-			),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "10.0.0.1:80",
-			expectErr:                 false,
-			msg:                       "first backend ref is used",
+			pathType: state.PathTypeExact,
+			expected: "/path_exact_route1",
 		},
 		{
-			refs: getModifiedRefs(
-				func(refs []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-					refs[0].BackendRef.Kind = (*v1alpha2.Kind)(helpers.GetStringPointer("NotService"))
-					return refs
-				},
-			),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 0,
-			expectedNsName:            types.NamespacedName{},
-			expectedAddress:           "",
-			expectErr:                 true,
-			msg:                       "not a service Kind",
+			pathType: state.PathTypeRegex,
+			expected: "/_regex2_route1",
 		},
+	}
+
+	for _, test := range tests {
+		result := createPathForMatch("/path", test.pathType, 2, 1)
+		if result != test.expected {
+			t.Errorf("createPathForMatch() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
+func TestGetPrefixLocationPaths(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
 		{
-			refs:                      nil,
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 0,
-			expectedNsName:            types.NamespacedName{},
-			expectedAddress:           "",
-			expectErr:                 true,
-			msg:                       "no refs",
+			path:     "/",
+			expected: []string{"/"},
 		},
 		{
-			refs: getModifiedRefs(
-				func(refs []v1alpha2.HTTPBackendRef) []v1alpha2.HTTPBackendRef {
-					refs[0].BackendRef.Port = nil
-					return refs
-				},
-			),
-			parentNS:                  "test",
-			storeAddress:              "10.0.0.1",
-			storeErr:                  nil,
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "",
-			expectErr:                 true,
-			msg:                       "no port",
+			path:     "/path",
+			expected: []string{"= /path", "^~ /path/"},
 		},
 		{
-			refs:                      getNormalRefs(),
-			parentNS:                  "test",
-			storeAddress:              "",
-			storeErr:                  errors.New(""),
-			expectedResolverCallCount: 1,
-			expectedNsName:            types.NamespacedName{Namespace: "test", Name: "service1"},
-			expectedAddress:           "",
-			expectErr:                 true,
-			msg:                       "service doesn't exist",
+			path:     "/path/more",
+			expected: []string{"= /path/more", "^~ /path/more/"},
 		},
 	}
 
 	for _, test := range tests {
-		fakeServiceStore := &statefakes.FakeServiceStore{}
-		fakeServiceStore.ResolveReturns(test.storeAddress, test.storeErr)
-
-		result, err := getBackendAddress(test.refs, test.parentNS, fakeServiceStore)
-		if result != test.expectedAddress {
-			t.Errorf(
-				"getBackendAddress() returned %s but expected %s for case %q",
-				result,
-				test.expectedAddress,
-				test.msg,
-			)
-		}
-
-		if test.expectErr {
-			if err == nil {
-				t.Errorf("getBackendAddress() didn't return any error for case %q", test.msg)
-			}
-		} else {
-			if err != nil {
-				t.Errorf("getBackendAddress() returned unexpected error %v for case %q", err, test.msg)
-			}
-		}
-
-		callCount := fakeServiceStore.ResolveCallCount()
-		if callCount != test.expectedResolverCallCount {
-			t.Errorf(
-				"getBackendAddress() called fakeServiceStore.Resolve %d times but expected %d for case %q",
-				callCount,
-				test.expectedResolverCallCount,
-				test.msg,
-			)
-		}
-
-		if test.expectedResolverCallCount == 0 {
-			continue
-		}
-
-		nsname := fakeServiceStore.ResolveArgsForCall(0)
-		if nsname != test.expectedNsName {
-			t.Errorf(
-				"getBackendAddress() called fakeServiceStore.Resolve with %v but expected %v for case %q",
-				nsname,
-				test.expectedNsName,
-				test.msg,
-			)
+		result := getPrefixLocationPaths(test.path)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("getPrefixLocationPaths() %q mismatch (-want +got):\n%s", test.path, diff)
 		}
 	}
 }
 
-func TestGenerateMatchLocation(t *testing.T) {
-	expected := location{
-		Path:      "/path",
-		Internal:  true,
-		ProxyPass: "http://10.0.0.1:80",
-	}
-
-	result := generateMatchLocation("/path", "10.0.0.1:80")
-	if result != expected {
-		t.Errorf("generateMatchLocation() returned %v but expected %v", result, expected)
+func TestQuoteRegex(t *testing.T) {
+	tests := []struct {
+		regex    string
+		expected string
+	}{
+		{
+			regex:    `^/path$`,
+			expected: `"^/path$"`,
+		},
+		{
+			regex:    `^/path/[a-z]{3};\.html$`,
+			expected: `"^/path/[a-z]{3};\\.html$"`,
+		},
+		{
+			regex:    `^/path/"quoted"$`,
+			expected: `"^/path/\"quoted\"$"`,
+		},
 	}
-}
-
-func TestCreatePathForMatch(t *testing.T) {
-	expected := "/path_route1"
 
-	result := createPathForMatch("/path", 1)
-	if result != expected {
-		t.Errorf("createPathForMatch() returned %q but expected %q", result, expected)
+	for _, test := range tests {
+		result := quoteRegex(test.regex)
+		if result != test.expected {
+			t.Errorf("quoteRegex() returned %q but expected %q", result, test.expected)
+		}
 	}
 }
 
@@ -565,7 +2649,7 @@ func TestMatchLocationNeeded(t *testing.T) {
 				Path: &v1alpha2.HTTPPathMatch{
 					Value: helpers.GetStringPointer("/path"),
 				},
-				Method: helpers.GetHTTPMethodPointer(v1alpha2.HTTPMethodGet),
+				Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
 			},
 			expected: false,
 			msg:      "method defined in match",
@@ -616,26 +2700,26 @@ func TestCreateHTTPMatch(t *testing.T) {
 	testPath := "/internal_loc"
 
 	testPathMatch := v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/")}
-	testMethodMatch := helpers.GetHTTPMethodPointer(v1alpha2.HTTPMethodPut)
+	testMethodMatch := helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPut)
 	testHeaderMatches := []v1alpha2.HTTPHeaderMatch{
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 			Name:  "header-1",
 			Value: "val-1",
 		},
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 			Name:  "header-2",
 			Value: "val-2",
 		},
 		{
-			// regex type is not supported. This should not be added to the httpMatch headers.
-			Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchRegularExpression),
-			Name:  "ignore-this-header",
-			Value: "val",
+			// regex headers are added to the httpMatch regex headers.
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+			Name:  "header-regex",
+			Value: "^val-[0-9]+$",
 		},
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 			Name:  "header-3",
 			Value: "val-3",
 		},
@@ -643,7 +2727,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	testDuplicateHeaders := make([]v1alpha2.HTTPHeaderMatch, 0, 5)
 	duplicateHeaderMatch := v1alpha2.HTTPHeaderMatch{
-		Type:  helpers.GetHeaderMatchTypePointer(v1alpha2.HeaderMatchExact),
+		Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
 		Name:  "HEADER-2", // header names are case-insensitive
 		Value: "val-2",
 	}
@@ -652,23 +2736,23 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	testQueryParamMatches := []v1alpha2.HTTPQueryParamMatch{
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchExact),
+			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
 			Name:  "arg1",
 			Value: "val1",
 		},
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchExact),
+			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
 			Name:  "arg2",
 			Value: "val2=another-val",
 		},
 		{
-			// regex type is not supported. This should not be added to the httpMatch args
-			Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchRegularExpression),
-			Name:  "ignore-this-arg",
-			Value: "val",
+			// regex query params are added to the httpMatch regex args.
+			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchRegularExpression),
+			Name:  "arg-regex",
+			Value: "^val=[0-9]+$",
 		},
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1alpha2.QueryParamMatchExact),
+			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
 			Name:  "arg3",
 			Value: "==val3",
 		},
@@ -676,6 +2760,8 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	expectedHeaders := []string{"header-1:val-1", "header-2:val-2", "header-3:val-3"}
 	expectedArgs := []string{"arg1=val1", "arg2=val2=another-val", "arg3===val3"}
+	expectedHeadersRegex := []string{"header-regex:^val-[0-9]+$"}
+	expectedArgsRegex := []string{"arg-regex=^val=[0-9]+$"}
 
 	tests := []struct {
 		match    v1alpha2.HTTPRouteMatch
@@ -710,6 +2796,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 			expected: httpMatch{
 				RedirectPath: testPath,
 				Headers:      expectedHeaders,
+				HeadersRegex: expectedHeadersRegex,
 			},
 			msg: "headers only match",
 		},
@@ -718,8 +2805,9 @@ func TestCreateHTTPMatch(t *testing.T) {
 				QueryParams: testQueryParamMatches,
 			},
 			expected: httpMatch{
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				QueryParams:      expectedArgs,
+				QueryParamsRegex: expectedArgsRegex,
+				RedirectPath:     testPath,
 			},
 			msg: "query params only match",
 		},
@@ -729,9 +2817,10 @@ func TestCreateHTTPMatch(t *testing.T) {
 				QueryParams: testQueryParamMatches,
 			},
 			expected: httpMatch{
-				Method:       "PUT",
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				Method:           "PUT",
+				QueryParams:      expectedArgs,
+				QueryParamsRegex: expectedArgsRegex,
+				RedirectPath:     testPath,
 			},
 			msg: "method and query params match",
 		},
@@ -743,6 +2832,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 			expected: httpMatch{
 				Method:       "PUT",
 				Headers:      expectedHeaders,
+				HeadersRegex: expectedHeadersRegex,
 				RedirectPath: testPath,
 			},
 			msg: "method and headers match",
@@ -753,9 +2843,11 @@ func TestCreateHTTPMatch(t *testing.T) {
 				Headers:     testHeaderMatches,
 			},
 			expected: httpMatch{
-				QueryParams:  expectedArgs,
-				Headers:      expectedHeaders,
-				RedirectPath: testPath,
+				QueryParams:      expectedArgs,
+				QueryParamsRegex: expectedArgsRegex,
+				Headers:          expectedHeaders,
+				HeadersRegex:     expectedHeadersRegex,
+				RedirectPath:     testPath,
 			},
 			msg: "query params and headers match",
 		},
@@ -766,10 +2858,12 @@ func TestCreateHTTPMatch(t *testing.T) {
 				Method:      testMethodMatch,
 			},
 			expected: httpMatch{
-				Method:       "PUT",
-				Headers:      expectedHeaders,
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				Method:           "PUT",
+				Headers:          expectedHeaders,
+				HeadersRegex:     expectedHeadersRegex,
+				QueryParams:      expectedArgs,
+				QueryParamsRegex: expectedArgsRegex,
+				RedirectPath:     testPath,
 			},
 			msg: "method, headers, and query params match",
 		},
@@ -779,6 +2873,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 			},
 			expected: httpMatch{
 				Headers:      expectedHeaders,
+				HeadersRegex: expectedHeadersRegex,
 				RedirectPath: testPath,
 			},
 			msg: "duplicate header names",
@@ -1,17 +1,105 @@
 package config
 
 type httpServers struct {
-	Servers []server
+	Upstreams    []upstream
+	SplitClients []splitClient
+	Maps         []nginxMap
+	Servers      []server
 }
 
 type server struct {
-	ServerName string
-	Locations  []location
+	IsDefaultHTTP bool
+	IsDefaultSSL  bool
+	ServerName    string
+	Port          int32
+	SSL           *ssl
+	Locations     []location
+}
+
+type ssl struct {
+	Certificate    string
+	CertificateKey string
 }
 
 type location struct {
-	Path         string
-	ProxyPass    string
-	HTTPMatchVar string
-	Internal     bool
+	Path      string
+	ProxyPass string
+	// GRPCPass is the address of the gRPC backends. It is set instead of ProxyPass for the locations of
+	// the GRPCRoutes, so that NGINX passes the requests to the backends over HTTP/2.
+	GRPCPass string
+	// ProxySetHeaders replaces the default headers of the proxied requests when it is not empty.
+	ProxySetHeaders []httpHeader
+	// RewrittenURI is the variable with the URI of the proxied requests. If it is empty, the requests are proxied with
+	// their original URI.
+	RewrittenURI string
+	// HideResponseHeaders are the headers of the responses of the backends that are not passed to the clients.
+	HideResponseHeaders []string
+	// AddResponseHeaders are the headers that are added to the responses, including the error responses.
+	AddResponseHeaders []httpHeader
+	// ServerTokensOff removes the NGINX version from the Server header of the responses.
+	ServerTokensOff bool
+	Mirrors         []mirror
+	HTTPMatchVar    string
+	Return          *returnVal
+	Internal        bool
+}
+
+// httpHeader is a header of a proxied request or of a response. Value is the content of a quoted NGINX string, so it
+// can include variables.
+type httpHeader struct {
+	Name  string
+	Value string
+}
+
+type returnVal struct {
+	Code int
+	// URL is the URL of the redirect for the redirect codes. It is an NGINX string, so it can include variables.
+	URL string
+}
+
+type upstream struct {
+	Name string
+	// LBMethod is the directive of the load-balancing method. It is empty for the default round-robin method.
+	LBMethod  string
+	Servers   []upstreamServer
+	Keepalive int
+}
+
+type upstreamServer struct {
+	Address string
+}
+
+type splitClient struct {
+	VariableName  string
+	Distributions []splitClientDistribution
+}
+
+// splitClientDistribution is a distribution of a split_clients block. Percent is either a percentage like "33.33%" or
+// "*", which matches the rest of the requests.
+type splitClientDistribution struct {
+	Percent string
+	Value   string
+}
+
+// mirror is a mirror of the requests of a location. Path is the path of the internal location that passes the mirrored
+// requests to the mirror backend using ProxyPass.
+type mirror struct {
+	Path      string
+	ProxyPass string
+}
+
+// nginxMap is an NGINX map that sets the Variable to the result of the first parameter that matches the value of
+// the Source. The values and the results are NGINX strings.
+// If Hostnames is true, the values are hostnames, which can include wildcards, and NGINX prefers the exact hostnames
+// to the wildcard ones.
+type nginxMap struct {
+	Source     string
+	Variable   string
+	Hostnames  bool
+	Parameters []mapParameter
+}
+
+type mapParameter struct {
+	Value  string
+	Result string
 }
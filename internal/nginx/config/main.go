@@ -0,0 +1,16 @@
+package config
+
+// mainConfig is the main NGINX configuration file. It includes the configuration of the http and stream servers.
+// WorkerProcesses is empty when NGINX must use the default number of worker processes.
+type mainConfig struct {
+	WorkerProcesses string
+	AccessLogs      []accessLog
+}
+
+// accessLog writes the logs of the requests in the format to the destination. Name is the name of the log format.
+// Format and Destination are the contents of quoted NGINX strings.
+type accessLog struct {
+	Name        string
+	Format      string
+	Destination string
+}
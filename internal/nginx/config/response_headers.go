@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// responseHeaderOp is the way a ResponseHeaderModifier filter modifies a header.
+type responseHeaderOp int
+
+const (
+	responseHeaderSet responseHeaderOp = iota
+	responseHeaderAdd
+	responseHeaderRemove
+)
+
+// responseHeaderModification is a modification of a response header by a ResponseHeaderModifier filter.
+type responseHeaderModification struct {
+	name  string
+	op    responseHeaderOp
+	value string
+}
+
+// responseBackend is a backend of a rule, which can modify the headers of its responses by the filters of its
+// backendRef. The address is the value of the variable of the split_clients block of the rule for the backend.
+type responseBackend struct {
+	address string
+	filters []v1alpha2.HTTPRouteFilter
+}
+
+// responseHeaderMapKey identifies a map that chooses the value of a response header based on the backend of a request.
+type responseHeaderMapKey struct {
+	backendVariable string
+	name            string
+	replace         bool
+}
+
+// responseHeaderMaps holds the maps that choose the values of the response headers that the backendRefs of the rules
+// modify. A rule can be attached to multiple servers or have multiple matches, so that the same rule shares
+// the same maps.
+type responseHeaderMaps struct {
+	maps      []nginxMap
+	variables map[responseHeaderMapKey]string
+}
+
+func newResponseHeaderMaps() *responseHeaderMaps {
+	return &responseHeaderMaps{
+		variables: make(map[responseHeaderMapKey]string),
+	}
+}
+
+// add adds the map for the key if there is no map for it yet.
+// It returns the name of the variable that holds the value of the header for a request.
+func (r *responseHeaderMaps) add(key responseHeaderMapKey, parameters []mapParameter) string {
+	if name, exist := r.variables[key]; exist {
+		return name
+	}
+
+	name := fmt.Sprintf("response_header_%d", len(r.maps))
+
+	r.maps = append(r.maps, nginxMap{
+		Source:     "$" + key.backendVariable,
+		Variable:   name,
+		Parameters: parameters,
+	})
+	r.variables[key] = name
+
+	return name
+}
+
+// getResponseHeaderModifications returns the modifications of the response headers by the ResponseHeaderModifier
+// filters. As required by the Gateway API, only the first modification of a header (case-insensitive) is applied.
+// The headers in the skipped set are not modified, and the names of the returned modifications are added to the set.
+func getResponseHeaderModifications(
+	filters []v1alpha2.HTTPRouteFilter,
+	skipped map[string]struct{},
+) []responseHeaderModification {
+	var mods []responseHeaderModification
+
+	modify := func(name string, op responseHeaderOp, value string) {
+		lowerName := strings.ToLower(name)
+		if _, exist := skipped[lowerName]; exist {
+			return
+		}
+		skipped[lowerName] = struct{}{}
+
+		mods = append(mods, responseHeaderModification{name: name, op: op, value: value})
+	}
+
+	for _, f := range filters {
+		if f.Type != v1beta1.HTTPRouteFilterResponseHeaderModifier || f.ResponseHeaderModifier == nil {
+			continue
+		}
+
+		for _, h := range f.ResponseHeaderModifier.Set {
+			modify(string(h.Name), responseHeaderSet, h.Value)
+		}
+
+		for _, h := range f.ResponseHeaderModifier.Add {
+			modify(string(h.Name), responseHeaderAdd, h.Value)
+		}
+
+		for _, name := range f.ResponseHeaderModifier.Remove {
+			modify(name, responseHeaderRemove, "")
+		}
+	}
+
+	return mods
+}
+
+// generateResponseHeaders generates the headers of the backend responses that the location hides and the headers that
+// it adds to the responses from the ResponseHeaderModifier filters of the rule and of its backends.
+// The names and the values of the headers are validated when the graph is built.
+// The filters of the rule take precedence over the filters of the backends. If the rule has multiple backends,
+// the backendVariable is the variable of its split_clients block, and the maps choose the values of the headers that
+// the backends modify based on the backend of a request.
+func generateResponseHeaders(
+	filters []v1alpha2.HTTPRouteFilter,
+	backends []responseBackend,
+	backendVariable string,
+	headerMaps *responseHeaderMaps,
+) (hidden []string, added []httpHeader) {
+	modified := make(map[string]struct{})
+
+	apply := func(mods []responseHeaderModification) {
+		for _, m := range mods {
+			value := nginxStringEscaper.Replace(m.value)
+
+			switch m.op {
+			case responseHeaderSet:
+				hidden = append(hidden, m.name)
+				added = append(added, httpHeader{Name: m.name, Value: value})
+			case responseHeaderAdd:
+				added = append(added, httpHeader{Name: m.name, Value: value})
+			case responseHeaderRemove:
+				hidden = append(hidden, m.name)
+			}
+		}
+	}
+
+	apply(getResponseHeaderModifications(filters, modified))
+
+	if len(backends) == 1 {
+		apply(getResponseHeaderModifications(backends[0].filters, modified))
+		return hidden, added
+	}
+
+	if backendVariable == "" {
+		return hidden, added
+	}
+
+	// the modifications of each header by the backends, in the order of the first modification of the header
+	var names []string
+	backendMods := make(map[string][]responseHeaderModification)
+	modAddresses := make(map[string][]string)
+	seenAddresses := make(map[string]struct{})
+
+	for _, b := range backends {
+		// the backends that cannot be resolved don't have responses to modify. The backends with the same address
+		// cannot be told apart, so the first of them applies its filters
+		if _, exist := seenAddresses[b.address]; exist || b.address == "" {
+			continue
+		}
+		seenAddresses[b.address] = struct{}{}
+
+		// the modifications of a backend only skip the headers that the rule modifies
+		skipped := make(map[string]struct{}, len(modified))
+		for name := range modified {
+			skipped[name] = struct{}{}
+		}
+
+		for _, m := range getResponseHeaderModifications(b.filters, skipped) {
+			lowerName := strings.ToLower(m.name)
+			if _, exist := backendMods[lowerName]; !exist {
+				names = append(names, m.name)
+			}
+
+			backendMods[lowerName] = append(backendMods[lowerName], m)
+			modAddresses[lowerName] = append(modAddresses[lowerName], b.address)
+		}
+	}
+
+	for _, name := range names {
+		lowerName := strings.ToLower(name)
+
+		// the Server header can only be removed, and NGINX adds the same Server header to the responses of all
+		// backends, so the header is removed for the whole location
+		if lowerName == "server" {
+			hidden = append(hidden, name)
+			continue
+		}
+
+		// the Set and Remove modifications replace the header of the backend. The backends that don't replace it keep
+		// the value of the header of their responses
+		replaceParams := []mapParameter{
+			{Value: "default", Result: `"$upstream_http_` + strings.ReplaceAll(lowerName, "-", "_") + `"`},
+		}
+		// the Add modifications add another header to the responses. NGINX doesn't add a header with an empty value
+		addParams := []mapParameter{
+			{Value: "default", Result: `""`},
+		}
+
+		for i, m := range backendMods[lowerName] {
+			param := mapParameter{
+				Value:  `"` + modAddresses[lowerName][i] + `"`,
+				Result: `"` + nginxStringEscaper.Replace(m.value) + `"`,
+			}
+
+			if m.op == responseHeaderAdd {
+				addParams = append(addParams, param)
+			} else {
+				replaceParams = append(replaceParams, param)
+			}
+		}
+
+		if len(replaceParams) > 1 {
+			key := responseHeaderMapKey{backendVariable: backendVariable, name: lowerName, replace: true}
+			variable := headerMaps.add(key, replaceParams)
+
+			hidden = append(hidden, name)
+			added = append(added, httpHeader{Name: name, Value: "${" + variable + "}"})
+		}
+
+		if len(addParams) > 1 {
+			key := responseHeaderMapKey{backendVariable: backendVariable, name: lowerName}
+			variable := headerMaps.add(key, addParams)
+
+			added = append(added, httpHeader{Name: name, Value: "${" + variable + "}"})
+		}
+	}
+
+	return hidden, added
+}
+
+// hidesServerHeader returns true if the hidden headers include the Server header. NGINX OSS cannot remove its own
+// Server header, so the location turns off server_tokens, which removes the NGINX version from the header.
+func hidesServerHeader(hidden []string) bool {
+	for _, name := range hidden {
+		if strings.EqualFold(name, "Server") {
+			return true
+		}
+	}
+
+	return false
+}
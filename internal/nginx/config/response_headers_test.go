@@ -0,0 +1,220 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestResponseHeaderMapsAdd(t *testing.T) {
+	headerMaps := newResponseHeaderMaps()
+
+	key1 := responseHeaderMapKey{backendVariable: "backend_group_0", name: "x-backend", replace: true}
+	key2 := responseHeaderMapKey{backendVariable: "backend_group_0", name: "x-backend"}
+
+	parameters := []mapParameter{
+		{Value: "default", Result: `""`},
+		{Value: `"test_service1_80"`, Result: `"one"`},
+	}
+
+	for _, test := range []struct {
+		key      responseHeaderMapKey
+		expected string
+	}{
+		{key: key1, expected: "response_header_0"},
+		{key: key2, expected: "response_header_1"},
+		{key: key1, expected: "response_header_0"}, // the same header of the same rule shares the map
+	} {
+		result := headerMaps.add(test.key, parameters)
+		if result != test.expected {
+			t.Errorf("add() returned %q but expected %q", result, test.expected)
+		}
+	}
+
+	expected := []nginxMap{
+		{Source: "$backend_group_0", Variable: "response_header_0", Parameters: parameters},
+		{Source: "$backend_group_0", Variable: "response_header_1", Parameters: parameters},
+	}
+
+	if diff := cmp.Diff(expected, headerMaps.maps); diff != "" {
+		t.Errorf("add() mismatch on maps (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateResponseHeaders(t *testing.T) {
+	createFilters := func(modifier v1alpha2.HTTPHeaderFilter) []v1alpha2.HTTPRouteFilter {
+		return []v1alpha2.HTTPRouteFilter{
+			{
+				Type:                   v1beta1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &modifier,
+			},
+		}
+	}
+
+	ruleFilters := append(
+		createFilters(v1alpha2.HTTPHeaderFilter{
+			Set:    []v1alpha2.HTTPHeader{{Name: "X-Set", Value: `set "value"`}},
+			Add:    []v1alpha2.HTTPHeader{{Name: "X-Add", Value: "add"}, {Name: "x-set", Value: "ignored"}},
+			Remove: []string{"X-Remove"},
+		}),
+		v1alpha2.HTTPRouteFilter{
+			Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+				Remove: []string{"X-Request"},
+			},
+		},
+	)
+
+	backend1 := responseBackend{
+		address: "test_service1_80",
+		filters: createFilters(v1alpha2.HTTPHeaderFilter{
+			Set:    []v1alpha2.HTTPHeader{{Name: "X-Backend", Value: "one"}, {Name: "X-Set", Value: "ignored"}},
+			Remove: []string{"X-Version"},
+		}),
+	}
+	backend2 := responseBackend{
+		address: "test_service2_80",
+		filters: createFilters(v1alpha2.HTTPHeaderFilter{
+			Add:    []v1alpha2.HTTPHeader{{Name: "x-backend", Value: "two"}},
+			Remove: []string{"Server"},
+		}),
+	}
+	backendDuplicate := responseBackend{
+		address: "test_service1_80",
+		filters: createFilters(v1alpha2.HTTPHeaderFilter{
+			Set: []v1alpha2.HTTPHeader{{Name: "X-Backend", Value: "ignored"}},
+		}),
+	}
+	backendUnresolved := responseBackend{
+		filters: createFilters(v1alpha2.HTTPHeaderFilter{
+			Set: []v1alpha2.HTTPHeader{{Name: "X-Unresolved", Value: "ignored"}},
+		}),
+	}
+
+	tests := []struct {
+		filters         []v1alpha2.HTTPRouteFilter
+		backends        []responseBackend
+		backendVariable string
+		expectedHidden  []string
+		expectedAdded   []httpHeader
+		expectedMaps    []nginxMap
+		msg             string
+	}{
+		{
+			filters:  nil,
+			backends: []responseBackend{{address: "test_service1_80"}},
+			msg:      "no modifications",
+		},
+		{
+			filters:        ruleFilters,
+			backends:       nil,
+			expectedHidden: []string{"X-Set", "X-Remove"},
+			expectedAdded: []httpHeader{
+				{Name: "X-Set", Value: `set \"value\"`},
+				{Name: "X-Add", Value: "add"},
+			},
+			msg: "rule modifications",
+		},
+		{
+			filters:        ruleFilters,
+			backends:       []responseBackend{backend1},
+			expectedHidden: []string{"X-Set", "X-Remove", "X-Backend", "X-Version"},
+			expectedAdded: []httpHeader{
+				{Name: "X-Set", Value: `set \"value\"`},
+				{Name: "X-Add", Value: "add"},
+				{Name: "X-Backend", Value: "one"},
+			},
+			msg: "single backend modifications",
+		},
+		{
+			filters:         ruleFilters,
+			backends:        []responseBackend{backend1, backend2, backendDuplicate, backendUnresolved},
+			backendVariable: "backend_group_0",
+			// the Server header is removed for all backends
+			expectedHidden: []string{"X-Set", "X-Remove", "X-Backend", "X-Version", "Server"},
+			expectedAdded: []httpHeader{
+				{Name: "X-Set", Value: `set \"value\"`},
+				{Name: "X-Add", Value: "add"},
+				{Name: "X-Backend", Value: "${response_header_0}"},
+				{Name: "X-Backend", Value: "${response_header_1}"},
+				{Name: "X-Version", Value: "${response_header_2}"},
+			},
+			expectedMaps: []nginxMap{
+				{
+					Source:   "$backend_group_0",
+					Variable: "response_header_0",
+					Parameters: []mapParameter{
+						{Value: "default", Result: `"$upstream_http_x_backend"`},
+						{Value: `"test_service1_80"`, Result: `"one"`},
+					},
+				},
+				{
+					Source:   "$backend_group_0",
+					Variable: "response_header_1",
+					Parameters: []mapParameter{
+						{Value: "default", Result: `""`},
+						{Value: `"test_service2_80"`, Result: `"two"`},
+					},
+				},
+				{
+					Source:   "$backend_group_0",
+					Variable: "response_header_2",
+					Parameters: []mapParameter{
+						{Value: "default", Result: `"$upstream_http_x_version"`},
+						{Value: `"test_service1_80"`, Result: `""`},
+					},
+				},
+			},
+			msg: "multiple backends modifications",
+		},
+	}
+
+	for _, test := range tests {
+		headerMaps := newResponseHeaderMaps()
+
+		hidden, added := generateResponseHeaders(test.filters, test.backends, test.backendVariable, headerMaps)
+		if diff := cmp.Diff(test.expectedHidden, hidden); diff != "" {
+			t.Errorf("generateResponseHeaders() %q mismatch on hidden headers (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedAdded, added); diff != "" {
+			t.Errorf("generateResponseHeaders() %q mismatch on added headers (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, headerMaps.maps); diff != "" {
+			t.Errorf("generateResponseHeaders() %q mismatch on maps (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestHidesServerHeader(t *testing.T) {
+	tests := []struct {
+		hidden   []string
+		expected bool
+		msg      string
+	}{
+		{
+			hidden:   nil,
+			expected: false,
+			msg:      "no hidden headers",
+		},
+		{
+			hidden:   []string{"X-Powered-By"},
+			expected: false,
+			msg:      "other headers",
+		},
+		{
+			hidden:   []string{"X-Powered-By", "server"},
+			expected: true,
+			msg:      "server header",
+		},
+	}
+
+	for _, test := range tests {
+		result := hidesServerHeader(test.hidden)
+		if result != test.expected {
+			t.Errorf("hidesServerHeader() returned %v but expected %v for the case of %q", result, test.expected,
+				test.msg)
+		}
+	}
+}
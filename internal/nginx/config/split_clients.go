@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ruleID identifies a rule of a route. A GRPCRoute is represented by the equivalent HTTPRoute, which can have
+// the same namespaced name as an HTTPRoute, so the route is identified by its source.
+type ruleID struct {
+	source  *v1alpha2.HTTPRoute
+	ruleIdx int
+}
+
+// splitClients holds the split_clients blocks that distribute the requests among the weighted backends of the rules.
+// A rule can be attached to multiple servers or have multiple matches, so that the same rule shares the same block.
+type splitClients struct {
+	blocks    []splitClient
+	variables map[ruleID]string
+}
+
+func newSplitClients() *splitClients {
+	return &splitClients{
+		variables: make(map[ruleID]string),
+	}
+}
+
+// add adds the split_clients block for the rule if the rule doesn't have one yet.
+// It returns the name of the variable that holds the backend chosen for a request.
+func (s *splitClients) add(id ruleID, distributions []splitClientDistribution) string {
+	if name, exist := s.variables[id]; exist {
+		return name
+	}
+
+	name := fmt.Sprintf("backend_group_%d", len(s.blocks))
+
+	s.blocks = append(s.blocks, splitClient{
+		VariableName:  name,
+		Distributions: distributions,
+	})
+	s.variables[id] = name
+
+	return name
+}
+
+// calculatePercents calculates the percentages of the requests that each weight gets, in hundredths of
+// a percent. split_clients supports percentages with two decimal places. The percentages are rounded down, so their
+// sum never exceeds 100%. The weights must be positive.
+func calculatePercents(weights []int32) []int64 {
+	var total int64
+	for _, w := range weights {
+		total += int64(w)
+	}
+
+	percents := make([]int64, 0, len(weights))
+	for _, w := range weights {
+		percents = append(percents, int64(w)*10000/total)
+	}
+
+	return percents
+}
+
+// formatPercent formats a percentage in hundredths of a percent. For example, 3333 becomes "33.33%".
+func formatPercent(percent int64) string {
+	return fmt.Sprintf("%d.%02d%%", percent/100, percent%100)
+}
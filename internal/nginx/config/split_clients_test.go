@@ -0,0 +1,106 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestSplitClientsAdd(t *testing.T) {
+	splits := newSplitClients()
+
+	distributions := []splitClientDistribution{
+		{Percent: "50.00%", Value: "10.0.0.1:80"},
+		{Percent: "*", Value: "10.0.0.2:80"},
+	}
+
+	hr := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route1"}}
+	// the GRPCRoute with the same namespaced name
+	gr := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route1"}}
+
+	id1 := ruleID{source: hr, ruleIdx: 0}
+	id2 := ruleID{source: hr, ruleIdx: 1}
+	id3 := ruleID{source: gr, ruleIdx: 0}
+
+	for _, test := range []struct {
+		id       ruleID
+		expected string
+	}{
+		{id: id1, expected: "backend_group_0"},
+		{id: id2, expected: "backend_group_1"},
+		{id: id1, expected: "backend_group_0"}, // the same rule shares the block
+		{id: id3, expected: "backend_group_2"},
+	} {
+		result := splits.add(test.id, distributions)
+		if result != test.expected {
+			t.Errorf("add() returned %q but expected %q", result, test.expected)
+		}
+	}
+
+	expected := []splitClient{
+		{VariableName: "backend_group_0", Distributions: distributions},
+		{VariableName: "backend_group_1", Distributions: distributions},
+		{VariableName: "backend_group_2", Distributions: distributions},
+	}
+
+	if diff := cmp.Diff(expected, splits.blocks); diff != "" {
+		t.Errorf("add() mismatch on blocks (-want +got):\n%s", diff)
+	}
+}
+
+func TestCalculatePercents(t *testing.T) {
+	tests := []struct {
+		weights  []int32
+		expected []int64
+		msg      string
+	}{
+		{
+			weights:  []int32{1, 1},
+			expected: []int64{5000, 5000},
+			msg:      "equal weights",
+		},
+		{
+			weights:  []int32{80, 20},
+			expected: []int64{8000, 2000},
+			msg:      "different weights",
+		},
+		{
+			weights:  []int32{1, 1, 1},
+			expected: []int64{3333, 3333, 3333},
+			msg:      "percents are rounded down",
+		},
+		{
+			weights:  []int32{1000000, 1000000, 1},
+			expected: []int64{4999, 4999, 0},
+			msg:      "big weights",
+		},
+	}
+
+	for _, test := range tests {
+		result := calculatePercents(test.weights)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("calculatePercents() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		percent  int64
+		expected string
+	}{
+		{percent: 10000, expected: "100.00%"},
+		{percent: 3333, expected: "33.33%"},
+		{percent: 505, expected: "5.05%"},
+		{percent: 0, expected: "0.00%"},
+	}
+
+	for _, test := range tests {
+		result := formatPercent(test.percent)
+		if result != test.expected {
+			t.Errorf("formatPercent() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
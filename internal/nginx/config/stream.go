@@ -0,0 +1,18 @@
+package config
+
+type streamServers struct {
+	Upstreams []upstream
+	Maps      []nginxMap
+	Servers   []streamServer
+}
+
+// streamServer is a server of the NGINX stream module that passes the connections to ProxyPass.
+// UDP makes the server accept UDP datagrams instead of TCP connections.
+// SSLPreread enables reading the SNI hostname of the TLS connections into the $ssl_preread_server_name variable
+// without terminating TLS.
+type streamServer struct {
+	Port       int32
+	UDP        bool
+	SSLPreread bool
+	ProxyPass  string
+}
@@ -6,8 +6,66 @@ import (
 	"text/template"
 )
 
-var httpServersTemplate = `{{ range $s := .Servers }}
+var httpServersTemplate = `{{ range $u := .Upstreams }}
+upstream {{ $u.Name }} {
+	{{ if $u.LBMethod }}
+	{{ $u.LBMethod }};
+	{{ end }}
+
+	{{ range $s := $u.Servers }}
+	server {{ $s.Address }};
+	{{ end }}
+
+	{{ if $u.Keepalive }}
+	keepalive {{ $u.Keepalive }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $sc := .SplitClients }}
+split_clients $request_id ${{ $sc.VariableName }} {
+	{{ range $d := $sc.Distributions }}
+	{{ $d.Percent }} {{ $d.Value }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $m := .Maps }}
+map {{ $m.Source }} ${{ $m.Variable }} {
+	{{ if $m.Hostnames }}
+	hostnames;
+	{{ end }}
+
+	{{ range $p := $m.Parameters }}
+	{{ $p.Value }} {{ $p.Result }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $s := .Servers }}
+	{{ if $s.IsDefaultSSL }}
+server {
+	listen {{ $s.Port }} ssl http2 default_server;
+
+	ssl_reject_handshake on;
+}
+	{{ else if $s.IsDefaultHTTP }}
+server {
+	listen {{ $s.Port }} default_server;
+
+	default_type text/html;
+	return 404;
+}
+	{{ else }}
 server {
+	{{ if $s.SSL }}
+	listen {{ $s.Port }} ssl http2;
+	ssl_certificate {{ $s.SSL.Certificate }};
+	ssl_certificate_key {{ $s.SSL.CertificateKey }};
+	{{ else }}
+	listen {{ $s.Port }};
+	{{ end }}
+
 	server_name {{ $s.ServerName }};
 
 	{{ range $l := $s.Locations }}
@@ -15,38 +73,173 @@ server {
 		{{ if $l.Internal }}
 		internal;
 		{{ end }}
-		
+
+		{{ if $l.GRPCPass }}
+			{{ if $l.ProxySetHeaders }}
+				{{ range $h := $l.ProxySetHeaders }}
+		grpc_set_header {{ $h.Name }} "{{ $h.Value }}";
+				{{ end }}
+			{{ else }}
+		grpc_set_header Host $host;
+			{{ end }}
+
+			{{ range $h := $l.HideResponseHeaders }}
+		grpc_hide_header {{ $h }};
+			{{ end }}
+		{{ else }}
+			{{ if $l.ProxySetHeaders }}
+				{{ range $h := $l.ProxySetHeaders }}
+		proxy_set_header {{ $h.Name }} "{{ $h.Value }}";
+				{{ end }}
+			{{ else }}
 		proxy_set_header Host $host;
+			{{ end }}
+
+			{{ range $h := $l.HideResponseHeaders }}
+		proxy_hide_header {{ $h }};
+			{{ end }}
+		{{ end }}
+
+		{{ if $l.ServerTokensOff }}
+		server_tokens off;
+		{{ end }}
+
+		{{ range $h := $l.AddResponseHeaders }}
+		add_header {{ $h.Name }} "{{ $h.Value }}" always;
+		{{ end }}
 
 		{{ if $l.HTTPMatchVar }}
 		set $http_matches {{ $l.HTTPMatchVar | printf "%q" }};
 		js_content httpmatches.redirect;
 		{{ end }}
 
+		{{ if $l.Return }}
+		return {{ $l.Return.Code }}{{ if $l.Return.URL }} "{{ $l.Return.URL }}"{{ end }};
+		{{ end }}
+
+		{{ range $m := $l.Mirrors }}
+		mirror {{ $m.Path }};
+		{{ end }}
+
 		{{ if $l.ProxyPass }}
-		proxy_pass {{ $l.ProxyPass }}$request_uri;
+		# the keepalive connections to the upstreams require HTTP/1.1 without the Connection header of the client
+		proxy_http_version 1.1;
+		proxy_set_header Connection "";
+		proxy_pass {{ $l.ProxyPass }}{{ if $l.RewrittenURI }}{{ $l.RewrittenURI }}{{ else }}$request_uri{{ end }};
+		{{ end }}
+
+		{{ if $l.GRPCPass }}
+			{{ if $l.Internal }}
+		# grpc_pass passes the requests with the current URI, which the internal redirect of the matches changed
+		rewrite ^ $request_uri break;
+			{{ end }}
+		grpc_pass {{ $l.GRPCPass }};
 		{{ end }}
 	}
 	{{ end }}
 }
+	{{ end }}
 {{ end }}
 `
 
+var streamServersTemplate = `{{ range $u := .Upstreams }}
+upstream {{ $u.Name }} {
+	{{ if $u.LBMethod }}
+	{{ $u.LBMethod }};
+	{{ end }}
+
+	{{ range $s := $u.Servers }}
+	server {{ $s.Address }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $m := .Maps }}
+map {{ $m.Source }} ${{ $m.Variable }} {
+	{{ if $m.Hostnames }}
+	hostnames;
+	{{ end }}
+
+	{{ range $p := $m.Parameters }}
+	{{ $p.Value }} {{ $p.Result }};
+	{{ end }}
+}
+{{ end }}
+
+{{ range $s := .Servers }}
+server {
+	listen {{ $s.Port }}{{ if $s.UDP }} udp{{ end }};
+
+	{{ if $s.SSLPreread }}
+	ssl_preread on;
+	{{ end }}
+
+	proxy_pass {{ $s.ProxyPass }};
+}
+{{ end }}
+`
+
+var mainConfigTemplate = `load_module /usr/lib/nginx/modules/ngx_http_js_module.so;
+
+{{ if .WorkerProcesses }}
+worker_processes {{ .WorkerProcesses }};
+{{ end }}
+
+pid /etc/nginx/nginx.pid;
+
+events {}
+
+http {
+	{{ range $l := .AccessLogs }}
+	log_format {{ $l.Name }} "{{ $l.Format }}";
+	access_log "{{ $l.Destination }}" {{ $l.Name }};
+	{{ end }}
+
+	include /etc/nginx/conf.d/*.conf;
+	js_import /usr/lib/nginx/modules/njs/httpmatches.js;
+}
+
+stream {
+	include /etc/nginx/stream-conf.d/*.conf;
+}
+`
+
 // templateExecutor generates NGINX configuration using a template.
 // Template parsing or executing errors can only occur if there is a bug in the template, so they are handled with panics.
-// For now, we only generate configuration with NGINX http servers, but in the future we will also need to generate
-// the main NGINX configuration file, upstreams, stream servers.
 type templateExecutor struct {
-	httpServersTemplate *template.Template
+	mainConfigTemplate    *template.Template
+	httpServersTemplate   *template.Template
+	streamServersTemplate *template.Template
 }
 
 func newTemplateExecutor() *templateExecutor {
+	mt, err := template.New("main").Parse(mainConfigTemplate)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse main config template: %w", err))
+	}
+
 	t, err := template.New("server").Parse(httpServersTemplate)
 	if err != nil {
 		panic(fmt.Errorf("failed to parse http servers template: %w", err))
 	}
 
-	return &templateExecutor{httpServersTemplate: t}
+	st, err := template.New("stream").Parse(streamServersTemplate)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse stream servers template: %w", err))
+	}
+
+	return &templateExecutor{mainConfigTemplate: mt, httpServersTemplate: t, streamServersTemplate: st}
+}
+
+func (e *templateExecutor) ExecuteForMainConfig(cfg mainConfig) []byte {
+	var buf bytes.Buffer
+
+	err := e.mainConfigTemplate.Execute(&buf, cfg)
+	if err != nil {
+		panic(fmt.Errorf("failed to execute main config template: %w", err))
+	}
+
+	return buf.Bytes()
 }
 
 func (e *templateExecutor) ExecuteForHTTPServers(servers httpServers) []byte {
@@ -59,3 +252,14 @@ func (e *templateExecutor) ExecuteForHTTPServers(servers httpServers) []byte {
 
 	return buf.Bytes()
 }
+
+func (e *templateExecutor) ExecuteForStreamServers(servers streamServers) []byte {
+	var buf bytes.Buffer
+
+	err := e.streamServersTemplate.Execute(&buf, servers)
+	if err != nil {
+		panic(fmt.Errorf("failed to execute stream servers template: %w", err))
+	}
+
+	return buf.Bytes()
+}
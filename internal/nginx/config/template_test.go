@@ -5,17 +5,118 @@ import (
 	"text/template"
 )
 
+func TestExecuteForMainConfig(t *testing.T) {
+	executor := newTemplateExecutor()
+
+	cfg := executor.ExecuteForMainConfig(mainConfig{
+		WorkerProcesses: "2",
+		AccessLogs: []accessLog{
+			{Name: "access_log_0", Format: "$remote_addr $status", Destination: "/dev/stdout"},
+		},
+	})
+	// we only do a sanity check here.
+	// the config generation logic is tested in the Generator tests.
+	if len(cfg) == 0 {
+		t.Error("ExecuteForMainConfig() returned 0-length config")
+	}
+}
+
 func TestExecuteForServer(t *testing.T) {
 	executor := newTemplateExecutor()
 
 	servers := httpServers{
+		Upstreams: []upstream{
+			{
+				Name:     "test_service1_80",
+				LBMethod: "least_conn",
+				Servers: []upstreamServer{
+					{Address: "10.0.0.1:8080"},
+					{Address: "10.0.0.2:8080"},
+				},
+				Keepalive: 16,
+			},
+		},
+		Maps: []nginxMap{
+			{
+				Source:   "$http_my_header",
+				Variable: "my_header_header_var",
+				Parameters: []mapParameter{
+					{Value: "default", Result: `""`},
+					{Value: "~.+", Result: `"$http_my_header,"`},
+				},
+			},
+		},
+		SplitClients: []splitClient{
+			{
+				VariableName: "backend_group_0",
+				Distributions: []splitClientDistribution{
+					{Percent: "50.00%", Value: "10.0.0.1:80"},
+					{Percent: "*", Value: "10.0.0.2:80"},
+				},
+			},
+		},
 		Servers: []server{
+			{
+				IsDefaultHTTP: true,
+				Port:          80,
+			},
 			{
 				ServerName: "example.com",
+				Port:       80,
 				Locations: []location{
 					{
 						Path:      "/",
-						ProxyPass: "http://10.0.0.1",
+						ProxyPass: "http://test_service1_80",
+						ProxySetHeaders: []httpHeader{
+							{Name: "Host", Value: "$host"},
+							{Name: "My-Header", Value: "${my_header_header_var}value"},
+						},
+					},
+				},
+			},
+			{
+				IsDefaultSSL: true,
+				Port:         443,
+			},
+			{
+				ServerName: "example.org",
+				Port:       80,
+				Locations: []location{
+					{
+						Path: "/",
+						Return: &returnVal{
+							Code: 301,
+							URL:  "https://$host$request_uri",
+						},
+					},
+				},
+			},
+			{
+				ServerName: "example.com",
+				Port:       443,
+				SSL: &ssl{
+					Certificate:    "/etc/nginx/secrets/test_secret.pem",
+					CertificateKey: "/etc/nginx/secrets/test_secret.pem",
+				},
+				Locations: []location{
+					{
+						Path:      "/",
+						ProxyPass: "http://test_service1_80",
+					},
+					{
+						Path:                "/api",
+						ProxyPass:           "http://test_service1_80",
+						RewrittenURI:        "$rewritten_uri_0",
+						HideResponseHeaders: []string{"X-Powered-By"},
+						AddResponseHeaders: []httpHeader{
+							{Name: "X-Backend", Value: "${response_header_0}"},
+						},
+					},
+					{
+						Path:                "= /_grpc",
+						GRPCPass:            "grpc://test_service1_80",
+						Internal:            true,
+						HideResponseHeaders: []string{"X-Powered-By"},
 					},
 				},
 			},
@@ -30,6 +131,51 @@ func TestExecuteForServer(t *testing.T) {
 	}
 }
 
+func TestExecuteForStreamServers(t *testing.T) {
+	executor := newTemplateExecutor()
+
+	servers := streamServers{
+		Upstreams: []upstream{
+			{
+				Name:     "test_app_443",
+				LBMethod: "least_conn",
+				Servers: []upstreamServer{
+					{Address: "10.0.0.1:8443"},
+				},
+			},
+		},
+		Maps: []nginxMap{
+			{
+				Source:    "$ssl_preread_server_name",
+				Variable:  "tls_passthrough_backend_443",
+				Hostnames: true,
+				Parameters: []mapParameter{
+					{Value: "*.example.com", Result: "test_app_443"},
+				},
+			},
+		},
+		Servers: []streamServer{
+			{
+				Port:       443,
+				SSLPreread: true,
+				ProxyPass:  "$tls_passthrough_backend_443",
+			},
+			{
+				Port:      53,
+				UDP:       true,
+				ProxyPass: "test_dns_53",
+			},
+		},
+	}
+
+	cfg := executor.ExecuteForStreamServers(servers)
+	// we only do a sanity check here.
+	// the config generation logic is tested in the Generator tests.
+	if len(cfg) == 0 {
+		t.Error("ExecuteForStreamServers() returned 0-length config")
+	}
+}
+
 func TestNewTemplateExecutorPanics(t *testing.T) {
 	defer func() {
 		r := recover()
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+const (
+	// lbMethodAnnotation is the annotation of a Service that configures the load-balancing method of its upstreams.
+	lbMethodAnnotation = "nginx.org/lb-method"
+	// defaultLBMethod is the load-balancing method that NGINX uses when an upstream doesn't configure one.
+	defaultLBMethod = "round_robin"
+	// upstreamKeepalive is the maximum number of idle keepalive connections to the endpoints of an upstream that are
+	// preserved in the cache of each NGINX worker.
+	upstreamKeepalive = 16
+)
+
+// lbMethodSet holds the load-balancing methods that the upstreams of an NGINX module support.
+type lbMethodSet struct {
+	// methods maps the supported fixed values of the load-balancing method annotation to the NGINX directives.
+	// The default round-robin method doesn't require a directive.
+	methods map[string]string
+	// hashVariables holds the names of the variables that the key of the hash method can include. The key cannot
+	// include other variables, because NGINX fails to load the configuration with an unknown variable.
+	hashVariables map[string]struct{}
+	// hashVariablePrefixes holds the prefixes of the names of the variables that the key of the hash method can
+	// include, like the prefix http_ of the variables of the request headers.
+	hashVariablePrefixes []string
+}
+
+// lbMethods holds the load-balancing methods of the http upstreams.
+var lbMethods = lbMethodSet{
+	methods: map[string]string{
+		defaultLBMethod:         "",
+		"least_conn":            "least_conn",
+		"ip_hash":               "ip_hash",
+		"random":                "random",
+		"random two":            "random two",
+		"random two least_conn": "random two least_conn",
+	},
+	hashVariables: map[string]struct{}{
+		"args":               {},
+		"binary_remote_addr": {},
+		"host":               {},
+		"remote_addr":        {},
+		"request_method":     {},
+		"request_uri":        {},
+		"scheme":             {},
+		"server_port":        {},
+		"uri":                {},
+	},
+	hashVariablePrefixes: []string{"arg_", "cookie_", "http_"},
+}
+
+// streamLBMethods holds the load-balancing methods of the stream upstreams. The stream module doesn't support
+// the ip_hash method, and the key of the hash method can only include the variables of the connections.
+var streamLBMethods = lbMethodSet{
+	methods: map[string]string{
+		defaultLBMethod:         "",
+		"least_conn":            "least_conn",
+		"random":                "random",
+		"random two":            "random two",
+		"random two least_conn": "random two least_conn",
+	},
+	hashVariables: map[string]struct{}{
+		"binary_remote_addr": {},
+		"remote_addr":        {},
+		"remote_port":        {},
+		"server_addr":        {},
+		"server_port":        {},
+	},
+}
+
+// upstreams resolves the backends into the upstream blocks with the endpoints of the ready pods of the backends.
+// A backend can be referenced by multiple rules, so that the rules share the same block.
+type upstreams struct {
+	serviceStore state.ServiceStore
+	// allowedCrossNamespaceBackends holds the backends in other namespaces that the routes are allowed to reference.
+	// See state.Configuration.
+	allowedCrossNamespaceBackends map[client.Object]map[types.NamespacedName]struct{}
+	lbMethods                     lbMethodSet
+	keepalive                     int
+	blocks                        []upstream
+	names                         map[string]struct{}
+	warnings                      Warnings
+}
+
+// newUpstreams creates upstreams for the http servers.
+func newUpstreams(
+	serviceStore state.ServiceStore,
+	allowedCrossNamespaceBackends map[client.Object]map[types.NamespacedName]struct{},
+) *upstreams {
+	return &upstreams{
+		serviceStore:                  serviceStore,
+		allowedCrossNamespaceBackends: allowedCrossNamespaceBackends,
+		lbMethods:                     lbMethods,
+		keepalive:                     upstreamKeepalive,
+		names:                         make(map[string]struct{}),
+		warnings:                      newWarnings(),
+	}
+}
+
+// newStreamUpstreams creates upstreams for the stream servers. The stream upstreams don't keep idle connections.
+func newStreamUpstreams(
+	serviceStore state.ServiceStore,
+	allowedCrossNamespaceBackends map[client.Object]map[types.NamespacedName]struct{},
+) *upstreams {
+	return &upstreams{
+		serviceStore:                  serviceStore,
+		allowedCrossNamespaceBackends: allowedCrossNamespaceBackends,
+		lbMethods:                     streamLBMethods,
+		names:                         make(map[string]struct{}),
+		warnings:                      newWarnings(),
+	}
+}
+
+// isAllowed returns true if the route can reference the backend. A route can always reference the backends in its
+// own namespace.
+func (u *upstreams) isAllowed(source client.Object, nsname types.NamespacedName) bool {
+	if nsname.Namespace == source.GetNamespace() {
+		return true
+	}
+
+	_, allowed := u.allowedCrossNamespaceBackends[source][nsname]
+	return allowed
+}
+
+// resolve adds the upstream block for the port of the service if the port doesn't have one yet.
+// It returns the name of the upstream or an error if the service port cannot be resolved into endpoints.
+func (u *upstreams) resolve(nsname types.NamespacedName, port int32) (string, error) {
+	// Namespaces and names of Kubernetes resources cannot include '_', so the name is unique.
+	name := fmt.Sprintf("%s_%s_%d", nsname.Namespace, nsname.Name, port)
+
+	if _, exist := u.names[name]; exist {
+		return name, nil
+	}
+
+	endpoints, err := u.serviceStore.Resolve(nsname, port)
+	if err != nil {
+		return "", err
+	}
+
+	servers := make([]upstreamServer, 0, len(endpoints))
+	for _, ep := range endpoints {
+		servers = append(servers, upstreamServer{
+			Address: net.JoinHostPort(ep.Address, fmt.Sprint(ep.Port)),
+		})
+	}
+
+	var lbMethod string
+
+	if svc, exist := u.serviceStore.Get(nsname); exist {
+		var err error
+
+		lbMethod, err = getLBMethod(svc, u.lbMethods)
+		if err != nil {
+			u.warnings.AddWarningf(svc, "%v; the default method %s is used", err, defaultLBMethod)
+		}
+	}
+
+	u.blocks = append(u.blocks, upstream{
+		Name:      name,
+		LBMethod:  lbMethod,
+		Servers:   servers,
+		Keepalive: u.keepalive,
+	})
+	u.names[name] = struct{}{}
+
+	return name, nil
+}
+
+// getLBMethod returns the NGINX directive of the load-balancing method configured by the annotation of the service,
+// using the supported methods. It returns an empty string for the default method.
+// Besides the fixed values, the annotation supports the hash method with a key and the optional consistent parameter,
+// for example "hash $request_uri consistent".
+func getLBMethod(svc *v1.Service, methods lbMethodSet) (string, error) {
+	value, exist := svc.Annotations[lbMethodAnnotation]
+	if !exist {
+		return "", nil
+	}
+
+	if method, supported := methods.methods[value]; supported {
+		return method, nil
+	}
+
+	fields := strings.Split(value, " ")
+
+	if fields[0] != "hash" || len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && fields[2] != "consistent") {
+		return "", fmt.Errorf("unsupported value %q of the annotation %s", value, lbMethodAnnotation)
+	}
+
+	if err := validateHashKey(fields[1], methods); err != nil {
+		return "", fmt.Errorf("unsupported value %q of the annotation %s: %w", value, lbMethodAnnotation, err)
+	}
+
+	return value, nil
+}
+
+// validateHashKey validates the key of the hash method. The key consists of the supported variables, like
+// $request_uri or ${remote_addr}, and the characters that don't need to be quoted in the NGINX configuration.
+func validateHashKey(key string, methods lbMethodSet) error {
+	if key == "" {
+		return errors.New("the hash key must not be empty")
+	}
+
+	isNameChar := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+
+		if c != '$' {
+			if !isNameChar(c) && !strings.ContainsRune("-.:/", rune(c)) {
+				return fmt.Errorf("the hash key cannot include %q", c)
+			}
+			continue
+		}
+
+		var name string
+
+		if strings.HasPrefix(key[i+1:], "{") {
+			end := strings.IndexByte(key[i:], '}')
+			if end == -1 {
+				return errors.New("the hash key includes an unterminated variable")
+			}
+			name = key[i+2 : i+end]
+			i += end
+		} else {
+			end := i + 1
+			for end < len(key) && isNameChar(key[end]) {
+				end++
+			}
+			name = key[i+1 : end]
+			i = end - 1
+		}
+
+		if !methods.isHashVariable(name) {
+			return fmt.Errorf("the hash key cannot include the variable %q", name)
+		}
+	}
+
+	return nil
+}
+
+// isHashVariable returns true if the key of the hash method can include the variable.
+func (s lbMethodSet) isHashVariable(name string) bool {
+	if _, exist := s.hashVariables[name]; exist {
+		return true
+	}
+
+	for _, prefix := range s.hashVariablePrefixes {
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+			for _, c := range name {
+				if c != '_' && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
+)
+
+func TestUpstreamsResolve(t *testing.T) {
+	fakeServiceStore := &statefakes.FakeServiceStore{}
+	fakeServiceStore.ResolveStub = func(nsname types.NamespacedName, _ int32) ([]state.Endpoint, error) {
+		switch nsname.Name {
+		case "service1":
+			return []state.Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080},
+			}, nil
+		case "service2":
+			return []state.Endpoint{{Address: "fd00::1", Port: 8080}}, nil
+		case "service4":
+			return []state.Endpoint{{Address: "10.0.0.4", Port: 8080}}, nil
+		default:
+			return nil, errors.New("service doesn't exist")
+		}
+	}
+
+	svc1 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "service1"}}
+	svc2 := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "service2",
+			Annotations: map[string]string{lbMethodAnnotation: "least_conn"},
+		},
+	}
+	svc4 := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test",
+			Name:        "service4",
+			Annotations: map[string]string{lbMethodAnnotation: "fastest"},
+		},
+	}
+
+	fakeServiceStore.GetStub = func(nsname types.NamespacedName) (*v1.Service, bool) {
+		switch nsname.Name {
+		case "service1":
+			return svc1, true
+		case "service2":
+			return svc2, true
+		case "service4":
+			return svc4, true
+		default:
+			return nil, false
+		}
+	}
+
+	ups := newUpstreams(fakeServiceStore, nil)
+
+	tests := []struct {
+		nsname    types.NamespacedName
+		port      int32
+		expected  string
+		expectErr bool
+		msg       string
+	}{
+		{
+			nsname:   types.NamespacedName{Namespace: "test", Name: "service1"},
+			port:     80,
+			expected: "test_service1_80",
+			msg:      "service",
+		},
+		{
+			nsname:   types.NamespacedName{Namespace: "test", Name: "service1"},
+			port:     80,
+			expected: "test_service1_80",
+			msg:      "the same service port shares the upstream",
+		},
+		{
+			nsname:   types.NamespacedName{Namespace: "test", Name: "service1"},
+			port:     81,
+			expected: "test_service1_81",
+			msg:      "another port of the service",
+		},
+		{
+			nsname:   types.NamespacedName{Namespace: "test", Name: "service2"},
+			port:     80,
+			expected: "test_service2_80",
+			msg:      "ipv6 endpoint",
+		},
+		{
+			nsname:   types.NamespacedName{Namespace: "test", Name: "service4"},
+			port:     80,
+			expected: "test_service4_80",
+			msg:      "unsupported load-balancing method",
+		},
+		{
+			nsname:    types.NamespacedName{Namespace: "test", Name: "service3"},
+			port:      80,
+			expected:  "",
+			expectErr: true,
+			msg:       "service cannot be resolved",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := ups.resolve(test.nsname, test.port)
+		if result != test.expected {
+			t.Errorf("resolve() returned %q but expected %q for case %q", result, test.expected, test.msg)
+		}
+		if test.expectErr != (err != nil) {
+			t.Errorf("resolve() returned error %v for case %q", err, test.msg)
+		}
+	}
+
+	servers := []upstreamServer{{Address: "10.0.0.1:8080"}, {Address: "10.0.0.2:8080"}}
+
+	expected := []upstream{
+		{Name: "test_service1_80", Servers: servers, Keepalive: upstreamKeepalive},
+		{Name: "test_service1_81", Servers: servers, Keepalive: upstreamKeepalive},
+		{
+			Name:      "test_service2_80",
+			LBMethod:  "least_conn",
+			Servers:   []upstreamServer{{Address: "[fd00::1]:8080"}},
+			Keepalive: upstreamKeepalive,
+		},
+		{
+			Name:      "test_service4_80",
+			Servers:   []upstreamServer{{Address: "10.0.0.4:8080"}},
+			Keepalive: upstreamKeepalive,
+		},
+	}
+
+	if diff := cmp.Diff(expected, ups.blocks); diff != "" {
+		t.Errorf("resolve() mismatch on blocks (-want +got):\n%s", diff)
+	}
+
+	expectedWarnings := Warnings{
+		svc4: []string{
+			`unsupported value "fastest" of the annotation nginx.org/lb-method; the default method round_robin is used`,
+		},
+	}
+
+	if diff := cmp.Diff(expectedWarnings, ups.warnings); diff != "" {
+		t.Errorf("resolve() mismatch on warnings (-want +got):\n%s", diff)
+	}
+
+	if callCount := fakeServiceStore.ResolveCallCount(); callCount != 5 {
+		t.Errorf("resolve() called fakeServiceStore.Resolve %d times but expected 5", callCount)
+	}
+}
+
+func TestGetLBMethod(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		methods     *lbMethodSet
+		expected    string
+		expectErr   bool
+		msg         string
+	}{
+		{
+			annotations: nil,
+			expected:    "",
+			msg:         "no annotation",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "round_robin"},
+			expected:    "",
+			msg:         "round robin",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "least_conn"},
+			expected:    "least_conn",
+			msg:         "least connections",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "random two least_conn"},
+			expected:    "random two least_conn",
+			msg:         "random with two choices",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "least_conn; return 200"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "unsupported value",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "ip_hash"},
+			expected:    "ip_hash",
+			msg:         "ip hash",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "ip_hash"},
+			methods:     &streamLBMethods,
+			expected:    "",
+			expectErr:   true,
+			msg:         "ip hash for stream upstreams",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $request_uri"},
+			expected:    "hash $request_uri",
+			msg:         "hash",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash ${http_x_user}:$cookie_session consistent"},
+			expected:    "hash ${http_x_user}:$cookie_session consistent",
+			msg:         "consistent hash with multiple variables",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $remote_addr consistent"},
+			methods:     &streamLBMethods,
+			expected:    "hash $remote_addr consistent",
+			msg:         "consistent hash for stream upstreams",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $request_uri"},
+			methods:     &streamLBMethods,
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash with an http variable for stream upstreams",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash without a key",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash  consistent"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash with an empty key",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $request_uri stable"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash with an unsupported parameter",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $request_uri;return"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash key with an unsupported character",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $upstream_addr"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash key with an unsupported variable",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash ${request_uri"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash key with an unterminated variable",
+		},
+		{
+			annotations: map[string]string{lbMethodAnnotation: "hash $http_"},
+			expected:    "",
+			expectErr:   true,
+			msg:         "hash key with a variable prefix only",
+		},
+	}
+
+	for _, test := range tests {
+		svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+
+		methods := lbMethods
+		if test.methods != nil {
+			methods = *test.methods
+		}
+
+		result, err := getLBMethod(svc, methods)
+		if result != test.expected {
+			t.Errorf("getLBMethod() returned %q but expected %q for case %q", result, test.expected, test.msg)
+		}
+		if test.expectErr != (err != nil) {
+			t.Errorf("getLBMethod() returned error %v for case %q", err, test.msg)
+		}
+	}
+}
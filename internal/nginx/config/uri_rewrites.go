@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// uriRewrite is a rewrite of the path of the proxied requests by a URLRewrite filter.
+type uriRewrite struct {
+	// prefix is the prefix path that the replacement replaces. It is empty if the replacement replaces the full path.
+	prefix      string
+	replacement string
+}
+
+// uriRewrites holds the maps that rewrite the URIs of the proxied requests. The rules that rewrite the paths the same
+// way share the same map.
+type uriRewrites struct {
+	maps      []nginxMap
+	variables map[uriRewrite]string
+}
+
+func newURIRewrites() *uriRewrites {
+	return &uriRewrites{
+		variables: make(map[uriRewrite]string),
+	}
+}
+
+// add adds the map for the rewrite if there is no map for it yet.
+// It returns the name of the variable that holds the rewritten URI of a request.
+func (u *uriRewrites) add(rewrite uriRewrite) string {
+	if name, exist := u.variables[rewrite]; exist {
+		return name
+	}
+
+	name := fmt.Sprintf("rewritten_uri_%d", len(u.maps))
+
+	u.maps = append(u.maps, nginxMap{
+		Source:     "$request_uri",
+		Variable:   name,
+		Parameters: createURIRewriteParameters(rewrite),
+	})
+	u.variables[rewrite] = name
+
+	return name
+}
+
+// createURIRewriteParameters creates the parameters of the map that rewrites the URI of a request. The map replaces
+// the path of the URI and keeps its query string.
+// As required by the Gateway API, the replacement of a prefix respects the boundaries of the path elements: with
+// the prefix /foo and the replacement /bar, /foo becomes /bar and /foo/baz becomes /bar/baz. If the replacement is
+// empty or /, the prefix is removed, so /foo becomes / and /foo/baz becomes /baz.
+// The replacement is validated when the graph is built.
+func createURIRewriteParameters(rewrite uriRewrite) []mapParameter {
+	replacement := nginxStringEscaper.Replace(rewrite.replacement)
+
+	if rewrite.prefix == "" {
+		return []mapParameter{
+			{Value: quoteRegex(`~^[^?]*(\?.*)?$`), Result: `"` + replacement + `$1"`},
+		}
+	}
+
+	replacement = strings.TrimSuffix(replacement, "/")
+
+	fullReplacement := replacement
+	if fullReplacement == "" {
+		fullReplacement = "/"
+	}
+
+	// NGINX matches the locations against the decoded path, while the map matches the original URI. The requests
+	// that the map doesn't match, like the requests with encoded characters in the prefix, keep their URI.
+	parameters := []mapParameter{
+		{Value: "default", Result: "$request_uri"},
+	}
+
+	// the prefix matches whole path elements, so its trailing / doesn't change the paths it matches.
+	// the paths always start with /, so the root prefix only needs the parameter for the paths that continue it
+	prefix := regexp.QuoteMeta(strings.TrimSuffix(rewrite.prefix, "/"))
+	if prefix != "" {
+		parameters = append(parameters, mapParameter{
+			Value:  quoteRegex(`~^` + prefix + `(\?.*)?$`),
+			Result: `"` + fullReplacement + `$1"`,
+		})
+	}
+
+	return append(parameters, mapParameter{
+		Value:  quoteRegex(`~^` + prefix + `(/[^?]*)(\?.*)?$`),
+		Result: `"` + replacement + `$1$2"`,
+	})
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestURIRewritesAdd(t *testing.T) {
+	rewrites := newURIRewrites()
+
+	rewrite1 := uriRewrite{prefix: "/foo", replacement: "/bar"}
+	rewrite2 := uriRewrite{replacement: "/bar"}
+
+	for _, test := range []struct {
+		rewrite  uriRewrite
+		expected string
+	}{
+		{rewrite: rewrite1, expected: "rewritten_uri_0"},
+		{rewrite: rewrite2, expected: "rewritten_uri_1"},
+		{rewrite: rewrite1, expected: "rewritten_uri_0"}, // the same rewrite shares the map
+	} {
+		result := rewrites.add(test.rewrite)
+		if result != test.expected {
+			t.Errorf("add() returned %q but expected %q", result, test.expected)
+		}
+	}
+
+	expected := []nginxMap{
+		{
+			Source:     "$request_uri",
+			Variable:   "rewritten_uri_0",
+			Parameters: createURIRewriteParameters(rewrite1),
+		},
+		{
+			Source:     "$request_uri",
+			Variable:   "rewritten_uri_1",
+			Parameters: createURIRewriteParameters(rewrite2),
+		},
+	}
+
+	if diff := cmp.Diff(expected, rewrites.maps); diff != "" {
+		t.Errorf("add() mismatch on maps (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateURIRewriteParameters(t *testing.T) {
+	tests := []struct {
+		rewrite  uriRewrite
+		expected []mapParameter
+		msg      string
+	}{
+		{
+			rewrite: uriRewrite{replacement: "/full"},
+			expected: []mapParameter{
+				{Value: `"~^[^?]*(\\?.*)?$"`, Result: `"/full$1"`},
+			},
+			msg: "full path",
+		},
+		{
+			rewrite: uriRewrite{prefix: "/foo.bar", replacement: "/baz/"},
+			expected: []mapParameter{
+				{Value: "default", Result: "$request_uri"},
+				{Value: `"~^/foo\\.bar(\\?.*)?$"`, Result: `"/baz$1"`},
+				{Value: `"~^/foo\\.bar(/[^?]*)(\\?.*)?$"`, Result: `"/baz$1$2"`},
+			},
+			msg: "prefix",
+		},
+		{
+			rewrite: uriRewrite{prefix: "/foo", replacement: "/"},
+			expected: []mapParameter{
+				{Value: "default", Result: "$request_uri"},
+				{Value: `"~^/foo(\\?.*)?$"`, Result: `"/$1"`},
+				{Value: `"~^/foo(/[^?]*)(\\?.*)?$"`, Result: `"$1$2"`},
+			},
+			msg: "prefix removed",
+		},
+		{
+			rewrite: uriRewrite{prefix: "/foo/", replacement: "/bar"},
+			expected: []mapParameter{
+				{Value: "default", Result: "$request_uri"},
+				{Value: `"~^/foo(\\?.*)?$"`, Result: `"/bar$1"`},
+				{Value: `"~^/foo(/[^?]*)(\\?.*)?$"`, Result: `"/bar$1$2"`},
+			},
+			msg: "prefix with trailing slash",
+		},
+		{
+			rewrite: uriRewrite{prefix: "/", replacement: "/bar"},
+			expected: []mapParameter{
+				{Value: "default", Result: "$request_uri"},
+				{Value: `"~^(/[^?]*)(\\?.*)?$"`, Result: `"/bar$1$2"`},
+			},
+			msg: "root prefix",
+		},
+	}
+
+	for _, test := range tests {
+		result := createURIRewriteParameters(test.rewrite)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("createURIRewriteParameters() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
@@ -6,16 +6,25 @@ import (
 	"path/filepath"
 )
 
-const confdFolder = "/etc/nginx/conf.d"
+const (
+	mainConfigPath    = "/etc/nginx/nginx.conf"
+	confdFolder       = "/etc/nginx/conf.d"
+	streamConfdFolder = "/etc/nginx/stream-conf.d"
+)
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Manager
 
 // Manager manages NGINX configuration files.
 type Manager interface {
+	// WriteMainConfig writes the main NGINX configuration file on the file system.
+	WriteMainConfig(cfg []byte) error
 	// WriteHTTPServersConfig writes the http servers config on the file system.
 	// The name distinguishes this config among all other configs. For that, it must be unique.
 	// Note that name is not the name of the corresponding configuration file.
 	WriteHTTPServersConfig(name string, cfg []byte) error
+	// WriteStreamServersConfig writes the stream servers config on the file system.
+	// The name distinguishes this config among all other stream configs. For that, it must be unique.
+	WriteStreamServersConfig(name string, cfg []byte) error
 }
 
 // ManagerImpl is an implementation of Manager.
@@ -26,9 +35,19 @@ func NewManagerImpl() *ManagerImpl {
 	return &ManagerImpl{}
 }
 
+func (m *ManagerImpl) WriteMainConfig(cfg []byte) error {
+	return writeConfig(mainConfigPath, cfg)
+}
+
 func (m *ManagerImpl) WriteHTTPServersConfig(name string, cfg []byte) error {
-	path := getPathForServerConfig(name)
+	return writeConfig(getPathForServerConfig(name), cfg)
+}
 
+func (m *ManagerImpl) WriteStreamServersConfig(name string, cfg []byte) error {
+	return writeConfig(getPathForStreamServerConfig(name), cfg)
+}
+
+func writeConfig(path string, cfg []byte) error {
 	file, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("failed to create server config %s: %w", path, err)
@@ -47,3 +66,7 @@ func (m *ManagerImpl) WriteHTTPServersConfig(name string, cfg []byte) error {
 func getPathForServerConfig(name string) string {
 	return filepath.Join(confdFolder, name+".conf")
 }
+
+func getPathForStreamServerConfig(name string) string {
+	return filepath.Join(streamConfdFolder, name+".conf")
+}
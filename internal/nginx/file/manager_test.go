@@ -10,3 +10,12 @@ func TestGetPathForServerConfig(t *testing.T) {
 		t.Errorf("getPathForServerConfig() returned %q but expected %q", result, expected)
 	}
 }
+
+func TestGetPathForStreamServerConfig(t *testing.T) {
+	expected := "/etc/nginx/stream-conf.d/stream-servers.conf"
+
+	result := getPathForStreamServerConfig("stream-servers")
+	if result != expected {
+		t.Errorf("getPathForStreamServerConfig() returned %q but expected %q", result, expected)
+	}
+}
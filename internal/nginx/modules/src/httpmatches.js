@@ -125,6 +125,30 @@ function testMatch(r, match) {
     }
   }
 
+  // check regex headers
+  if (match.headersRegex) {
+    try {
+      let found = headersRegexMatch(r.headersIn, match.headersRegex);
+      if (!found) {
+        return false;
+      }
+    } catch (e) {
+      throw e;
+    }
+  }
+
+  // check regex params
+  if (match.paramsRegex) {
+    try {
+      let found = paramsRegexMatch(r.args, match.paramsRegex);
+      if (!found) {
+        return false;
+      }
+    } catch (e) {
+      throw e;
+    }
+  }
+
   // all match conditions are satisfied so return true
   return true;
 }
@@ -185,12 +209,63 @@ function paramsMatch(requestParams, params) {
   return true;
 }
 
+function headersRegexMatch(requestHeaders, headers) {
+  for (let i = 0; i < headers.length; i++) {
+    const h = headers[i];
+    // We store regex header matches as strings with the format "name:regex".
+    // Header names cannot include ":", but the regex can, so we split on the first occurrence of ":".
+    const idx = h.indexOf(':');
+
+    if (idx <= 0 || idx === h.length - 1) {
+      throw Error(`invalid regex header match: ${h}`);
+    }
+
+    const val = requestHeaders[h.slice(0, idx)];
+
+    if (!val) {
+      return false;
+    }
+
+    // Unlike the exact matches, the regex is tested against the whole header value, including all values of
+    // the header delimited by commas, because the regex itself can include commas.
+    // The regex matches if it matches any part of the value, the same as the path regexes in NGINX.
+    if (!new RegExp(h.slice(idx + 1)).test(val)) {
+      return false;
+    }
+  }
+
+  return true;
+}
+
+function paramsRegexMatch(requestParams, params) {
+  for (let i = 0; i < params.length; i++) {
+    const p = params[i];
+    // We store regex query parameter matches as strings with the format "key=regex".
+    // The regex can include "=", so we split on the first occurrence of "=".
+    const idx = p.indexOf('=');
+
+    if (idx <= 0 || idx === p.length - 1) {
+      throw Error(`invalid regex query parameter: ${p}`);
+    }
+
+    const val = requestParams[p.slice(0, idx)];
+
+    if (!val || !new RegExp(p.slice(idx + 1)).test(val)) {
+      return false;
+    }
+  }
+
+  return true;
+}
+
 export default {
   redirect,
   testMatch,
   findWinningMatch,
   headersMatch,
   paramsMatch,
+  headersRegexMatch,
+  paramsRegexMatch,
   extractMatchesFromRequest,
   HTTP_CODES,
   MATCHES_VARIABLE,
@@ -135,6 +135,24 @@ describe('testMatch', () => {
       request: createRequest({ method: 'GET', headers: { header: 'value' } }), // no params set on request
       expected: false,
     },
+    {
+      name: 'returns true if regex headers and query parameters match',
+      match: { headersRegex: ['header:^v[a-z]+$'], paramsRegex: ['key=^[0-9]+$'] },
+      request: createRequest({ headers: { header: 'value' }, params: { key: '123' } }),
+      expected: true,
+    },
+    {
+      name: 'returns false if regex headers do not match',
+      match: { headers: ['header:value'], headersRegex: ['other:^[0-9]+$'] },
+      request: createRequest({ headers: { header: 'value', other: 'abc' } }),
+      expected: false,
+    },
+    {
+      name: 'returns false if regex query parameters do not match',
+      match: { paramsRegex: ['key=^[0-9]+$'] },
+      request: createRequest({ params: { key: 'abc' } }),
+      expected: false,
+    },
     {
       name: 'throws if headers are malformed',
       match: { headers: ['malformedheader'] },
@@ -334,6 +352,123 @@ describe('paramsMatch', () => {
   });
 });
 
+describe('headersRegexMatch', () => {
+  const tests = [
+    {
+      name: 'throws an error if a header has no colon',
+      headers: ['no-delimiter'],
+      requestHeaders: {},
+      expectThrow: true,
+    },
+    {
+      name: 'throws an error if a header has no name',
+      headers: [':^value$'],
+      requestHeaders: {},
+      expectThrow: true,
+    },
+    {
+      name: 'throws an error if a header has no regex',
+      headers: ['header:'],
+      requestHeaders: {},
+      expectThrow: true,
+    },
+    {
+      name: 'returns false if the header is missing from request',
+      headers: ['header:^value$'],
+      requestHeaders: {},
+      expected: false,
+    },
+    {
+      name: 'returns false if the header value does not match',
+      headers: ['header1:^v[0-9]$', 'header2:^value$'],
+      requestHeaders: {
+        header1: 'v1',
+        header2: 'VALUE', // case matters for header values
+      },
+      expected: false,
+    },
+    {
+      name: 'returns true if all header values match',
+      headers: ['header1:^v[0-9]$', 'header2:val'],
+      requestHeaders: {
+        header1: 'v1',
+        header2: 'some-value', // the regex matches a part of the value
+      },
+      expected: true,
+    },
+    {
+      name: 'returns true if the regex includes colons and commas',
+      headers: ['header:^a:b,c{1,2}$'],
+      requestHeaders: {
+        header: 'a:b,cc',
+      },
+      expected: true,
+    },
+  ];
+
+  tests.forEach((test) => {
+    it(test.name, () => {
+      if (test.expectThrow) {
+        expect(() => hm.headersRegexMatch(test.requestHeaders, test.headers)).to.throw(
+          'invalid regex header match',
+        );
+      } else {
+        expect(hm.headersRegexMatch(test.requestHeaders, test.headers)).to.equal(test.expected);
+      }
+    });
+  });
+});
+
+describe('paramsRegexMatch', () => {
+  const tests = [
+    {
+      name: 'throws an error if a param has no key',
+      params: ['=^value$'],
+      expectThrow: true,
+    },
+    {
+      name: 'throws an error if a param has no regex',
+      params: ['key='],
+      expectThrow: true,
+    },
+    {
+      name: 'throws an error if a param has no equal sign delimiter',
+      params: ['keyregex'],
+      expectThrow: true,
+    },
+    {
+      name: 'returns false if the param is missing from request',
+      params: ['key=^value$'],
+      requestParams: {},
+      expected: false,
+    },
+    {
+      name: 'returns false if the param value does not match',
+      params: ['Arg1=^[0-9]+$', 'arg2=^value$'],
+      requestParams: { Arg1: '123', arg2: 'VALUE' },
+      expected: false,
+    },
+    {
+      name: 'returns true if all param values match',
+      params: ['Arg1=^[0-9]+$', 'arg2=^a=b$'],
+      requestParams: { Arg1: '123', arg2: 'a=b' },
+      expected: true,
+    },
+  ];
+
+  tests.forEach((test) => {
+    it(test.name, () => {
+      if (test.expectThrow) {
+        expect(() => hm.paramsRegexMatch(test.requestParams, test.params)).to.throw(
+          'invalid regex query parameter',
+        );
+      } else {
+        expect(hm.paramsRegexMatch(test.requestParams, test.params)).to.equal(test.expected);
+      }
+    });
+  });
+});
+
 describe('redirect', () => {
   const testAnyMatch = { any: true, redirectPath: '/any' };
   const testHeaderMatches = {
@@ -0,0 +1,44 @@
+package state
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// buildAddresses builds the addresses where the Gateways are reachable.
+// If the Service that exposes NGINX is configured (svcName is not empty), the addresses are the IPs and the hostnames
+// of the LoadBalancer ingress of the Service. They are empty if the Service doesn't exist or its load balancer is not
+// provisioned yet. Otherwise, the address is the IP of the pod.
+func buildAddresses(svcName types.NamespacedName, svc *apiv1.Service, podIP string) []v1alpha2.GatewayAddress {
+	if svcName == (types.NamespacedName{}) {
+		if podIP == "" {
+			return nil
+		}
+		return []v1alpha2.GatewayAddress{newGatewayAddress(v1alpha2.IPAddressType, podIP)}
+	}
+
+	if svc == nil {
+		return nil
+	}
+
+	var addresses []v1alpha2.GatewayAddress
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, newGatewayAddress(v1alpha2.IPAddressType, ingress.IP))
+		}
+		if ingress.Hostname != "" {
+			addresses = append(addresses, newGatewayAddress(v1alpha2.HostnameAddressType, ingress.Hostname))
+		}
+	}
+
+	return addresses
+}
+
+func newGatewayAddress(addressType v1alpha2.AddressType, value string) v1alpha2.GatewayAddress {
+	return v1alpha2.GatewayAddress{
+		Type:  &addressType,
+		Value: value,
+	}
+}
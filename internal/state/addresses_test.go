@@ -0,0 +1,88 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestBuildAddresses(t *testing.T) {
+	svcName := types.NamespacedName{Namespace: "nginx-gateway", Name: "nginx-gateway"}
+
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "nginx-gateway",
+			Name:      "nginx-gateway",
+		},
+		Status: apiv1.ServiceStatus{
+			LoadBalancer: apiv1.LoadBalancerStatus{
+				Ingress: []apiv1.LoadBalancerIngress{
+					{IP: "1.2.3.4"},
+					{Hostname: "lb.example.com"},
+					{IP: "5.6.7.8", Hostname: "lb2.example.com"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		svcName  types.NamespacedName
+		svc      *apiv1.Service
+		podIP    string
+		expected []v1alpha2.GatewayAddress
+		msg      string
+	}{
+		{
+			podIP: "10.0.0.1",
+			expected: []v1alpha2.GatewayAddress{
+				newGatewayAddress(v1alpha2.IPAddressType, "10.0.0.1"),
+			},
+			msg: "service is not configured",
+		},
+		{
+			expected: nil,
+			msg:      "service is not configured and pod ip is unknown",
+		},
+		{
+			svcName: svcName,
+			svc:     svc,
+			podIP:   "10.0.0.1",
+			expected: []v1alpha2.GatewayAddress{
+				newGatewayAddress(v1alpha2.IPAddressType, "1.2.3.4"),
+				newGatewayAddress(v1alpha2.HostnameAddressType, "lb.example.com"),
+				newGatewayAddress(v1alpha2.IPAddressType, "5.6.7.8"),
+				newGatewayAddress(v1alpha2.HostnameAddressType, "lb2.example.com"),
+			},
+			msg: "service with load balancer ingress",
+		},
+		{
+			svcName: svcName,
+			svc: &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "nginx-gateway",
+					Name:      "nginx-gateway",
+				},
+			},
+			podIP:    "10.0.0.1",
+			expected: nil,
+			msg:      "service without load balancer ingress",
+		},
+		{
+			svcName:  svcName,
+			podIP:    "10.0.0.1",
+			expected: nil,
+			msg:      "service doesn't exist",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildAddresses(test.svcName, test.svc, test.podIP)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildAddresses() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
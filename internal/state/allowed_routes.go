@@ -0,0 +1,161 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// protocolRouteKinds maps the supported listener protocols to the kinds of the routes that can attach to them.
+// gRPC requires HTTP/2, which NGINX negotiates with the clients of the HTTPS listeners using ALPN. The HTTP listeners
+// only serve HTTP/1.x, so that GRPCRoutes can only attach to the HTTPS listeners.
+var protocolRouteKinds = map[v1alpha2.ProtocolType][]v1alpha2.Kind{
+	v1beta1.HTTPProtocolType:  {"HTTPRoute"},
+	v1beta1.HTTPSProtocolType: {"HTTPRoute", "GRPCRoute"},
+	v1beta1.TLSProtocolType:   {"TLSRoute"},
+	v1beta1.TCPProtocolType:   {"TCPRoute"},
+	v1beta1.UDPProtocolType:   {"UDPRoute"},
+}
+
+// getSupportedKinds returns the kinds of the routes that can attach to the listener. If the allowedRoutes of
+// the listener don't specify the kinds, the kinds supported by the protocol of the listener are used.
+// The kinds that are specified but not supported by the protocol are returned as unsupported.
+func getSupportedKinds(l v1alpha2.Listener) (supported []v1alpha2.RouteGroupKind, unsupported []string) {
+	protocolKinds := protocolRouteKinds[l.Protocol]
+
+	if l.AllowedRoutes == nil || len(l.AllowedRoutes.Kinds) == 0 {
+		for _, kind := range protocolKinds {
+			group := v1alpha2.Group(v1alpha2.GroupName)
+			supported = append(supported, v1alpha2.RouteGroupKind{Group: &group, Kind: kind})
+		}
+
+		return supported, nil
+	}
+
+	isProtocolKind := func(kind v1alpha2.Kind) bool {
+		for _, k := range protocolKinds {
+			if k == kind {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, k := range l.AllowedRoutes.Kinds {
+		group := v1alpha2.GroupName
+		if k.Group != nil {
+			group = string(*k.Group)
+		}
+
+		if group != v1alpha2.GroupName || !isProtocolKind(k.Kind) {
+			unsupported = append(unsupported, fmt.Sprintf("%s/%s", group, k.Kind))
+			continue
+		}
+
+		supported = append(supported, k)
+	}
+
+	return supported, unsupported
+}
+
+// validateAllowedRoutes validates the allowedRoutes of the listener. If some of the kinds are not supported,
+// it returns the condition that explains why. The listener remains valid unless none of the kinds are supported.
+func validateAllowedRoutes(l v1alpha2.Listener) (valid bool, conds []Condition) {
+	supported, unsupported := getSupportedKinds(l)
+	if len(unsupported) > 0 {
+		msg := fmt.Sprintf("Route kinds %s are not supported by the %s listener",
+			strings.Join(unsupported, ", "), l.Protocol)
+		conds = append(conds, newListenerInvalidRouteKindsCondition(msg))
+	}
+
+	if len(supported) == 0 {
+		return false, conds
+	}
+
+	if err := validateRouteNamespaces(l.AllowedRoutes); err != nil {
+		return false, append(conds, newListenerUnsupportedValueCondition(err.Error()))
+	}
+
+	return true, conds
+}
+
+func validateRouteNamespaces(allowedRoutes *v1alpha2.AllowedRoutes) error {
+	if allowedRoutes == nil || allowedRoutes.Namespaces == nil || allowedRoutes.Namespaces.From == nil {
+		return nil
+	}
+
+	switch from := *allowedRoutes.Namespaces.From; from {
+	case v1beta1.NamespacesFromSame, v1beta1.NamespacesFromAll:
+		return nil
+	case v1beta1.NamespacesFromSelector:
+		if allowedRoutes.Namespaces.Selector == nil {
+			return errors.New("allowedRoutes.namespaces.selector must be specified for the Selector value of from")
+		}
+
+		if _, err := metav1.LabelSelectorAsSelector(allowedRoutes.Namespaces.Selector); err != nil {
+			return fmt.Errorf("allowedRoutes.namespaces.selector is invalid: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("allowedRoutes.namespaces.from: unsupported value %q", from)
+	}
+}
+
+// isRouteAllowedByListener returns true if the allowedRoutes of the listener of the Gateway in the namespace allow
+// the route of the kind in the namespace. If the allowedRoutes don't specify the namespaces, only the routes in
+// the namespace of the Gateway are allowed.
+// The allowedRoutes of the listener must be validated by validateAllowedRoutes beforehand.
+func isRouteAllowedByListener(
+	l *listener,
+	routeKind v1alpha2.Kind,
+	routeNamespace string,
+	gwNamespace string,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) bool {
+	supported, _ := getSupportedKinds(l.Source)
+
+	kindAllowed := false
+	for _, k := range supported {
+		if k.Kind == routeKind {
+			kindAllowed = true
+			break
+		}
+	}
+
+	if !kindAllowed {
+		return false
+	}
+
+	from := v1beta1.NamespacesFromSame
+	if l.Source.AllowedRoutes != nil && l.Source.AllowedRoutes.Namespaces != nil &&
+		l.Source.AllowedRoutes.Namespaces.From != nil {
+		from = *l.Source.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case v1beta1.NamespacesFromAll:
+		return true
+	case v1beta1.NamespacesFromSelector:
+		selector, err := metav1.LabelSelectorAsSelector(l.Source.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			return false
+		}
+
+		ns, exist := namespaces[types.NamespacedName{Name: routeNamespace}]
+		if !exist {
+			return false
+		}
+
+		return selector.Matches(labels.Set(ns.Labels))
+	default:
+		return routeNamespace == gwNamespace
+	}
+}
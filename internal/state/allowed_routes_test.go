@@ -0,0 +1,296 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+)
+
+func TestGetSupportedKinds(t *testing.T) {
+	gatewayGroup := (*v1alpha2.Group)(helpers.GetStringPointer(v1alpha2.GroupName))
+
+	tests := []struct {
+		listener            v1alpha2.Listener
+		expectedSupported   []v1alpha2.RouteGroupKind
+		expectedUnsupported []string
+		msg                 string
+	}{
+		{
+			listener: v1alpha2.Listener{
+				Protocol: v1beta1.HTTPSProtocolType,
+			},
+			expectedSupported: []v1alpha2.RouteGroupKind{
+				{Group: gatewayGroup, Kind: "HTTPRoute"},
+				{Group: gatewayGroup, Kind: "GRPCRoute"},
+			},
+			msg: "default kinds",
+		},
+		{
+			listener: v1alpha2.Listener{
+				Protocol: v1beta1.HTTPProtocolType,
+				AllowedRoutes: &v1alpha2.AllowedRoutes{
+					Kinds: []v1alpha2.RouteGroupKind{
+						{Kind: "HTTPRoute"},
+						{Kind: "GRPCRoute"},
+					},
+				},
+			},
+			expectedSupported: []v1alpha2.RouteGroupKind{
+				{Kind: "HTTPRoute"},
+			},
+			expectedUnsupported: []string{"gateway.networking.k8s.io/GRPCRoute"},
+			msg:                 "GRPCRoute requires HTTP/2",
+		},
+		{
+			listener: v1alpha2.Listener{
+				Protocol: v1beta1.UDPProtocolType,
+				AllowedRoutes: &v1alpha2.AllowedRoutes{
+					Kinds: []v1alpha2.RouteGroupKind{
+						{Kind: "UDPRoute"},
+						{Kind: "TCPRoute"},
+						{Group: (*v1alpha2.Group)(helpers.GetStringPointer("example.com")), Kind: "UDPRoute"},
+					},
+				},
+			},
+			expectedSupported: []v1alpha2.RouteGroupKind{
+				{Kind: "UDPRoute"},
+			},
+			expectedUnsupported: []string{"gateway.networking.k8s.io/TCPRoute", "example.com/UDPRoute"},
+			msg:                 "specified kinds",
+		},
+		{
+			listener: v1alpha2.Listener{
+				Protocol: "SCTP",
+			},
+			msg: "unsupported protocol",
+		},
+	}
+
+	for _, test := range tests {
+		supported, unsupported := getSupportedKinds(test.listener)
+		if diff := cmp.Diff(test.expectedSupported, supported); diff != "" {
+			t.Errorf("getSupportedKinds() %q mismatch on supported kinds (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedUnsupported, unsupported); diff != "" {
+			t.Errorf("getSupportedKinds() %q mismatch on unsupported kinds (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateAllowedRoutes(t *testing.T) {
+	createListener := func(allowedRoutes *v1alpha2.AllowedRoutes) v1alpha2.Listener {
+		return v1alpha2.Listener{
+			Protocol:      v1beta1.HTTPProtocolType,
+			AllowedRoutes: allowedRoutes,
+		}
+	}
+
+	fromSelector := (*v1alpha2.FromNamespaces)(helpers.GetStringPointer(string(v1beta1.NamespacesFromSelector)))
+
+	tests := []struct {
+		listener      v1alpha2.Listener
+		expectedValid bool
+		expectedConds []Condition
+		msg           string
+	}{
+		{
+			listener:      createListener(nil),
+			expectedValid: true,
+			msg:           "default allowed routes",
+		},
+		{
+			listener: createListener(&v1alpha2.AllowedRoutes{
+				Namespaces: &v1alpha2.RouteNamespaces{
+					From: fromSelector,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test"},
+					},
+				},
+			}),
+			expectedValid: true,
+			msg:           "valid selector",
+		},
+		{
+			listener: createListener(&v1alpha2.AllowedRoutes{
+				Kinds: []v1alpha2.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "TLSRoute"}},
+			}),
+			expectedValid: true,
+			expectedConds: []Condition{
+				newListenerInvalidRouteKindsCondition(
+					"Route kinds gateway.networking.k8s.io/TLSRoute are not supported by the HTTP listener"),
+			},
+			msg: "some kinds are unsupported",
+		},
+		{
+			listener: createListener(&v1alpha2.AllowedRoutes{
+				Kinds: []v1alpha2.RouteGroupKind{{Kind: "TLSRoute"}},
+			}),
+			expectedValid: false,
+			expectedConds: []Condition{
+				newListenerInvalidRouteKindsCondition(
+					"Route kinds gateway.networking.k8s.io/TLSRoute are not supported by the HTTP listener"),
+			},
+			msg: "all kinds are unsupported",
+		},
+		{
+			listener: createListener(&v1alpha2.AllowedRoutes{
+				Namespaces: &v1alpha2.RouteNamespaces{
+					From: fromSelector,
+				},
+			}),
+			expectedValid: false,
+			expectedConds: []Condition{
+				newListenerUnsupportedValueCondition(
+					"allowedRoutes.namespaces.selector must be specified for the Selector value of from"),
+			},
+			msg: "missing selector",
+		},
+		{
+			listener: createListener(&v1alpha2.AllowedRoutes{
+				Namespaces: &v1alpha2.RouteNamespaces{
+					From: fromSelector,
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: "Unknown"},
+						},
+					},
+				},
+			}),
+			expectedValid: false,
+			expectedConds: []Condition{
+				newListenerUnsupportedValueCondition(
+					`allowedRoutes.namespaces.selector is invalid: "Unknown" is not a valid label selector operator`),
+			},
+			msg: "invalid selector",
+		},
+	}
+
+	for _, test := range tests {
+		valid, conds := validateAllowedRoutes(test.listener)
+		if valid != test.expectedValid {
+			t.Errorf("validateAllowedRoutes() returned %v but expected %v for the case of %q",
+				valid, test.expectedValid, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedConds, conds); diff != "" {
+			t.Errorf("validateAllowedRoutes() %q mismatch on conditions (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestIsRouteAllowedByListener(t *testing.T) {
+	createListener := func(protocol v1alpha2.ProtocolType, from v1alpha2.FromNamespaces) *listener {
+		l := &listener{
+			Source: v1alpha2.Listener{
+				Protocol: protocol,
+				AllowedRoutes: &v1alpha2.AllowedRoutes{
+					Namespaces: &v1alpha2.RouteNamespaces{
+						From: &from,
+					},
+				},
+			},
+		}
+
+		if from == v1beta1.NamespacesFromSelector {
+			l.Source.AllowedRoutes.Namespaces.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "test"},
+			}
+		}
+
+		return l
+	}
+
+	namespaces := map[types.NamespacedName]*apiv1.Namespace{
+		{Name: "matching"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "matching", Labels: map[string]string{"app": "test"}},
+		},
+		{Name: "not-matching"}: {
+			ObjectMeta: metav1.ObjectMeta{Name: "not-matching", Labels: map[string]string{"app": "other"}},
+		},
+	}
+
+	defaultListener := &listener{
+		Source: v1alpha2.Listener{
+			Protocol: v1beta1.HTTPProtocolType,
+		},
+	}
+
+	tests := []struct {
+		listener       *listener
+		routeKind      v1alpha2.Kind
+		routeNamespace string
+		expected       bool
+		msg            string
+	}{
+		{
+			listener:       defaultListener,
+			routeKind:      "HTTPRoute",
+			routeNamespace: "test",
+			expected:       true,
+			msg:            "default allowed routes and the same namespace",
+		},
+		{
+			listener:       defaultListener,
+			routeKind:      "HTTPRoute",
+			routeNamespace: "other",
+			expected:       false,
+			msg:            "default allowed routes and a different namespace",
+		},
+		{
+			listener:       defaultListener,
+			routeKind:      "TLSRoute",
+			routeNamespace: "test",
+			expected:       false,
+			msg:            "unsupported kind",
+		},
+		{
+			listener:       createListener(v1beta1.HTTPProtocolType, v1beta1.NamespacesFromSame),
+			routeKind:      "HTTPRoute",
+			routeNamespace: "other",
+			expected:       false,
+			msg:            "same namespace and a different namespace",
+		},
+		{
+			listener:       createListener(v1beta1.TCPProtocolType, v1beta1.NamespacesFromAll),
+			routeKind:      "TCPRoute",
+			routeNamespace: "other",
+			expected:       true,
+			msg:            "all namespaces",
+		},
+		{
+			listener:       createListener(v1beta1.HTTPProtocolType, v1beta1.NamespacesFromSelector),
+			routeKind:      "HTTPRoute",
+			routeNamespace: "matching",
+			expected:       true,
+			msg:            "selector and a matching namespace",
+		},
+		{
+			listener:       createListener(v1beta1.HTTPProtocolType, v1beta1.NamespacesFromSelector),
+			routeKind:      "HTTPRoute",
+			routeNamespace: "not-matching",
+			expected:       false,
+			msg:            "selector and a not matching namespace",
+		},
+		{
+			listener:       createListener(v1beta1.HTTPProtocolType, v1beta1.NamespacesFromSelector),
+			routeKind:      "HTTPRoute",
+			routeNamespace: "not-existing",
+			expected:       false,
+			msg:            "selector and a namespace that doesn't exist",
+		},
+	}
+
+	for _, test := range tests {
+		result := isRouteAllowedByListener(test.listener, test.routeKind, test.routeNamespace, "test", namespaces)
+		if result != test.expected {
+			t.Errorf("isRouteAllowedByListener() returned %v but expected %v for the case of %q",
+				result, test.expected, test.msg)
+		}
+	}
+}
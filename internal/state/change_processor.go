@@ -2,52 +2,77 @@ package state
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
+)
+
+// ChangeType is the type of the changes captured by the ChangeProcessor.
+type ChangeType int
+
+const (
+	// NoChange means that the captured changes don't affect the configuration or the statuses.
+	NoChange ChangeType = iota
+	// StatusOnlyChange means that the captured changes only affect the statuses, so there is no need to update
+	// the NGINX configuration.
+	StatusOnlyChange
+	// ConfigChange means that the captured changes affect the configuration (and possibly the statuses).
+	ConfigChange
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ChangeProcessor
 
 // ChangeProcessor processes the changes to resources producing the internal representation of the Gateway configuration.
-// ChangeProcessor only supports one Gateway resource.
+// The configuration includes the listeners of all Gateway resources of the GatewayClass.
 type ChangeProcessor interface {
 	// CaptureUpsertChange captures an upsert change to a resource.
-	// It panics if the resource is of unsupported type or if the passed Gateway is different from the one this ChangeProcessor
-	// was created for.
+	// It panics if the resource is of unsupported type or if the passed GatewayClass is different from the one this
+	// ChangeProcessor was created for.
 	CaptureUpsertChange(obj client.Object)
 	// CaptureDeleteChange captures a delete change to a resource.
-	// The method panics if the resource is of unsupported type or if the passed Gateway is different from the one this ChangeProcessor
-	// was created for.
+	// The method panics if the resource is of unsupported type or if the passed GatewayClass is different from the one
+	// this ChangeProcessor was created for.
 	CaptureDeleteChange(resourceType client.Object, nsname types.NamespacedName)
 	// Process processes any captured changes and produces an internal representation of the Gateway configuration and
 	// the status information about the processed resources.
-	// If no changes were captured, the changed return argument will be false and both the configuration and statuses
-	// will be empty.
-	Process() (changed bool, conf Configuration, statuses Statuses)
+	// If no changes were captured, the changeType return argument will be NoChange and both the configuration and
+	// statuses will be empty.
+	Process() (changeType ChangeType, conf Configuration, statuses Statuses)
 }
 
 // ChangeProcessorConfig holds configuration parameters for ChangeProcessorImpl.
 type ChangeProcessorConfig struct {
-	// GatewayNsName is the namespaced name of the Gateway resource.
-	GatewayNsName types.NamespacedName
 	// GatewayCtlrName is the name of the Gateway controller.
 	GatewayCtlrName string
 	// GatewayClassName is the name of the GatewayClass resource.
 	GatewayClassName string
+	// SecretMemoryManager is the secret memory manager.
+	SecretMemoryManager SecretDiskMemoryManager
+	// GatewayServiceName is the namespaced name of the Service that exposes NGINX. The Gateways report the
+	// LoadBalancer ingress of the Service as their addresses. If it is empty, the Gateways report PodIP instead.
+	GatewayServiceName types.NamespacedName
+	// PodIP is the IP of the pod of the NGINX Gateway.
+	PodIP string
 }
 
 type ChangeProcessorImpl struct {
-	store   *store
+	store *store
+	// changed tells if the captured changes affect the configuration.
 	changed bool
-	cfg     ChangeProcessorConfig
+	// statusChanged tells if the captured changes affect only the statuses.
+	statusChanged bool
+	cfg           ChangeProcessorConfig
 
 	lock sync.Mutex
 }
 
-// NewChangeProcessorImpl creates a new ChangeProcessorImpl for the Gateway resource with the configured namespace name.
+// NewChangeProcessorImpl creates a new ChangeProcessorImpl for the Gateway resources of the configured GatewayClass.
 func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 	return &ChangeProcessorImpl{
 		store: newStore(),
@@ -91,6 +116,76 @@ func (c *ChangeProcessorImpl) CaptureUpsertChange(obj client.Object) {
 			c.changed = false
 		}
 		c.store.httpRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.GRPCRoute:
+		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.grpcRoutes[getNamespacedName(obj)]
+		if exist && o.Generation == prev.Generation {
+			c.changed = false
+		}
+		c.store.grpcRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.TLSRoute:
+		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.tlsRoutes[getNamespacedName(obj)]
+		if exist && o.Generation == prev.Generation {
+			c.changed = false
+		}
+		c.store.tlsRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.TCPRoute:
+		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.tcpRoutes[getNamespacedName(obj)]
+		if exist && o.Generation == prev.Generation {
+			c.changed = false
+		}
+		c.store.tcpRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.UDPRoute:
+		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.udpRoutes[getNamespacedName(obj)]
+		if exist && o.Generation == prev.Generation {
+			c.changed = false
+		}
+		c.store.udpRoutes[getNamespacedName(obj)] = o
+	case *v1alpha2.ReferenceGrant:
+		// if the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.referenceGrants[getNamespacedName(obj)]
+		if exist && o.Generation == prev.Generation {
+			c.changed = false
+		}
+		c.store.referenceGrants[getNamespacedName(obj)] = o
+	case *apiv1.Secret:
+		// only the Secrets referenced by the listeners affect the configuration and statuses
+		if !c.store.isReferencedSecret(getNamespacedName(obj), c.cfg.GatewayClassName) {
+			c.changed = false
+		}
+		c.store.secrets[getNamespacedName(obj)] = o
+	case *apiv1.Namespace:
+		// the labels of a Namespace affect which routes the listeners allow. Changing the labels doesn't change
+		// the generation of the resource, so we compare the labels instead
+		prev, exist := c.store.namespaces[getNamespacedName(obj)]
+		if exist && reflect.DeepEqual(prev.Labels, o.Labels) {
+			c.changed = false
+		}
+		c.store.namespaces[getNamespacedName(obj)] = o
+	case *nginxgwv1alpha1.GatewayConfig:
+		// only the GatewayConfig referenced by the GatewayClass affects the configuration and statuses.
+		// If the resource spec hasn't changed (its generation is the same), ignore the upsert
+		prev, exist := c.store.gatewayConfigs[getNamespacedName(obj)]
+		if !c.store.isReferencedGatewayConfig(getNamespacedName(obj)) || (exist && o.Generation == prev.Generation) {
+			c.changed = false
+		}
+		c.store.gatewayConfigs[getNamespacedName(obj)] = o
+	case *apiv1.Service:
+		// only the LoadBalancer ingress of the Service that exposes NGINX affects the statuses, and it never
+		// affects the configuration. The ingress is in the status of the resource, which doesn't change its
+		// generation, so we compare the ingress instead
+		c.changed = false
+		if getNamespacedName(obj) != c.cfg.GatewayServiceName {
+			return
+		}
+		if c.store.gatewayService == nil ||
+			!reflect.DeepEqual(c.store.gatewayService.Status.LoadBalancer, o.Status.LoadBalancer) {
+			c.statusChanged = true
+		}
+		c.store.gatewayService = o
 	default:
 		panic(fmt.Errorf("ChangeProcessor doesn't support %T", obj))
 	}
@@ -112,25 +207,64 @@ func (c *ChangeProcessorImpl) CaptureDeleteChange(resourceType client.Object, ns
 		delete(c.store.gateways, nsname)
 	case *v1alpha2.HTTPRoute:
 		delete(c.store.httpRoutes, nsname)
+	case *v1alpha2.GRPCRoute:
+		delete(c.store.grpcRoutes, nsname)
+	case *v1alpha2.TLSRoute:
+		delete(c.store.tlsRoutes, nsname)
+	case *v1alpha2.TCPRoute:
+		delete(c.store.tcpRoutes, nsname)
+	case *v1alpha2.UDPRoute:
+		delete(c.store.udpRoutes, nsname)
+	case *v1alpha2.ReferenceGrant:
+		delete(c.store.referenceGrants, nsname)
+	case *apiv1.Secret:
+		// only the Secrets referenced by the listeners affect the configuration and statuses
+		if !c.store.isReferencedSecret(nsname, c.cfg.GatewayClassName) {
+			c.changed = false
+		}
+		delete(c.store.secrets, nsname)
+	case *apiv1.Namespace:
+		delete(c.store.namespaces, nsname)
+	case *nginxgwv1alpha1.GatewayConfig:
+		// only the GatewayConfig referenced by the GatewayClass affects the configuration and statuses
+		if !c.store.isReferencedGatewayConfig(nsname) {
+			c.changed = false
+		}
+		delete(c.store.gatewayConfigs, nsname)
+	case *apiv1.Service:
+		// only the Service that exposes NGINX affects the statuses, and it never affects the configuration
+		c.changed = false
+		if nsname != c.cfg.GatewayServiceName {
+			return
+		}
+		c.statusChanged = true
+		c.store.gatewayService = nil
 	default:
 		panic(fmt.Errorf("ChangeProcessor doesn't support %T", resourceType))
 	}
 }
 
-func (c *ChangeProcessorImpl) Process() (changed bool, conf Configuration, statuses Statuses) {
+func (c *ChangeProcessorImpl) Process() (changeType ChangeType, conf Configuration, statuses Statuses) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if !c.changed {
-		return false, conf, statuses
+	switch {
+	case c.changed:
+		changeType = ConfigChange
+	case c.statusChanged:
+		changeType = StatusOnlyChange
+	default:
+		return NoChange, conf, statuses
 	}
 
 	c.changed = false
+	c.statusChanged = false
 
-	graph := buildGraph(c.store, c.cfg.GatewayCtlrName, c.cfg.GatewayClassName)
+	graph := buildGraph(c.store, c.cfg.GatewayCtlrName, c.cfg.GatewayClassName, c.cfg.SecretMemoryManager)
 
 	conf = buildConfiguration(graph)
-	statuses = buildStatuses(graph)
+	addresses := buildAddresses(c.cfg.GatewayServiceName, c.store.gatewayService, c.cfg.PodIP)
+	statuses = buildStatuses(graph, addresses)
 
-	return true, conf, statuses
+	return changeType, conf, statuses
 }
@@ -3,13 +3,17 @@ package state_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state/statefakes"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 var _ = Describe("ChangeProcessor", func() {
@@ -26,6 +30,24 @@ var _ = Describe("ChangeProcessor", func() {
 			processor            state.ChangeProcessor
 		)
 
+		httpRouteKinds := []v1alpha2.RouteGroupKind{
+			{
+				Group: (*v1alpha2.Group)(helpers.GetStringPointer(v1alpha2.GroupName)),
+				Kind:  "HTTPRoute",
+			},
+		}
+
+		gw1NsName := types.NamespacedName{Namespace: "test", Name: "gateway-1"}
+		gw2NsName := types.NamespacedName{Namespace: "test", Name: "gateway-2"}
+
+		gw2ConflictCond := state.Condition{
+			Type:   string(v1beta1.ListenerConditionConflicted),
+			Status: metav1.ConditionTrue,
+			Reason: string(v1beta1.ListenerReasonHostnameConflict),
+			Message: "Multiple listeners for the same port use the same hostname; " +
+				"the conflicting listener listener-80-1 belongs to the Gateway test/gateway-1",
+		}
+
 		BeforeEach(OncePerOrdered, func() {
 			gc = &v1alpha2.GatewayClass{
 				ObjectMeta: metav1.ObjectMeta{
@@ -48,7 +70,7 @@ var _ = Describe("ChangeProcessor", func() {
 					},
 					Spec: v1alpha2.HTTPRouteSpec{
 						CommonRouteSpec: v1alpha2.CommonRouteSpec{
-							ParentRefs: []v1alpha2.ParentRef{
+							ParentRefs: []v1alpha2.ParentReference{
 								{
 									Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 									Name:        v1alpha2.ObjectName(gateway),
@@ -95,7 +117,7 @@ var _ = Describe("ChangeProcessor", func() {
 								Name:     "listener-80-1",
 								Hostname: nil,
 								Port:     80,
-								Protocol: v1alpha2.HTTPProtocolType,
+								Protocol: v1beta1.HTTPProtocolType,
 							},
 						},
 					},
@@ -119,7 +141,7 @@ var _ = Describe("ChangeProcessor", func() {
 			When("no upsert has occurred", func() {
 				It("should return empty configuration and statuses", func() {
 					changed, conf, statuses := processor.Process()
-					Expect(changed).To(BeFalse())
+					Expect(changed).To(Equal(state.NoChange))
 					Expect(conf).To(BeZero())
 					Expect(statuses).To(BeZero())
 				})
@@ -132,12 +154,16 @@ var _ = Describe("ChangeProcessor", func() {
 
 						expectedConf := state.Configuration{}
 						expectedStatuses := state.Statuses{
-							IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-							HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+							GatewayStatuses:   map[types.NamespacedName]state.GatewayStatus{},
+							HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+							GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+							TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+							TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+							UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 						}
 
 						changed, conf, statuses := processor.Process()
-						Expect(changed).To(BeTrue())
+						Expect(changed).To(Equal(state.ConfigChange))
 						Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 						Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 					})
@@ -148,27 +174,32 @@ var _ = Describe("ChangeProcessor", func() {
 
 					expectedConf := state.Configuration{}
 					expectedStatuses := state.Statuses{
-						GatewayStatus: &state.GatewayStatus{
-							NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-							ListenerStatuses: map[string]state.ListenerStatus{
-								"listener-80-1": {
-									Valid:          false,
-									AttachedRoutes: 1,
+						GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+							{Namespace: "test", Name: "gateway-1"}: {
+								ListenerStatuses: map[string]state.ListenerStatus{
+									"listener-80-1": {
+										Valid:          false,
+										AttachedRoutes: 1,
+										SupportedKinds: httpRouteKinds,
+									},
 								},
 							},
 						},
-						IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 						HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 							{Namespace: "test", Name: "hr-1"}: {
-								ParentStatuses: map[string]state.ParentStatus{
-									"listener-80-1": {Attached: false},
+								ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+									{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: false},
 								},
 							},
 						},
+						GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+						TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+						TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+						UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 					}
 
 					changed, conf, statuses := processor.Process()
-					Expect(changed).To(BeTrue())
+					Expect(changed).To(Equal(state.ConfigChange))
 					Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 					Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 				})
@@ -181,9 +212,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -195,33 +228,41 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -232,7 +273,7 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(hr1UpdatedSameGen)
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeFalse())
+				Expect(changed).To(Equal(state.NoChange))
 				Expect(conf).To(BeZero())
 				Expect(statuses).To(BeZero())
 			})
@@ -244,9 +285,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -258,33 +301,41 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -295,7 +346,7 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(gwUpdatedSameGen)
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeFalse())
+				Expect(changed).To(Equal(state.NoChange))
 				Expect(conf).To(BeZero())
 				Expect(statuses).To(BeZero())
 			})
@@ -307,9 +358,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -321,33 +374,41 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gc.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -358,7 +419,7 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureUpsertChange(gcUpdatedSameGen)
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeFalse())
+				Expect(changed).To(Equal(state.NoChange))
 				Expect(conf).To(BeZero())
 				Expect(statuses).To(BeZero())
 			})
@@ -370,9 +431,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -384,33 +447,41 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -418,7 +489,7 @@ var _ = Describe("ChangeProcessor", func() {
 			It("should return empty configuration and statuses after processing without capturing any changes", func() {
 				changed, conf, statuses := processor.Process()
 
-				Expect(changed).To(BeFalse())
+				Expect(changed).To(Equal(state.NoChange))
 				Expect(conf).To(BeZero())
 				Expect(statuses).To(BeZero())
 			})
@@ -430,9 +501,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -444,37 +517,52 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
+						// the listener of gateway-2 conflicts with the listener of the older gateway-1
 						{Namespace: "test", Name: "gateway-2"}: {
-							ObservedGeneration: gw2.Generation,
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          false,
+									AttachedRoutes: 0,
+									SupportedKinds: httpRouteKinds,
+									Conditions:     []state.Condition{gw2ConflictCond},
+								},
+							},
 						},
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -486,9 +574,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "foo.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -500,42 +590,57 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-1"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-1"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
+						// the listener of gateway-2 conflicts with the listener of the older gateway-1
 						{Namespace: "test", Name: "gateway-2"}: {
-							ObservedGeneration: gw2.Generation,
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          false,
+									AttachedRoutes: 0,
+									SupportedKinds: httpRouteKinds,
+									Conditions:     []state.Condition{gw2ConflictCond},
+								},
+							},
 						},
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw1NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 						{Namespace: "test", Name: "hr-2"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: false},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw2NsName, SectionName: "listener-80-1"}: {Attached: false},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -547,9 +652,11 @@ var _ = Describe("ChangeProcessor", func() {
 					HTTPServers: []state.HTTPServer{
 						{
 							Hostname: "bar.example.com",
+							Port:     80,
 							PathRules: []state.PathRule{
 								{
-									Path: "/",
+									Path:     "/",
+									PathType: state.PathTypePrefix,
 									MatchRules: []state.MatchRule{
 										{
 											MatchIdx: 0,
@@ -561,33 +668,41 @@ var _ = Describe("ChangeProcessor", func() {
 							},
 						},
 					},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 1,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-2"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 1,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "hr-2"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"listener-80-1": {Attached: true},
+							ParentStatuses: map[state.ParentRefKey]state.ParentStatus{
+								{Gateway: gw2NsName, SectionName: "listener-80-1"}: {Attached: true},
 							},
 						},
 					},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -596,28 +711,36 @@ var _ = Describe("ChangeProcessor", func() {
 				processor.CaptureDeleteChange(&v1alpha2.HTTPRoute{}, types.NamespacedName{Namespace: "test", Name: "hr-2"})
 
 				expectedConf := state.Configuration{
-					HTTPServers: []state.HTTPServer{},
+					HTTPServers:           []state.HTTPServer{},
+					SSLServers:            []state.HTTPServer{},
+					TLSPassthroughServers: []state.TLSPassthroughServer{},
+					L4Servers:             []state.L4Server{},
 				}
 				expectedStatuses := state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              true,
 						ObservedGeneration: gcUpdated.Generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          true,
-								AttachedRoutes: 0,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-2"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          true,
+									AttachedRoutes: 0,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -627,21 +750,26 @@ var _ = Describe("ChangeProcessor", func() {
 
 				expectedConf := state.Configuration{}
 				expectedStatuses := state.Statuses{
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway-2"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"listener-80-1": {
-								Valid:          false,
-								AttachedRoutes: 0,
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway-2"}: {
+							ListenerStatuses: map[string]state.ListenerStatus{
+								"listener-80-1": {
+									Valid:          false,
+									AttachedRoutes: 0,
+									SupportedKinds: httpRouteKinds,
+								},
 							},
 						},
 					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -651,12 +779,16 @@ var _ = Describe("ChangeProcessor", func() {
 
 				expectedConf := state.Configuration{}
 				expectedStatuses := state.Statuses{
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					GatewayStatuses:   map[types.NamespacedName]state.GatewayStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
@@ -666,18 +798,483 @@ var _ = Describe("ChangeProcessor", func() {
 
 				expectedConf := state.Configuration{}
 				expectedStatuses := state.Statuses{
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{},
-					HTTPRouteStatuses:      map[types.NamespacedName]state.HTTPRouteStatus{},
+					GatewayStatuses:   map[types.NamespacedName]state.GatewayStatus{},
+					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{},
+					GRPCRouteStatuses: map[types.NamespacedName]state.GRPCRouteStatus{},
+					TLSRouteStatuses:  map[types.NamespacedName]state.TLSRouteStatus{},
+					TCPRouteStatuses:  map[types.NamespacedName]state.TCPRouteStatus{},
+					UDPRouteStatuses:  map[types.NamespacedName]state.UDPRouteStatus{},
 				}
 
 				changed, conf, statuses := processor.Process()
-				Expect(changed).To(BeTrue())
+				Expect(changed).To(Equal(state.ConfigChange))
 				Expect(helpers.Diff(expectedConf, conf)).To(BeEmpty())
 				Expect(helpers.Diff(expectedStatuses, statuses)).To(BeEmpty())
 			})
 		})
 	})
 
+	Describe("Processing Secrets", Ordered, func() {
+		const (
+			controllerName = "my.controller"
+			gcName         = "test-class"
+		)
+
+		var (
+			processor       state.ChangeProcessor
+			fakeSecretMgr   *statefakes.FakeSecretDiskMemoryManager
+			secret, another *apiv1.Secret
+		)
+
+		gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+		BeforeAll(func() {
+			fakeSecretMgr = &statefakes.FakeSecretDiskMemoryManager{}
+			fakeSecretMgr.RequestReturns("/etc/nginx/secrets/test_secret.pem", nil)
+
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:     controllerName,
+				GatewayClassName:    gcName,
+				SecretMemoryManager: fakeSecretMgr,
+			})
+
+			secret = &apiv1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "secret",
+				},
+				Type: apiv1.SecretTypeTLS,
+			}
+
+			another = &apiv1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "another",
+				},
+				Type: apiv1.SecretTypeTLS,
+			}
+
+			gc := &v1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: gcName,
+				},
+				Spec: v1alpha2.GatewayClassSpec{
+					ControllerName: controllerName,
+				},
+			}
+
+			gw := &v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1alpha2.GatewaySpec{
+					GatewayClassName: gcName,
+					Listeners: []v1alpha2.Listener{
+						{
+							Name:     "listener-443-1",
+							Hostname: nil,
+							Port:     443,
+							Protocol: v1beta1.HTTPSProtocolType,
+							TLS: &v1alpha2.GatewayTLSConfig{
+								CertificateRefs: []v1alpha2.SecretObjectReference{
+									{
+										Name: v1alpha2.ObjectName(secret.Name),
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			processor.CaptureUpsertChange(gc)
+			processor.CaptureUpsertChange(gw)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.GatewayStatuses[gwNsName].ListenerStatuses["listener-443-1"].Valid).To(BeFalse())
+		})
+
+		It("should not report changes after upserting a Secret that is not referenced", func() {
+			processor.CaptureUpsertChange(another)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report changes and make the listener valid after upserting the referenced Secret", func() {
+			processor.CaptureUpsertChange(secret)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.GatewayStatuses[gwNsName].ListenerStatuses["listener-443-1"].Valid).To(BeTrue())
+
+			Expect(fakeSecretMgr.RequestCallCount()).To(Equal(1))
+			Expect(fakeSecretMgr.RequestArgsForCall(0)).To(Equal(secret))
+		})
+
+		It("should not report changes after deleting a Secret that is not referenced", func() {
+			processor.CaptureDeleteChange(&apiv1.Secret{}, types.NamespacedName{Namespace: "test", Name: "another"})
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report changes and make the listener invalid after deleting the referenced Secret", func() {
+			processor.CaptureDeleteChange(&apiv1.Secret{}, types.NamespacedName{Namespace: "test", Name: "secret"})
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.GatewayStatuses[gwNsName].ListenerStatuses["listener-443-1"].Valid).To(BeFalse())
+		})
+	})
+
+	Describe("Processing Namespaces", Ordered, func() {
+		const (
+			controllerName = "my.controller"
+			gcName         = "test-class"
+		)
+
+		var (
+			processor state.ChangeProcessor
+			ns        *apiv1.Namespace
+		)
+
+		hrNsName := types.NamespacedName{Namespace: "apps", Name: "hr"}
+		parentRefKey := state.ParentRefKey{
+			Gateway:     types.NamespacedName{Namespace: "test", Name: "gateway"},
+			SectionName: "listener-80-1",
+		}
+
+		BeforeAll(func() {
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:  controllerName,
+				GatewayClassName: gcName,
+			})
+
+			ns = &apiv1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "apps",
+					Labels: map[string]string{"gateway": "allowed"},
+				},
+			}
+
+			gc := &v1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: gcName,
+				},
+				Spec: v1alpha2.GatewayClassSpec{
+					ControllerName: controllerName,
+				},
+			}
+
+			from := v1beta1.NamespacesFromSelector
+
+			gw := &v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "gateway",
+				},
+				Spec: v1alpha2.GatewaySpec{
+					GatewayClassName: gcName,
+					Listeners: []v1alpha2.Listener{
+						{
+							Name:     "listener-80-1",
+							Port:     80,
+							Protocol: v1beta1.HTTPProtocolType,
+							AllowedRoutes: &v1alpha2.AllowedRoutes{
+								Namespaces: &v1alpha2.RouteNamespaces{
+									From: &from,
+									Selector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"gateway": "allowed"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			hr := &v1alpha2.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: hrNsName.Namespace,
+					Name:      hrNsName.Name,
+				},
+				Spec: v1alpha2.HTTPRouteSpec{
+					CommonRouteSpec: v1alpha2.CommonRouteSpec{
+						ParentRefs: []v1alpha2.ParentReference{
+							{
+								Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+								Name:        "gateway",
+								SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+							},
+						},
+					},
+					Hostnames: []v1alpha2.Hostname{"foo.example.com"},
+				},
+			}
+
+			processor.CaptureUpsertChange(gc)
+			processor.CaptureUpsertChange(gw)
+			processor.CaptureUpsertChange(hr)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.HTTPRouteStatuses[hrNsName].ParentStatuses[parentRefKey].Attached).To(BeFalse())
+		})
+
+		It("should report changes and attach the route after upserting the selected Namespace", func() {
+			processor.CaptureUpsertChange(ns)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.HTTPRouteStatuses[hrNsName].ParentStatuses[parentRefKey].Attached).To(BeTrue())
+		})
+
+		It("should not report changes after upserting the Namespace with the same labels", func() {
+			processor.CaptureUpsertChange(ns.DeepCopy())
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report changes and detach the route after deleting the Namespace", func() {
+			processor.CaptureDeleteChange(&apiv1.Namespace{}, types.NamespacedName{Name: "apps"})
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.HTTPRouteStatuses[hrNsName].ParentStatuses[parentRefKey].Attached).To(BeFalse())
+		})
+	})
+
+	Describe("Processing GatewayConfigs", Ordered, func() {
+		const (
+			controllerName = "my.controller"
+			gcName         = "test-class"
+		)
+
+		var (
+			processor state.ChangeProcessor
+			gcfg      *nginxgwv1alpha1.GatewayConfig
+		)
+
+		BeforeAll(func() {
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:  controllerName,
+				GatewayClassName: gcName,
+			})
+
+			gcfg = &nginxgwv1alpha1.GatewayConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "nginx",
+					Generation: 1,
+				},
+			}
+
+			gc := &v1alpha2.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: gcName,
+				},
+				Spec: v1alpha2.GatewayClassSpec{
+					ControllerName: controllerName,
+					ParametersRef: &v1alpha2.ParametersReference{
+						Group: "gateway.nginx.org",
+						Kind:  "GatewayConfig",
+						Name:  "nginx",
+					},
+				},
+			}
+
+			processor.CaptureUpsertChange(gc)
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(conf.GatewayConfig).To(BeNil())
+			Expect(statuses.GatewayClassStatus.Valid).To(BeFalse())
+			Expect(statuses.GatewayClassStatus.InvalidParameters).To(BeTrue())
+		})
+
+		It("should report changes and accept the GatewayClass after upserting the referenced GatewayConfig", func() {
+			processor.CaptureUpsertChange(gcfg)
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(conf.GatewayConfig).To(Equal(gcfg))
+			Expect(statuses.GatewayClassStatus.Valid).To(BeTrue())
+		})
+
+		It("should not report changes after upserting the GatewayConfig without generation change", func() {
+			processor.CaptureUpsertChange(gcfg.DeepCopy())
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report changes after upserting the GatewayConfig with generation change", func() {
+			gcfgUpdated := gcfg.DeepCopy()
+			gcfgUpdated.Generation++
+
+			processor.CaptureUpsertChange(gcfgUpdated)
+
+			changed, conf, _ := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(conf.GatewayConfig).To(Equal(gcfgUpdated))
+		})
+
+		It("should not report changes after upserting and deleting an unreferenced GatewayConfig", func() {
+			another := &nginxgwv1alpha1.GatewayConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "another",
+				},
+			}
+
+			processor.CaptureUpsertChange(another)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+
+			processor.CaptureDeleteChange(&nginxgwv1alpha1.GatewayConfig{}, types.NamespacedName{Name: "another"})
+
+			changed, _, _ = processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report changes and reject the GatewayClass after deleting the referenced GatewayConfig", func() {
+			processor.CaptureDeleteChange(&nginxgwv1alpha1.GatewayConfig{}, types.NamespacedName{Name: "nginx"})
+
+			changed, conf, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(conf.GatewayConfig).To(BeNil())
+			Expect(statuses.GatewayClassStatus.Valid).To(BeFalse())
+			Expect(statuses.GatewayClassStatus.InvalidParameters).To(BeTrue())
+		})
+	})
+
+	Describe("Processing the Service that exposes NGINX", Ordered, func() {
+		const (
+			controllerName = "my.controller"
+			gcName         = "test-class"
+		)
+
+		var (
+			processor state.ChangeProcessor
+			svc       *apiv1.Service
+			gwNsName  types.NamespacedName
+		)
+
+		BeforeAll(func() {
+			processor = state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:    controllerName,
+				GatewayClassName:   gcName,
+				GatewayServiceName: types.NamespacedName{Namespace: "nginx-gateway", Name: "nginx-gateway"},
+				PodIP:              "10.0.0.1",
+			})
+
+			svc = &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "nginx-gateway",
+					Name:      "nginx-gateway",
+				},
+			}
+
+			gwNsName = types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+			gw := &v1alpha2.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gwNsName.Namespace,
+					Name:      gwNsName.Name,
+				},
+				Spec: v1alpha2.GatewaySpec{
+					GatewayClassName: gcName,
+					Listeners: []v1alpha2.Listener{
+						{
+							Name:     "listener-80-1",
+							Port:     80,
+							Protocol: v1beta1.HTTPProtocolType,
+						},
+					},
+				},
+			}
+
+			processor.CaptureUpsertChange(gw)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.ConfigChange))
+			Expect(statuses.GatewayStatuses[gwNsName].Addresses).To(BeEmpty())
+		})
+
+		It("should report status changes and no addresses after upserting the Service without a load balancer", func() {
+			processor.CaptureUpsertChange(svc)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.StatusOnlyChange))
+			Expect(statuses.GatewayStatuses[gwNsName].Addresses).To(BeEmpty())
+		})
+
+		It("should report status changes and the addresses after the load balancer of the Service is provisioned", func() {
+			svcUpdated := svc.DeepCopy()
+			svcUpdated.Status.LoadBalancer.Ingress = []apiv1.LoadBalancerIngress{
+				{IP: "1.2.3.4"},
+				{Hostname: "lb.example.com"},
+			}
+
+			processor.CaptureUpsertChange(svcUpdated)
+
+			ipType := v1alpha2.IPAddressType
+			hostnameType := v1alpha2.HostnameAddressType
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.StatusOnlyChange))
+			Expect(statuses.GatewayStatuses[gwNsName].Addresses).To(Equal([]v1alpha2.GatewayAddress{
+				{Type: &ipType, Value: "1.2.3.4"},
+				{Type: &hostnameType, Value: "lb.example.com"},
+			}))
+
+			svc = svcUpdated
+		})
+
+		It("should not report changes after upserting the Service with the same load balancer", func() {
+			svcUpdated := svc.DeepCopy()
+			svcUpdated.Spec.Type = apiv1.ServiceTypeLoadBalancer
+
+			processor.CaptureUpsertChange(svcUpdated)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should not report changes after upserting and deleting another Service", func() {
+			another := &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "another",
+				},
+			}
+
+			processor.CaptureUpsertChange(another)
+
+			changed, _, _ := processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+
+			processor.CaptureDeleteChange(&apiv1.Service{}, types.NamespacedName{Namespace: "test", Name: "another"})
+
+			changed, _, _ = processor.Process()
+			Expect(changed).To(Equal(state.NoChange))
+		})
+
+		It("should report status changes and no addresses after deleting the Service", func() {
+			processor.CaptureDeleteChange(
+				&apiv1.Service{},
+				types.NamespacedName{Namespace: "nginx-gateway", Name: "nginx-gateway"},
+			)
+
+			changed, _, statuses := processor.Process()
+			Expect(changed).To(Equal(state.StatusOnlyChange))
+			Expect(statuses.GatewayStatuses[gwNsName].Addresses).To(BeEmpty())
+		})
+	})
+
 	Describe("Edge cases with panic", func() {
 		var processor state.ChangeProcessor
 
@@ -696,7 +1293,7 @@ var _ = Describe("ChangeProcessor", func() {
 				}
 				Expect(process).Should(Panic())
 			},
-			Entry("an unsupported resource", &v1alpha2.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tcp"}}),
+			Entry("an unsupported resource", &apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "cm"}}),
 			Entry("a wrong gatewayclass", &v1alpha2.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "wrong-class"}}))
 
 		DescribeTable("CaptureDeleteChange must panic",
@@ -706,7 +1303,7 @@ var _ = Describe("ChangeProcessor", func() {
 				}
 				Expect(process).Should(Panic())
 			},
-			Entry("an unsupported resource", &v1alpha2.TCPRoute{}, types.NamespacedName{Namespace: "test", Name: "tcp"}),
+			Entry("an unsupported resource", &apiv1.ConfigMap{}, types.NamespacedName{Namespace: "test", Name: "cm"}),
 			Entry("a wrong gatewayclass", &v1alpha2.GatewayClass{}, types.NamespacedName{Name: "wrong-class"}))
 	})
 })
@@ -0,0 +1,119 @@
+package state
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// listenerReasonUnsupportedValue is used with the Detached condition when a value of the listener is not supported.
+// Unlike the routes, the listeners don't have a reason for the unsupported values in v1beta1 of the Gateway API.
+const listenerReasonUnsupportedValue v1beta1.ListenerConditionReason = "UnsupportedValue"
+
+// Condition defines a condition to be reported in the status of resources.
+// Unlike metav1.Condition, it doesn't include the fields that depend on the time of the status update, like
+// LastTransitionTime.
+type Condition struct {
+	Type    string
+	Status  metav1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+func newListenerUnsupportedProtocolCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionDetached),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1beta1.ListenerReasonUnsupportedProtocol),
+		Message: msg,
+	}
+}
+
+func newListenerPortUnavailableCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionDetached),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1beta1.ListenerReasonPortUnavailable),
+		Message: msg,
+	}
+}
+
+func newListenerInvalidCertificateRefCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.ListenerReasonInvalidCertificateRef),
+		Message: msg,
+	}
+}
+
+func newListenerInvalidRouteKindsCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.ListenerReasonInvalidRouteKinds),
+		Message: msg,
+	}
+}
+
+func newListenerUnsupportedValueCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionDetached),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(listenerReasonUnsupportedValue),
+		Message: msg,
+	}
+}
+
+func newListenerRefNotPermittedCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.ListenerReasonRefNotPermitted),
+		Message: msg,
+	}
+}
+
+func newListenerProtocolConflictCondition() Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionConflicted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1beta1.ListenerReasonProtocolConflict),
+		Message: "Multiple listeners for the same port use different protocols",
+	}
+}
+
+func newListenerHostnameConflictCondition() Condition {
+	return Condition{
+		Type:    string(v1beta1.ListenerConditionConflicted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1beta1.ListenerReasonHostnameConflict),
+		Message: "Multiple listeners for the same port use the same hostname",
+	}
+}
+
+func newRouteUnsupportedValueCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.RouteConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.RouteReasonUnsupportedValue),
+		Message: msg,
+	}
+}
+
+func newRouteRefNotPermittedCondition(msg string) Condition {
+	return Condition{
+		Type:    string(v1beta1.RouteConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.RouteReasonRefNotPermitted),
+		Message: msg,
+	}
+}
+
+func newRouteNotAllowedByListenersCondition() Condition {
+	return Condition{
+		Type:    string(v1beta1.RouteConditionAccepted),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(v1beta1.RouteReasonNotAllowedByListeners),
+		Message: "The allowedRoutes of the listeners don't allow the route",
+	}
+}
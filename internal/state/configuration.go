@@ -2,8 +2,14 @@ package state
 
 import (
 	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // Configuration is an internal representation of Gateway configuration.
@@ -11,22 +17,83 @@ import (
 // configuration.
 type Configuration struct {
 	// HTTPServers holds all HTTPServers.
-	// FIXME(pleshakov) We assume that all servers are HTTP and listen on port 80.
 	HTTPServers []HTTPServer
+	// SSLServers holds all HTTPServers that terminate TLS.
+	SSLServers []HTTPServer
+	// TLSPassthroughServers holds all TLSPassthroughServers.
+	TLSPassthroughServers []TLSPassthroughServer
+	// L4Servers holds all L4Servers.
+	L4Servers []L4Server
+	// AllowedCrossNamespaceBackends holds the Services in other namespaces that the routes of the servers are allowed
+	// to reference by the ReferenceGrants, keyed by the route resources. The backends of a route in other namespaces
+	// that are not included must not be resolved.
+	AllowedCrossNamespaceBackends map[client.Object]map[types.NamespacedName]struct{}
+	// GatewayConfig holds the GatewayConfig resource referenced by the parametersRef of the GatewayClass.
+	// It is nil if the GatewayClass doesn't reference any parameters.
+	GatewayConfig *nginxgwv1alpha1.GatewayConfig
 }
 
 // HTTPServer is a virtual server.
 type HTTPServer struct {
 	// Hostname is the hostname of the server.
 	Hostname string
+	// Port is the port the server listens on.
+	Port int32
 	// PathRules is a collection of routing rules.
 	PathRules []PathRule
+	// SSL holds the SSL configuration options for the server. It is nil for servers that don't terminate TLS.
+	SSL *SSL
 }
 
-// PathRule represents routing rules that share a common path.
+// TLSPassthroughServer is a virtual server that passes the TLS connections for a hostname through to a backend without
+// terminating TLS. NGINX chooses the server by the SNI hostname of the connections.
+type TLSPassthroughServer struct {
+	// Hostname is the hostname of the server. The empty hostname means any hostname.
+	Hostname string
+	// Port is the port the server listens on.
+	Port int32
+	// BackendRef is the backend of the connections.
+	BackendRef v1alpha2.BackendRef
+	// Source is the corresponding TLSRoute resource.
+	Source *v1alpha2.TLSRoute
+}
+
+// L4Server is a server that passes the TCP or UDP connections for a port through to a backend.
+type L4Server struct {
+	// Port is the port the server listens on.
+	Port int32
+	// Protocol is the protocol of the server, either TCP or UDP.
+	Protocol v1alpha2.ProtocolType
+	// BackendRef is the backend of the connections.
+	BackendRef v1alpha2.BackendRef
+	// Source is the corresponding TCPRoute or UDPRoute resource.
+	Source client.Object
+}
+
+// SSL holds the SSL configuration options for a server.
+type SSL struct {
+	// CertificatePath is the path to the file with the certificate and the key.
+	CertificatePath string
+}
+
+// PathType is the type of the path of a PathRule.
+type PathType string
+
+const (
+	// PathTypePrefix means the path matches the request paths by prefix.
+	PathTypePrefix PathType = "prefix"
+	// PathTypeExact means the path matches the request paths exactly.
+	PathTypeExact PathType = "exact"
+	// PathTypeRegex means the path is a regular expression that matches the request paths.
+	PathTypeRegex PathType = "regex"
+)
+
+// PathRule represents routing rules that share a common path and path type.
 type PathRule struct {
 	// Path is a path. For example, '/hello'.
 	Path string
+	// PathType is the type of the path.
+	PathType PathType
 	// MatchRules holds routing rules.
 	MatchRules []MatchRule
 }
@@ -39,8 +106,11 @@ type MatchRule struct {
 	MatchIdx int
 	// RuleIdx is the index of the corresponding rule in the HTTPRoute.
 	RuleIdx int
-	// Source is the corresponding HTTPRoute resource.
+	// Source is the corresponding HTTPRoute resource. For a GRPCRoute, it is the equivalent HTTPRoute.
 	Source *v1alpha2.HTTPRoute
+	// GRPC shows whether the rule belongs to a GRPCRoute, so that NGINX proxies the requests to the backends
+	// using gRPC.
+	GRPC bool
 }
 
 // GetMatch returns the HTTPRouteMatch of the Route .
@@ -54,58 +124,185 @@ func buildConfiguration(graph *graph) Configuration {
 		return Configuration{}
 	}
 
-	if graph.Gateway == nil {
-		return Configuration{}
+	if len(graph.Gateways) == 0 {
+		return Configuration{
+			GatewayConfig: graph.GatewayClass.Config,
+		}
+	}
+
+	// NGINX serves the listeners of all Gateways, so the listeners of different Gateways share the servers
+	listeners := getListeners(graph.Gateways)
+
+	return Configuration{
+		HTTPServers:           buildServers(listeners, v1beta1.HTTPProtocolType),
+		SSLServers:            buildServers(listeners, v1beta1.HTTPSProtocolType),
+		TLSPassthroughServers: buildTLSPassthroughServers(listeners),
+		L4Servers:             buildL4Servers(listeners),
+
+		AllowedCrossNamespaceBackends: buildAllowedCrossNamespaceBackends(listeners),
+
+		GatewayConfig: graph.GatewayClass.Config,
+	}
+}
+
+// getListeners returns the listeners of all the Gateways.
+func getListeners(gateways map[types.NamespacedName]*gateway) []*listener {
+	var listeners []*listener
+
+	for _, gw := range gateways {
+		for _, l := range gw.Listeners {
+			listeners = append(listeners, l)
+		}
+	}
+
+	return listeners
+}
+
+// buildAllowedCrossNamespaceBackends collects the allowed Services in other namespaces of the routes attached to
+// the valid listeners. It returns nil if none of the routes reference Services in other namespaces.
+func buildAllowedCrossNamespaceBackends(
+	listeners []*listener,
+) map[client.Object]map[types.NamespacedName]struct{} {
+	var result map[client.Object]map[types.NamespacedName]struct{}
+
+	add := func(source client.Object, allowed map[types.NamespacedName]struct{}) {
+		if len(allowed) == 0 {
+			return
+		}
+
+		if result == nil {
+			result = make(map[client.Object]map[types.NamespacedName]struct{})
+		}
+		result[source] = allowed
+	}
+
+	for _, l := range listeners {
+		if !l.Valid {
+			continue
+		}
+
+		for _, r := range l.Routes {
+			add(r.Source, r.AllowedCrossNamespaceBackends)
+		}
+
+		for _, r := range l.GRPCRoutes {
+			add(r.Source, r.AllowedCrossNamespaceBackends)
+		}
+
+		for _, r := range l.TLSRoutes {
+			add(r.Source, r.AllowedCrossNamespaceBackends)
+		}
+
+		for _, r := range l.L4Routes {
+			add(r.Source, r.AllowedCrossNamespaceBackends)
+		}
 	}
 
-	// FIXME(pleshakov) For now we only handle paths with prefix matches. Handle exact and regex matches
-	pathRulesForHosts := make(map[string]map[string]PathRule)
+	return result
+}
+
+// serverKey identifies a server by its port and hostname.
+type serverKey struct {
+	port     int32
+	hostname string
+}
+
+// pathKey identifies a PathRule of a server by its path and path type, so that the exact and the prefix rules for
+// the same path don't collide.
+type pathKey struct {
+	path     string
+	pathType PathType
+}
+
+// buildServers builds the servers for the valid listeners of the protocol.
+// Listeners for the same port share the servers for the same hostnames.
+func buildServers(listeners []*listener, protocol v1alpha2.ProtocolType) []HTTPServer {
+	pathRulesForServers := make(map[serverKey]map[pathKey]PathRule)
+	sslForServers := make(map[serverKey]*SSL)
+
+	for _, l := range listeners {
+		if !l.Valid || l.Source.Protocol != protocol {
+			continue
+		}
+
+		port := int32(l.Source.Port)
 
-	for _, l := range graph.Gateway.Listeners {
+		// GRPCRoutes are represented by the equivalent HTTPRoutes, so they share the servers with HTTPRoutes
+		routes := make([]*route, 0, len(l.Routes)+len(l.GRPCRoutes))
 		for _, r := range l.Routes {
-			var hostnames []string
+			routes = append(routes, r)
+		}
+		for _, r := range l.GRPCRoutes {
+			routes = append(routes, r)
+		}
+
+		for _, r := range routes {
+			var keys []serverKey
 
-			for _, h := range r.Source.Spec.Hostnames {
-				if _, exist := l.AcceptedHostnames[string(h)]; exist {
-					hostnames = append(hostnames, string(h))
+			for _, h := range findAcceptedHostnames(l.Source.Hostname, r.Source.Spec.Hostnames) {
+				if _, exist := l.AcceptedHostnames[h]; !exist {
+					continue
 				}
+
+				// the requests for the hostname are served only by the most specific listener for the port
+				if hasMoreSpecificListener(l, h, listeners) {
+					continue
+				}
+
+				keys = append(keys, serverKey{port: port, hostname: h})
 			}
 
-			for _, h := range hostnames {
-				if _, exist := pathRulesForHosts[h]; !exist {
-					pathRulesForHosts[h] = make(map[string]PathRule)
+			for _, k := range keys {
+				if _, exist := pathRulesForServers[k]; !exist {
+					pathRulesForServers[k] = make(map[pathKey]PathRule)
+				}
+
+				if l.SecretPath != "" {
+					sslForServers[k] = &SSL{CertificatePath: l.SecretPath}
 				}
 			}
 
 			for i, rule := range r.Source.Spec.Rules {
-				for _, h := range hostnames {
+				for _, k := range keys {
 					for j, m := range rule.Matches {
-						path := getPath(m.Path)
+						pk := pathKey{
+							pathType: getPathType(m.Path),
+						}
+
+						if pk.pathType == PathTypePrefix {
+							pk.path = getPrefixPath(m.Path)
+						} else {
+							pk.path = getPath(m.Path)
+						}
 
-						rule, exist := pathRulesForHosts[h][path]
+						rule, exist := pathRulesForServers[k][pk]
 						if !exist {
-							rule.Path = path
+							rule.Path = pk.path
+							rule.PathType = pk.pathType
 						}
 
 						rule.MatchRules = append(rule.MatchRules, MatchRule{
 							MatchIdx: j,
 							RuleIdx:  i,
 							Source:   r.Source,
+							GRPC:     r.GRPC,
 						})
 
-						pathRulesForHosts[h][path] = rule
+						pathRulesForServers[k][pk] = rule
 					}
 				}
 			}
 		}
 	}
 
-	httpServers := make([]HTTPServer, 0, len(pathRulesForHosts))
+	servers := make([]HTTPServer, 0, len(pathRulesForServers))
 
-	for h, rules := range pathRulesForHosts {
+	for k, rules := range pathRulesForServers {
 		s := HTTPServer{
-			Hostname:  h,
+			Hostname:  k.hostname,
+			Port:      k.port,
 			PathRules: make([]PathRule, 0, len(rules)),
+			SSL:       sslForServers[k],
 		}
 
 		for _, r := range rules {
@@ -116,20 +313,165 @@ func buildConfiguration(graph *graph) Configuration {
 
 		// sort rules for predictable order
 		sort.Slice(s.PathRules, func(i, j int) bool {
-			return s.PathRules[i].Path < s.PathRules[j].Path
+			return lessPathRule(s.PathRules[i], s.PathRules[j])
 		})
 
-		httpServers = append(httpServers, s)
+		servers = append(servers, s)
 	}
 
 	// sort servers for predictable order
-	sort.Slice(httpServers, func(i, j int) bool {
-		return httpServers[i].Hostname < httpServers[j].Hostname
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].Port != servers[j].Port {
+			return servers[i].Port < servers[j].Port
+		}
+		return servers[i].Hostname < servers[j].Hostname
 	})
 
-	return Configuration{
-		HTTPServers: httpServers,
+	return servers
+}
+
+// buildTLSPassthroughServers builds the servers for the valid TLS listeners.
+// If multiple TLSRoutes attach to the listeners for the same port with the same hostname, the oldest route serves
+// the hostname.
+func buildTLSPassthroughServers(listeners []*listener) []TLSPassthroughServer {
+	routesForServers := make(map[serverKey]*v1alpha2.TLSRoute)
+
+	for _, l := range listeners {
+		if !l.Valid || l.Source.Protocol != v1beta1.TLSProtocolType {
+			continue
+		}
+
+		port := int32(l.Source.Port)
+
+		for _, r := range l.TLSRoutes {
+			for _, h := range findAcceptedTLSHostnames(l.Source.Hostname, r.Source.Spec.Hostnames) {
+				// the connections for the hostname are served only by the most specific listener for the port
+				if hasMoreSpecificListener(l, h, listeners) {
+					continue
+				}
+
+				k := serverKey{port: port, hostname: h}
+
+				if winner, exist := routesForServers[k]; exist && !lessObjectMeta(&r.Source.ObjectMeta, &winner.ObjectMeta) {
+					continue
+				}
+
+				routesForServers[k] = r.Source
+			}
+		}
+	}
+
+	servers := make([]TLSPassthroughServer, 0, len(routesForServers))
+
+	for k, tr := range routesForServers {
+		servers = append(servers, TLSPassthroughServer{
+			Hostname: k.hostname,
+			Port:     k.port,
+			// the route is validated when the graph is built, so it has exactly one rule with one backendRef
+			BackendRef: tr.Spec.Rules[0].BackendRefs[0],
+			Source:     tr,
+		})
+	}
+
+	// sort servers for predictable order
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].Port != servers[j].Port {
+			return servers[i].Port < servers[j].Port
+		}
+		return servers[i].Hostname < servers[j].Hostname
+	})
+
+	return servers
+}
+
+// buildL4Servers builds the servers for the valid TCP and UDP listeners.
+// If multiple routes attach to a listener, the oldest route serves the connections.
+func buildL4Servers(listeners []*listener) []L4Server {
+	servers := make([]L4Server, 0, len(listeners))
+
+	for _, l := range listeners {
+		if !l.Valid || !isL4Listener(l.Source) {
+			continue
+		}
+
+		var winner *l4Route
+
+		for _, r := range l.L4Routes {
+			if winner == nil || lessObject(r.Source, winner.Source) {
+				winner = r
+			}
+		}
+
+		if winner == nil {
+			continue
+		}
+
+		servers = append(servers, L4Server{
+			Port:       int32(l.Source.Port),
+			Protocol:   l.Source.Protocol,
+			BackendRef: winner.BackendRef,
+			Source:     winner.Source,
+		})
+	}
+
+	// sort servers for predictable order
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].Port != servers[j].Port {
+			return servers[i].Port < servers[j].Port
+		}
+		return servers[i].Protocol < servers[j].Protocol
+	})
+
+	return servers
+}
+
+// hasMoreSpecificListener returns true if another valid listener for the same port and protocol as the listener l
+// covers the hostname with a more specific listener hostname.
+func hasMoreSpecificListener(l *listener, hostname string, listeners []*listener) bool {
+	lHostname := getHostname(l.Source.Hostname)
+
+	for _, other := range listeners {
+		if other == l || !other.Valid {
+			continue
+		}
+
+		if other.Source.Port != l.Source.Port || other.Source.Protocol != l.Source.Protocol {
+			continue
+		}
+
+		otherHostname := getHostname(other.Source.Hostname)
+
+		if otherHostname != "" && !matchHostname(otherHostname, hostname) {
+			continue
+		}
+
+		if isMoreSpecificHostname(otherHostname, lHostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lessPathRule orders the rules by path and path type, putting the regex rules after the other rules.
+// NGINX checks the regex locations in the order they appear in the configuration and uses the first matching one.
+// To prevent a short regex from shadowing a more specific one, longer regexes come first.
+func lessPathRule(a, b PathRule) bool {
+	aRegex, bRegex := a.PathType == PathTypeRegex, b.PathType == PathTypeRegex
+
+	if aRegex != bRegex {
+		return bRegex
 	}
+
+	if aRegex && len(a.Path) != len(b.Path) {
+		return len(a.Path) > len(b.Path)
+	}
+
+	if a.Path != b.Path {
+		return a.Path < b.Path
+	}
+
+	return a.PathType < b.PathType
 }
 
 func getPath(path *v1alpha2.HTTPPathMatch) string {
@@ -138,3 +480,32 @@ func getPath(path *v1alpha2.HTTPPathMatch) string {
 	}
 	return *path.Value
 }
+
+// getPrefixPath returns the path of a prefix path match without the trailing slash.
+// A prefix matches the request paths element by element, so the trailing slash doesn't change what the prefix matches:
+// both /foo and /foo/ match /foo, /foo/ and /foo/bar, but not /foobar. As a result, the rules for /foo and /foo/
+// share the same PathRule.
+func getPrefixPath(path *v1alpha2.HTTPPathMatch) string {
+	p := getPath(path)
+	if p == "/" {
+		return p
+	}
+	return strings.TrimSuffix(p, "/")
+}
+
+// getPathType returns the PathType of the path. A path without a type is a prefix path, which is the default type
+// of the Gateway API.
+func getPathType(path *v1alpha2.HTTPPathMatch) PathType {
+	if path == nil || path.Type == nil {
+		return PathTypePrefix
+	}
+
+	switch *path.Type {
+	case v1beta1.PathMatchExact:
+		return PathTypeExact
+	case v1beta1.PathMatchRegularExpression:
+		return PathTypeRegex
+	default:
+		return PathTypePrefix
+	}
+}
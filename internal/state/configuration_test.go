@@ -1,17 +1,22 @@
 package state
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 )
 
 func TestBuildConfiguration(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
 	createRoute := func(name string, hostname string, paths ...string) *v1alpha2.HTTPRoute {
 		rules := make([]v1alpha2.HTTPRouteRule, 0, len(paths))
 		for _, p := range paths {
@@ -32,7 +37,7 @@ func TestBuildConfiguration(t *testing.T) {
 			},
 			Spec: v1alpha2.HTTPRouteSpec{
 				CommonRouteSpec: v1alpha2.CommonRouteSpec{
-					ParentRefs: []v1alpha2.ParentRef{
+					ParentRefs: []v1alpha2.ParentReference{
 						{
 							Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 							Name:        "gateway",
@@ -52,40 +57,118 @@ func TestBuildConfiguration(t *testing.T) {
 
 	routeHR1 := &route{
 		Source: hr1,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 	}
 
 	hr2 := createRoute("hr-2", "bar.example.com", "/")
 
 	routeHR2 := &route{
 		Source: hr2,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 	}
 
 	hr3 := createRoute("hr-3", "foo.example.com", "/", "/third")
 
 	routeHR3 := &route{
 		Source: hr3,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 	}
 
 	hr4 := createRoute("hr-4", "foo.example.com", "/fourth", "/")
 
 	routeHR4 := &route{
 		Source: hr4,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	hr6 := createRoute("hr-6", "foo.example.com", "/", "/exact")
+	hr6.Spec.Rules[0].Matches[0].Path.Type = helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact)
+	hr6.Spec.Rules[1].Matches[0].Path.Type = helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact)
+
+	routeHR6 := &route{
+		Source: hr6,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	hr7 := createRoute("hr-7", "foo.example.com", "/foo/", "/foo")
+
+	routeHR7 := &route{
+		Source: hr7,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	listener80 := v1alpha2.Listener{
+		Name:     "listener-80-1",
+		Port:     80,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	listener8080 := v1alpha2.Listener{
+		Name:     "listener-8080",
+		Port:     8080,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	listenerWildcard := v1alpha2.Listener{
+		Name:     "listener-wildcard",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("*.example.com")),
+		Port:     80,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	listenerFoo := v1alpha2.Listener{
+		Name:     "listener-foo",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+		Port:     80,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	listener443 := v1alpha2.Listener{
+		Name:     "listener-443-1",
+		Port:     443,
+		Protocol: v1beta1.HTTPSProtocolType,
+	}
+
+	hr5 := createRoute("hr-5", "foo.example.com", "/")
+	hr5.Spec.ParentRefs[0].SectionName = (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-443-1"))
+
+	routeHR5 := &route{
+		Source: hr5,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	// the equivalent HTTPRoute of a GRPCRoute with the same name as an HTTPRoute
+	gr1 := createRoute("hr-5", "foo.example.com", "/helloworld.Greeter")
+	gr1.Spec.ParentRefs[0].SectionName = (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-443-1"))
+
+	routeGR1 := &route{
+		Source: gr1,
+		GRPC:   true,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 	}
 
 	tests := []struct {
@@ -99,14 +182,19 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1alpha2.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source:    &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{},
+					},
 				},
 				Routes: map[types.NamespacedName]*route{},
 			},
 			expected: Configuration{
-				HTTPServers: []HTTPServer{},
+				HTTPServers:           []HTTPServer{},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
 			},
 			msg: "no listeners and routes",
 		},
@@ -116,20 +204,26 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1alpha2.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Valid:             true,
-							Routes:            map[types.NamespacedName]*route{},
-							AcceptedHostnames: map[string]struct{}{},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source:            listener80,
+								Valid:             true,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
+							},
 						},
 					},
 				},
 				Routes: map[types.NamespacedName]*route{},
 			},
 			expected: Configuration{
-				HTTPServers: []HTTPServer{},
+				HTTPServers:           []HTTPServer{},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
 			},
 			msg: "listener with no routes",
 		},
@@ -139,18 +233,21 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1alpha2.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Valid: true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
-								{Namespace: "test", Name: "hr-2"}: routeHR2,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
-								"bar.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+									{Namespace: "test", Name: "hr-2"}: routeHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
 							},
 						},
 					},
@@ -164,9 +261,11 @@ func TestBuildConfiguration(t *testing.T) {
 				HTTPServers: []HTTPServer{
 					{
 						Hostname: "bar.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
-								Path: "/",
+								Path:     "/",
+								PathType: PathTypePrefix,
 								MatchRules: []MatchRule{
 									{
 										MatchIdx: 0,
@@ -179,9 +278,11 @@ func TestBuildConfiguration(t *testing.T) {
 					},
 					{
 						Hostname: "foo.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
-								Path: "/",
+								Path:     "/",
+								PathType: PathTypePrefix,
 								MatchRules: []MatchRule{
 									{
 										MatchIdx: 0,
@@ -193,6 +294,9 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
 			},
 			msg: "one listener with two routes for different hostnames",
 		},
@@ -202,17 +306,20 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1alpha2.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Valid: true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-3"}: routeHR3,
-								{Namespace: "test", Name: "hr-4"}: routeHR4,
-							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-3"}: routeHR3,
+									{Namespace: "test", Name: "hr-4"}: routeHR4,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
 						},
 					},
@@ -226,9 +333,11 @@ func TestBuildConfiguration(t *testing.T) {
 				HTTPServers: []HTTPServer{
 					{
 						Hostname: "foo.example.com",
+						Port:     80,
 						PathRules: []PathRule{
 							{
-								Path: "/",
+								Path:     "/",
+								PathType: PathTypePrefix,
 								MatchRules: []MatchRule{
 									{
 										MatchIdx: 0,
@@ -243,7 +352,8 @@ func TestBuildConfiguration(t *testing.T) {
 								},
 							},
 							{
-								Path: "/fourth",
+								Path:     "/fourth",
+								PathType: PathTypePrefix,
 								MatchRules: []MatchRule{
 									{
 										MatchIdx: 0,
@@ -253,7 +363,8 @@ func TestBuildConfiguration(t *testing.T) {
 								},
 							},
 							{
-								Path: "/third",
+								Path:     "/third",
+								PathType: PathTypePrefix,
 								MatchRules: []MatchRule{
 									{
 										MatchIdx: 0,
@@ -265,60 +376,207 @@ func TestBuildConfiguration(t *testing.T) {
 						},
 					},
 				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
 			},
 			msg: "one listener with two routes with the same hostname with and without collisions",
 		},
 		{
 			graph: &graph{
 				GatewayClass: &gatewayClass{
-					Source:   &v1alpha2.GatewayClass{},
-					Valid:    false,
-					ErrorMsg: "error",
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
 				},
-				Gateway: &gateway{
-					Source: &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Valid: true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+							"listener-443-1": {
+								Source:     listener443,
+								Valid:      true,
+								SecretPath: "/etc/nginx/secrets/test_secret.pem",
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-5"}: routeHR5,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+							"listener-443-2": {
+								Source: v1alpha2.Listener{
+									Name:     "listener-443-2",
+									Port:     443,
+									Protocol: v1beta1.HTTPSProtocolType,
+								},
+								Valid:             false,
+								Routes:            map[types.NamespacedName]*route{},
+								AcceptedHostnames: map[string]struct{}{},
 							},
 						},
 					},
 				},
 				Routes: map[types.NamespacedName]*route{
 					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-5"}: routeHR5,
 				},
 			},
-			expected: Configuration{},
-			msg:      "invalid gatewayclass",
+			expected: Configuration{
+				HTTPServers: []HTTPServer{
+					{
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr1,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers: []HTTPServer{
+					{
+						Hostname: "foo.example.com",
+						Port:     443,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr5,
+									},
+								},
+							},
+						},
+						SSL: &SSL{
+							CertificatePath: "/etc/nginx/secrets/test_secret.pem",
+						},
+					},
+				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "http and https listeners with routes for the same hostname",
 		},
 		{
 			graph: &graph{
-				GatewayClass: nil,
-				Gateway: &gateway{
-					Source: &v1alpha2.Gateway{},
-					Listeners: map[string]*listener{
-						"listener-80-1": {
-							Valid: true,
-							Routes: map[types.NamespacedName]*route{
-								{Namespace: "test", Name: "hr-1"}: routeHR1,
+				GatewayClass: &gatewayClass{
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-8080": {
+								Source: listener8080,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-2"}: routeHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"bar.example.com": {},
+								},
 							},
-							AcceptedHostnames: map[string]struct{}{
-								"foo.example.com": {},
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+									{Namespace: "test", Name: "hr-2"}: routeHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
 							},
 						},
 					},
 				},
 				Routes: map[types.NamespacedName]*route{
 					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2,
 				},
 			},
-			expected: Configuration{},
-			msg:      "missing gatewayclass",
+			expected: Configuration{
+				HTTPServers: []HTTPServer{
+					{
+						Hostname: "bar.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr2,
+									},
+								},
+							},
+						},
+					},
+					{
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr1,
+									},
+								},
+							},
+						},
+					},
+					{
+						Hostname: "bar.example.com",
+						Port:     8080,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr2,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "http listeners on different ports",
 		},
 		{
 			graph: &graph{
@@ -326,55 +584,840 @@ func TestBuildConfiguration(t *testing.T) {
 					Source: &v1alpha2.GatewayClass{},
 					Valid:  true,
 				},
-				Gateway: nil,
-				Routes:  map[types.NamespacedName]*route{},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-wildcard": {
+								Source: listenerWildcard,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+									{Namespace: "test", Name: "hr-2"}: routeHR2,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+									"bar.example.com": {},
+								},
+							},
+							"listener-foo": {
+								Source: listenerFoo,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-4"}: routeHR4,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2,
+					{Namespace: "test", Name: "hr-4"}: routeHR4,
+				},
 			},
-			expected: Configuration{},
-			msg:      "missing gateway",
-		},
-	}
-
-	for _, test := range tests {
-		result := buildConfiguration(test.graph)
-		if diff := cmp.Diff(test.expected, result); diff != "" {
-			t.Errorf("buildConfiguration() %q mismatch (-want +got):\n%s", test.msg, diff)
-		}
-	}
-}
-
-func TestGetPath(t *testing.T) {
-	tests := []struct {
-		path     *v1alpha2.HTTPPathMatch
-		expected string
-		msg      string
-	}{
-		{
-			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc")},
-			expected: "/abc",
-			msg:      "normal case",
-		},
-		{
-			path:     nil,
-			expected: "/",
-			msg:      "nil path",
-		},
-		{
-			path:     &v1alpha2.HTTPPathMatch{Value: nil},
-			expected: "/",
-			msg:      "nil value",
+			expected: Configuration{
+				HTTPServers: []HTTPServer{
+					{
+						Hostname: "bar.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr2,
+									},
+								},
+							},
+						},
+					},
+					{
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  1,
+										Source:   hr4,
+									},
+								},
+							},
+							{
+								Path:     "/fourth",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr4,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "the most specific listener serves the hostname",
 		},
 		{
-			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("")},
-			expected: "/",
-			msg:      "empty value",
-		},
-	}
-
-	for _, test := range tests {
-		result := getPath(test.path)
-		if result != test.expected {
-			t.Errorf("getPath() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
-		}
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+									{Namespace: "test", Name: "hr-6"}: routeHR6,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-6"}: routeHR6,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []HTTPServer{
+					{
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypeExact,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr6,
+									},
+								},
+							},
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr1,
+									},
+								},
+							},
+							{
+								Path:     "/exact",
+								PathType: PathTypeExact,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  1,
+										Source:   hr6,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "exact and prefix paths",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-7"}: routeHR7,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-7"}: routeHR7,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []HTTPServer{
+					{
+						Hostname: "foo.example.com",
+						Port:     80,
+						PathRules: []PathRule{
+							{
+								Path:     "/foo",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr7,
+									},
+									{
+										MatchIdx: 0,
+										RuleIdx:  1,
+										Source:   hr7,
+									},
+								},
+							},
+						},
+					},
+				},
+				SSLServers:            []HTTPServer{},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "prefix paths with and without trailing slash",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-443-1": {
+								Source:     listener443,
+								Valid:      true,
+								SecretPath: "/etc/nginx/secrets/test_secret.pem",
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-5"}: routeHR5,
+								},
+								GRPCRoutes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-5"}: routeGR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-5"}: routeHR5,
+				},
+				GRPCRoutes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-5"}: routeGR1,
+				},
+			},
+			expected: Configuration{
+				HTTPServers: []HTTPServer{},
+				SSLServers: []HTTPServer{
+					{
+						Hostname: "foo.example.com",
+						Port:     443,
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   hr5,
+									},
+								},
+							},
+							{
+								Path:     "/helloworld.Greeter",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx: 0,
+										RuleIdx:  0,
+										Source:   gr1,
+										GRPC:     true,
+									},
+								},
+							},
+						},
+						SSL: &SSL{
+							CertificatePath: "/etc/nginx/secrets/test_secret.pem",
+						},
+					},
+				},
+				TLSPassthroughServers: []TLSPassthroughServer{},
+				L4Servers:             []L4Server{},
+			},
+			msg: "https listener with an HTTPRoute and a GRPCRoute with the same name",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source:   &v1alpha2.GatewayClass{},
+					Valid:    false,
+					ErrorMsg: "error",
+				},
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+				},
+			},
+			expected: Configuration{},
+			msg:      "invalid gatewayclass",
+		},
+		{
+			graph: &graph{
+				GatewayClass: nil,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source: &v1alpha2.Gateway{},
+						Listeners: map[string]*listener{
+							"listener-80-1": {
+								Source: listener80,
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{
+									{Namespace: "test", Name: "hr-1"}: routeHR1,
+								},
+								AcceptedHostnames: map[string]struct{}{
+									"foo.example.com": {},
+								},
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+				},
+			},
+			expected: Configuration{},
+			msg:      "missing gatewayclass",
+		},
+		{
+			graph: &graph{
+				GatewayClass: &gatewayClass{
+					Source: &v1alpha2.GatewayClass{},
+					Valid:  true,
+				},
+				Routes: map[types.NamespacedName]*route{},
+			},
+			expected: Configuration{},
+			msg:      "missing gateway",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildConfiguration(test.graph)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildConfiguration() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBuildTLSPassthroughServers(t *testing.T) {
+	createTLSRoute := func(name string, creationTime metav1.Time, hostnames ...v1alpha2.Hostname) *v1alpha2.TLSRoute {
+		return &v1alpha2.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              name,
+				CreationTimestamp: creationTime,
+			},
+			Spec: v1alpha2.TLSRouteSpec{
+				Hostnames: hostnames,
+				Rules: []v1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []v1alpha2.BackendRef{
+							{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: v1alpha2.ObjectName(name + "-backend"),
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(443)),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	earlier := metav1.Now()
+	later := metav1.NewTime(earlier.Add(1))
+
+	trFoo := createTLSRoute("tr-foo", earlier, "foo.example.com")
+	trFooLater := createTLSRoute("tr-foo-later", later, "foo.example.com", "bar.example.com")
+	trAny := createTLSRoute("tr-any", earlier)
+
+	createListener := func(hostname string, routes ...*v1alpha2.TLSRoute) *listener {
+		l := &listener{
+			Source: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
+			},
+			Valid:     true,
+			TLSRoutes: make(map[types.NamespacedName]*tlsRoute),
+		}
+
+		if hostname != "" {
+			l.Source.Hostname = (*v1alpha2.Hostname)(helpers.GetStringPointer(hostname))
+		}
+
+		for _, r := range routes {
+			l.TLSRoutes[getNamespacedName(r)] = &tlsRoute{Source: r}
+		}
+
+		return l
+	}
+
+	invalidListener := createListener("", trAny)
+	invalidListener.Valid = false
+
+	httpsListener := createListener("", trAny)
+	httpsListener.Source.Protocol = v1beta1.HTTPSProtocolType
+
+	tests := []struct {
+		listeners []*listener
+		expected  []TLSPassthroughServer
+		msg       string
+	}{
+		{
+			listeners: []*listener{
+				createListener("", trFooLater, trFoo),
+			},
+			expected: []TLSPassthroughServer{
+				{
+					Hostname:   "bar.example.com",
+					Port:       443,
+					BackendRef: trFooLater.Spec.Rules[0].BackendRefs[0],
+					Source:     trFooLater,
+				},
+				{
+					Hostname:   "foo.example.com",
+					Port:       443,
+					BackendRef: trFoo.Spec.Rules[0].BackendRefs[0],
+					Source:     trFoo,
+				},
+			},
+			msg: "the oldest route serves the hostname",
+		},
+		{
+			listeners: []*listener{
+				createListener("*.example.com", trAny),
+				createListener("foo.example.com", trFoo),
+			},
+			expected: []TLSPassthroughServer{
+				{
+					Hostname:   "*.example.com",
+					Port:       443,
+					BackendRef: trAny.Spec.Rules[0].BackendRefs[0],
+					Source:     trAny,
+				},
+				{
+					Hostname:   "foo.example.com",
+					Port:       443,
+					BackendRef: trFoo.Spec.Rules[0].BackendRefs[0],
+					Source:     trFoo,
+				},
+			},
+			msg: "route without hostnames",
+		},
+		{
+			listeners: []*listener{
+				invalidListener,
+				httpsListener,
+			},
+			expected: []TLSPassthroughServer{},
+			msg:      "invalid and https listeners",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildTLSPassthroughServers(test.listeners)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildTLSPassthroughServers() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBuildL4Servers(t *testing.T) {
+	createBackendRef := func(name string) v1alpha2.BackendRef {
+		return v1alpha2.BackendRef{
+			BackendObjectReference: v1alpha2.BackendObjectReference{
+				Name: v1alpha2.ObjectName(name),
+				Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(53)),
+			},
+		}
+	}
+
+	earlier := metav1.Now()
+	later := metav1.NewTime(earlier.Add(1))
+
+	tcpRoute := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tcp", CreationTimestamp: earlier},
+	}
+	tcpRouteLater := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tcp-later", CreationTimestamp: later},
+	}
+	udpRoute := &v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "udp", CreationTimestamp: earlier},
+	}
+
+	createListener := func(port int32, protocol v1alpha2.ProtocolType, routes ...*l4Route) *listener {
+		l := &listener{
+			Source: v1alpha2.Listener{
+				Port:     v1alpha2.PortNumber(port),
+				Protocol: protocol,
+			},
+			Valid:    true,
+			L4Routes: make(map[types.NamespacedName]*l4Route),
+		}
+
+		for _, r := range routes {
+			l.L4Routes[getNamespacedName(r.Source)] = r
+		}
+
+		return l
+	}
+
+	tcp := &l4Route{Source: tcpRoute, BackendRef: createBackendRef("tcp-backend")}
+	tcpLater := &l4Route{Source: tcpRouteLater, BackendRef: createBackendRef("tcp-later-backend")}
+	udp := &l4Route{Source: udpRoute, BackendRef: createBackendRef("udp-backend")}
+
+	invalidListener := createListener(5432, v1beta1.TCPProtocolType, tcp)
+	invalidListener.Valid = false
+
+	tests := []struct {
+		listeners []*listener
+		expected  []L4Server
+		msg       string
+	}{
+		{
+			listeners: []*listener{
+				createListener(53, v1beta1.UDPProtocolType, udp),
+				createListener(53, v1beta1.TCPProtocolType, tcpLater, tcp),
+				createListener(8080, v1beta1.TCPProtocolType, tcpLater),
+			},
+			expected: []L4Server{
+				{
+					Port:       53,
+					Protocol:   v1beta1.TCPProtocolType,
+					BackendRef: createBackendRef("tcp-backend"),
+					Source:     tcpRoute,
+				},
+				{
+					Port:       53,
+					Protocol:   v1beta1.UDPProtocolType,
+					BackendRef: createBackendRef("udp-backend"),
+					Source:     udpRoute,
+				},
+				{
+					Port:       8080,
+					Protocol:   v1beta1.TCPProtocolType,
+					BackendRef: createBackendRef("tcp-later-backend"),
+					Source:     tcpRouteLater,
+				},
+			},
+			msg: "the oldest route serves the port",
+		},
+		{
+			listeners: []*listener{
+				invalidListener,
+				createListener(53, v1beta1.TCPProtocolType),
+				createListener(80, v1beta1.HTTPProtocolType),
+			},
+			expected: []L4Server{},
+			msg:      "invalid listener, listener without routes and http listener",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildL4Servers(test.listeners)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildL4Servers() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBuildAllowedCrossNamespaceBackends(t *testing.T) {
+	allowed := map[types.NamespacedName]struct{}{
+		{Namespace: "backends", Name: "service1"}: {},
+	}
+
+	hr := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}}
+	hrSameNamespace := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr-same-ns"}}
+	gr := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gr"}}
+	tr := &v1alpha2.TLSRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tr"}}
+	tcpr := &v1alpha2.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "tcpr"}}
+	hrInvalidListener := &v1alpha2.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr-invalid"}}
+
+	tests := []struct {
+		listeners []*listener
+		expected  map[client.Object]map[types.NamespacedName]struct{}
+		msg       string
+	}{
+		{
+			listeners: []*listener{
+				{
+					Valid: true,
+					Routes: map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr"}:         {Source: hr, AllowedCrossNamespaceBackends: allowed},
+						{Namespace: "test", Name: "hr-same-ns"}: {Source: hrSameNamespace},
+					},
+					GRPCRoutes: map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "gr"}: {Source: gr, GRPC: true, AllowedCrossNamespaceBackends: allowed},
+					},
+				},
+				{
+					Valid: true,
+					TLSRoutes: map[types.NamespacedName]*tlsRoute{
+						{Namespace: "test", Name: "tr"}: {Source: tr, AllowedCrossNamespaceBackends: allowed},
+					},
+				},
+				{
+					Valid: true,
+					L4Routes: map[types.NamespacedName]*l4Route{
+						{Namespace: "test", Name: "tcpr"}: {Source: tcpr, AllowedCrossNamespaceBackends: allowed},
+					},
+				},
+				{
+					Valid: false,
+					Routes: map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-invalid"}: {
+							Source:                        hrInvalidListener,
+							AllowedCrossNamespaceBackends: allowed,
+						},
+					},
+				},
+			},
+			expected: map[client.Object]map[types.NamespacedName]struct{}{
+				hr:   allowed,
+				gr:   allowed,
+				tr:   allowed,
+				tcpr: allowed,
+			},
+			msg: "routes with allowed backends",
+		},
+		{
+			listeners: []*listener{
+				{
+					Valid: true,
+					Routes: map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-same-ns"}: {Source: hrSameNamespace},
+					},
+				},
+			},
+			expected: nil,
+			msg:      "no allowed backends",
+		},
+	}
+
+	for _, test := range tests {
+		result := buildAllowedCrossNamespaceBackends(test.listeners)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildAllowedCrossNamespaceBackends() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	tests := []struct {
+		path     *v1alpha2.HTTPPathMatch
+		expected string
+		msg      string
+	}{
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc")},
+			expected: "/abc",
+			msg:      "normal case",
+		},
+		{
+			path:     nil,
+			expected: "/",
+			msg:      "nil path",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: nil},
+			expected: "/",
+			msg:      "nil value",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("")},
+			expected: "/",
+			msg:      "empty value",
+		},
+	}
+
+	for _, test := range tests {
+		result := getPath(test.path)
+		if result != test.expected {
+			t.Errorf("getPath() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetPrefixPath(t *testing.T) {
+	tests := []struct {
+		path     *v1alpha2.HTTPPathMatch
+		expected string
+		msg      string
+	}{
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc")},
+			expected: "/abc",
+			msg:      "no trailing slash",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc/")},
+			expected: "/abc",
+			msg:      "trailing slash",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc/def/")},
+			expected: "/abc/def",
+			msg:      "trailing slash with multiple elements",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/")},
+			expected: "/",
+			msg:      "root path",
+		},
+		{
+			path:     nil,
+			expected: "/",
+			msg:      "nil path",
+		},
+	}
+
+	for _, test := range tests {
+		result := getPrefixPath(test.path)
+		if result != test.expected {
+			t.Errorf("getPrefixPath() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetPathType(t *testing.T) {
+	tests := []struct {
+		path     *v1alpha2.HTTPPathMatch
+		expected PathType
+		msg      string
+	}{
+		{
+			path:     nil,
+			expected: PathTypePrefix,
+			msg:      "nil path",
+		},
+		{
+			path:     &v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/abc")},
+			expected: PathTypePrefix,
+			msg:      "nil type",
+		},
+		{
+			path: &v1alpha2.HTTPPathMatch{
+				Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchPathPrefix),
+				Value: helpers.GetStringPointer("/abc"),
+			},
+			expected: PathTypePrefix,
+			msg:      "prefix",
+		},
+		{
+			path: &v1alpha2.HTTPPathMatch{
+				Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+				Value: helpers.GetStringPointer("/abc"),
+			},
+			expected: PathTypeExact,
+			msg:      "exact",
+		},
+		{
+			path: &v1alpha2.HTTPPathMatch{
+				Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+				Value: helpers.GetStringPointer("/abc.*"),
+			},
+			expected: PathTypeRegex,
+			msg:      "regex",
+		},
+	}
+
+	for _, test := range tests {
+		result := getPathType(test.path)
+		if result != test.expected {
+			t.Errorf("getPathType() returned %q but expected %q for the case of %q", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestLessPathRule(t *testing.T) {
+	rules := []PathRule{
+		{Path: "^/a", PathType: PathTypeRegex},
+		{Path: "/b", PathType: PathTypePrefix},
+		{Path: "^/a/b$", PathType: PathTypeRegex},
+		{Path: "/a", PathType: PathTypePrefix},
+		{Path: "^/b", PathType: PathTypeRegex},
+		{Path: "/a", PathType: PathTypeExact},
+	}
+
+	expected := []PathRule{
+		{Path: "/a", PathType: PathTypeExact},
+		{Path: "/a", PathType: PathTypePrefix},
+		{Path: "/b", PathType: PathTypePrefix},
+		{Path: "^/a/b$", PathType: PathTypeRegex},
+		{Path: "^/a", PathType: PathTypeRegex},
+		{Path: "^/b", PathType: PathTypeRegex},
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return lessPathRule(rules[i], rules[j])
+	})
+
+	if diff := cmp.Diff(expected, rules); diff != "" {
+		t.Errorf("lessPathRule() mismatch (-want +got):\n%s", diff)
 	}
 }
 
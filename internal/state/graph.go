@@ -1,14 +1,27 @@
 package state
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxgw "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
-// gateway represents the winning Gateway resource.
+// gatewayConfigKind is the kind of the resource that the parametersRef of the GatewayClass can reference.
+const gatewayConfigKind = "GatewayConfig"
+
+// gateway represents a Gateway resource of the GatewayClass.
 type gateway struct {
 	// Source is the corresponding Gateway resource.
 	Source *v1alpha2.Gateway
@@ -16,33 +29,109 @@ type gateway struct {
 	Listeners map[string]*listener
 }
 
-// listener represents a listener of the Gateway resource.
-// FIXME(pleshakov) For now, we only support HTTP listeners.
+// listener represents a listener of a Gateway resource.
+// FIXME(pleshakov) For now, we only support HTTP, HTTPS, TCP and UDP listeners and TLS listeners in the Passthrough
+// mode.
 type listener struct {
 	// Source holds the source of the listener from the Gateway resource.
 	Source v1alpha2.Listener
 	// Valid shows whether the listener is valid.
-	// FIXME(pleshakov) For now, only capture true/false without any error message.
 	Valid bool
-	// Routes holds the routes attached to the listener.
+	// Conditions holds the conditions that explain why the listener is not valid or, for a valid listener, why some
+	// of its configuration is ignored.
+	Conditions []Condition
+	// SecretPath is the path to the file with the TLS certificate and key of an HTTPS listener.
+	// It is empty for HTTP listeners or if the listener is not valid.
+	SecretPath string
+	// Routes holds the HTTPRoutes attached to the HTTP or HTTPS listener.
 	Routes map[types.NamespacedName]*route
+	// GRPCRoutes holds the GRPCRoutes attached to the HTTPS listener.
+	GRPCRoutes map[types.NamespacedName]*route
+	// TLSRoutes holds the TLSRoutes attached to the TLS listener.
+	TLSRoutes map[types.NamespacedName]*tlsRoute
+	// L4Routes holds the TCPRoutes attached to the TCP listener or the UDPRoutes attached to the UDP listener.
+	L4Routes map[types.NamespacedName]*l4Route
 	// AcceptedHostnames is an intersection between the hostnames supported by the listener and the hostnames
 	// from the attached routes.
 	AcceptedHostnames map[string]struct{}
 }
 
-// route represents an HTTPRoute.
+// route represents an HTTPRoute or a GRPCRoute.
+// TLSRoutes are represented by tlsRoute, TCPRoutes and UDPRoutes -- by l4Route.
 type route struct {
-	// Source is the source resource of the route.
-	// FIXME(pleshakov)
-	// For now, we assume that the source is only HTTPRoute. Later we can support more types - TLSRoute, TCPRoute and UDPRoute.
+	// Source is the source resource of the route. For a GRPCRoute, it is the equivalent HTTPRoute.
+	// See convertGRPCRoute.
 	Source *v1alpha2.HTTPRoute
+	// GRPC shows whether the route is a GRPCRoute.
+	GRPC bool
+
+	// ValidSectionNameRefs includes the Gateways and the sectionNames from the parentRefs of the HTTPRoute that are
+	// valid -- i.e. the Gateway resource has a corresponding valid listener.
+	// An empty sectionName represents a parentRef without a sectionName, which references the whole Gateway.
+	ValidSectionNameRefs map[ParentRefKey]struct{}
+	// ValidSectionNameRefs includes the Gateways and the sectionNames from the parentRefs of the HTTPRoute that are
+	// invalid.
+	InvalidSectionNameRefs map[ParentRefKey]struct{}
+	// NotAllowedSectionNameRefs includes the invalid sectionNames whose listeners don't allow the route because of
+	// their allowedRoutes. It is nil if there are no such sectionNames.
+	NotAllowedSectionNameRefs map[ParentRefKey]struct{}
+	// Conditions holds the conditions that explain why the route is not valid.
+	// An invalid route is not bound to any listener.
+	Conditions []Condition
+	// AllowedCrossNamespaceBackends holds the Services in other namespaces that the route references and that
+	// the ReferenceGrants allow the route to reference.
+	AllowedCrossNamespaceBackends map[types.NamespacedName]struct{}
+	// RefConditions holds the conditions that explain why some of the backendRefs of the route cannot be resolved.
+	// Unlike Conditions, they don't prevent the route from being bound to the listeners.
+	RefConditions []Condition
+}
+
+// tlsRoute represents a TLSRoute.
+type tlsRoute struct {
+	// Source is the source resource of the route.
+	Source *v1alpha2.TLSRoute
+	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the TLSRoute that are valid.
+	// See route.ValidSectionNameRefs.
+	ValidSectionNameRefs map[ParentRefKey]struct{}
+	// InvalidSectionNameRefs includes the sectionNames from the parentRefs of the TLSRoute that are invalid.
+	InvalidSectionNameRefs map[ParentRefKey]struct{}
+	// NotAllowedSectionNameRefs includes the invalid sectionNames whose listeners don't allow the route.
+	// See route.NotAllowedSectionNameRefs.
+	NotAllowedSectionNameRefs map[ParentRefKey]struct{}
+	// Conditions holds the conditions that explain why the route is not valid.
+	// An invalid route is not bound to any listener.
+	Conditions []Condition
+	// AllowedCrossNamespaceBackends holds the Services in other namespaces that the route references and that
+	// the ReferenceGrants allow the route to reference.
+	AllowedCrossNamespaceBackends map[types.NamespacedName]struct{}
+	// RefConditions holds the conditions that explain why some of the backendRefs of the route cannot be resolved.
+	// Unlike Conditions, they don't prevent the route from being bound to the listeners.
+	RefConditions []Condition
+}
 
-	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the HTTPRoute that are valid -- i.e.
-	// the Gateway resource has a corresponding valid listener.
-	ValidSectionNameRefs map[string]struct{}
-	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the HTTPRoute that are invalid.
-	InvalidSectionNameRefs map[string]struct{}
+// l4Route represents a TCPRoute or a UDPRoute.
+type l4Route struct {
+	// Source is the source resource of the route, either a TCPRoute or a UDPRoute.
+	Source client.Object
+	// BackendRef is the backend of the connections. It is set only if the route is valid.
+	BackendRef v1alpha2.BackendRef
+	// ValidSectionNameRefs includes the sectionNames from the parentRefs of the route that are valid.
+	// See route.ValidSectionNameRefs.
+	ValidSectionNameRefs map[ParentRefKey]struct{}
+	// InvalidSectionNameRefs includes the sectionNames from the parentRefs of the route that are invalid.
+	InvalidSectionNameRefs map[ParentRefKey]struct{}
+	// NotAllowedSectionNameRefs includes the invalid sectionNames whose listeners don't allow the route.
+	// See route.NotAllowedSectionNameRefs.
+	NotAllowedSectionNameRefs map[ParentRefKey]struct{}
+	// Conditions holds the conditions that explain why the route is not valid.
+	// An invalid route is not bound to any listener.
+	Conditions []Condition
+	// AllowedCrossNamespaceBackends holds the Services in other namespaces that the route references and that
+	// the ReferenceGrants allow the route to reference.
+	AllowedCrossNamespaceBackends map[types.NamespacedName]struct{}
+	// RefConditions holds the conditions that explain why some of the backendRefs of the route cannot be resolved.
+	// Unlike Conditions, they don't prevent the route from being bound to the listeners.
+	RefConditions []Condition
 }
 
 // gatewayClass represents the GatewayClass resource.
@@ -53,58 +142,131 @@ type gatewayClass struct {
 	Valid bool
 	// ErrorMsg explains the error when the resource is not valid.
 	ErrorMsg string
+	// InvalidParameters shows that the GatewayClass is not valid because its parametersRef cannot be resolved.
+	InvalidParameters bool
+	// Config holds the GatewayConfig resource referenced by the parametersRef of the GatewayClass.
+	// It is nil if the GatewayClass doesn't reference any parameters.
+	Config *nginxgwv1alpha1.GatewayConfig
 }
 
 // graph is a graph-like representation of Gateway API resources.
 type graph struct {
 	// GatewayClass holds the GatewayClass resource.
 	GatewayClass *gatewayClass
-	// Gateway holds the winning Gateway resource.
-	Gateway *gateway
-	// IgnoredGateways holds the ignored Gateway resources, which belong to the NGINX Gateway (based on the
-	// GatewayClassName field of the resource) but ignored. It doesn't hold the Gateway resources that do not belong to
-	// the NGINX Gateway.
-	IgnoredGateways map[types.NamespacedName]*v1alpha2.Gateway
+	// Gateways holds the Gateway resources that belong to the NGINX Gateway (based on the GatewayClassName field of
+	// the resource). It doesn't hold the Gateway resources that do not belong to the NGINX Gateway.
+	Gateways map[types.NamespacedName]*gateway
 	// Routes holds route resources.
 	Routes map[types.NamespacedName]*route
+	// GRPCRoutes holds GRPCRoute resources.
+	GRPCRoutes map[types.NamespacedName]*route
+	// TLSRoutes holds TLSRoute resources.
+	TLSRoutes map[types.NamespacedName]*tlsRoute
+	// TCPRoutes holds TCPRoute resources.
+	TCPRoutes map[types.NamespacedName]*l4Route
+	// UDPRoutes holds UDPRoute resources.
+	UDPRoutes map[types.NamespacedName]*l4Route
 }
 
-// buildGraph builds a graph from a store assuming that the Gateway resource has the gwNsName namespace and name.
-func buildGraph(store *store, controllerName string, gcName string) *graph {
-	gc := buildGatewayClass(store.gc, controllerName)
+// buildGraph builds a graph from a store.
+func buildGraph(
+	store *store,
+	controllerName string,
+	gcName string,
+	secretMemoryMgr SecretDiskMemoryManager,
+) *graph {
+	gc := buildGatewayClass(store.gc, controllerName, store.gatewayConfigs)
 
-	gw, ignoredGws := processGateways(store.gateways, gcName)
-
-	listeners := buildListeners(gw, gcName)
+	gateways := buildGateways(store.gateways, gcName, store.secrets, secretMemoryMgr)
 
 	routes := make(map[types.NamespacedName]*route)
 	for _, ghr := range store.httpRoutes {
-		ignored, r := bindHTTPRouteToListeners(ghr, gw, ignoredGws, listeners)
+		ignored, r := bindHTTPRouteToListeners(ghr, gateways, store.namespaces)
 		if !ignored {
+			r.AllowedCrossNamespaceBackends, r.RefConditions = resolveCrossNamespaceBackendRefs(
+				"HTTPRoute", ghr.Namespace, getHTTPRouteBackendRefs(ghr), store.referenceGrants)
 			routes[getNamespacedName(ghr)] = r
 		}
 	}
 
-	g := &graph{
-		GatewayClass:    gc,
-		Routes:          routes,
-		IgnoredGateways: ignoredGws,
+	grpcRoutes := make(map[types.NamespacedName]*route)
+	for _, ggr := range store.grpcRoutes {
+		ignored, r := bindGRPCRouteToListeners(ggr, gateways, store.namespaces)
+		if !ignored {
+			r.AllowedCrossNamespaceBackends, r.RefConditions = resolveCrossNamespaceBackendRefs(
+				"GRPCRoute", ggr.Namespace, getHTTPRouteBackendRefs(r.Source), store.referenceGrants)
+			grpcRoutes[getNamespacedName(ggr)] = r
+		}
 	}
 
-	if gw != nil {
-		g.Gateway = &gateway{
-			Source:    gw,
-			Listeners: listeners,
+	tlsRoutes := make(map[types.NamespacedName]*tlsRoute)
+	for _, gtr := range store.tlsRoutes {
+		ignored, r := bindTLSRouteToListeners(gtr, gateways, store.namespaces)
+		if !ignored {
+			refs := make([]v1alpha2.BackendObjectReference, 0, len(gtr.Spec.Rules))
+			for _, rule := range gtr.Spec.Rules {
+				refs = appendBackendObjectRefs(refs, rule.BackendRefs)
+			}
+
+			r.AllowedCrossNamespaceBackends, r.RefConditions = resolveCrossNamespaceBackendRefs(
+				"TLSRoute", gtr.Namespace, refs, store.referenceGrants)
+			tlsRoutes[getNamespacedName(gtr)] = r
 		}
 	}
 
-	return g
+	tcpRoutes := make(map[types.NamespacedName]*l4Route)
+	for _, gtr := range store.tcpRoutes {
+		ignored, r := bindTCPRouteToListeners(gtr, gateways, store.namespaces)
+		if !ignored {
+			refs := make([]v1alpha2.BackendObjectReference, 0, len(gtr.Spec.Rules))
+			for _, rule := range gtr.Spec.Rules {
+				refs = appendBackendObjectRefs(refs, rule.BackendRefs)
+			}
+
+			r.AllowedCrossNamespaceBackends, r.RefConditions = resolveCrossNamespaceBackendRefs(
+				"TCPRoute", gtr.Namespace, refs, store.referenceGrants)
+			tcpRoutes[getNamespacedName(gtr)] = r
+		}
+	}
+
+	udpRoutes := make(map[types.NamespacedName]*l4Route)
+	for _, gur := range store.udpRoutes {
+		ignored, r := bindUDPRouteToListeners(gur, gateways, store.namespaces)
+		if !ignored {
+			refs := make([]v1alpha2.BackendObjectReference, 0, len(gur.Spec.Rules))
+			for _, rule := range gur.Spec.Rules {
+				refs = appendBackendObjectRefs(refs, rule.BackendRefs)
+			}
+
+			r.AllowedCrossNamespaceBackends, r.RefConditions = resolveCrossNamespaceBackendRefs(
+				"UDPRoute", gur.Namespace, refs, store.referenceGrants)
+			udpRoutes[getNamespacedName(gur)] = r
+		}
+	}
+
+	return &graph{
+		GatewayClass: gc,
+		Gateways:     gateways,
+		Routes:       routes,
+		GRPCRoutes:   grpcRoutes,
+		TLSRoutes:    tlsRoutes,
+		TCPRoutes:    tcpRoutes,
+		UDPRoutes:    udpRoutes,
+	}
 }
 
-// processGateways determines which Gateway resource the NGINX Gateway will use (the winner) and which Gateway(s) will
-// be ignored. Note that the function will not take into the account any unrelated Gateway resources - the ones with the
-// different GatewayClassName field.
-func processGateways(gws map[types.NamespacedName]*v1alpha2.Gateway, gcName string) (winner *v1alpha2.Gateway, ignoredGateways map[types.NamespacedName]*v1alpha2.Gateway) {
+// buildGateways builds the gateways for all Gateway resources that belong to the NGINX Gateway. Note that
+// the function will not take into the account any unrelated Gateway resources - the ones with the different
+// GatewayClassName field.
+// NGINX serves the listeners of all the Gateways, so the listeners of different Gateways can conflict with each other.
+// The Gateways are processed from the oldest to the newest, so that a listener that conflicts with a listener of
+// an older Gateway becomes invalid, while the listener of the older Gateway remains valid.
+func buildGateways(
+	gws map[types.NamespacedName]*v1alpha2.Gateway,
+	gcName string,
+	secrets map[types.NamespacedName]*apiv1.Secret,
+	secretMemoryMgr SecretDiskMemoryManager,
+) map[types.NamespacedName]*gateway {
 	referencedGws := make([]*v1alpha2.Gateway, 0, len(gws))
 
 	for _, gw := range gws {
@@ -115,40 +277,87 @@ func processGateways(gws map[types.NamespacedName]*v1alpha2.Gateway, gcName stri
 		referencedGws = append(referencedGws, gw)
 	}
 
-	if len(referencedGws) == 0 {
-		return nil, nil
-	}
-
 	sort.Slice(referencedGws, func(i, j int) bool {
 		return lessObjectMeta(&referencedGws[i].ObjectMeta, &referencedGws[j].ObjectMeta)
 	})
 
-	ignoredGws := make(map[types.NamespacedName]*v1alpha2.Gateway)
+	gateways := make(map[types.NamespacedName]*gateway, len(referencedGws))
+	olderGateways := make([]*gateway, 0, len(referencedGws))
 
-	for _, gw := range referencedGws[1:] {
-		ignoredGws[getNamespacedName(gw)] = gw
+	for _, gw := range referencedGws {
+		g := &gateway{
+			Source:    gw,
+			Listeners: buildListeners(gw, gcName, olderGateways, secrets, secretMemoryMgr),
+		}
+
+		gateways[getNamespacedName(gw)] = g
+		olderGateways = append(olderGateways, g)
 	}
 
-	return referencedGws[0], ignoredGws
+	return gateways
 }
 
-func buildGatewayClass(gc *v1alpha2.GatewayClass, controllerName string) *gatewayClass {
+func buildGatewayClass(
+	gc *v1alpha2.GatewayClass,
+	controllerName string,
+	gatewayConfigs map[types.NamespacedName]*nginxgwv1alpha1.GatewayConfig,
+) *gatewayClass {
 	if gc == nil {
 		return nil
 	}
 
-	var errorMsg string
-
 	err := validateGatewayClass(gc, controllerName)
 	if err != nil {
-		errorMsg = err.Error()
+		return &gatewayClass{
+			Source:   gc,
+			Valid:    false,
+			ErrorMsg: err.Error(),
+		}
+	}
+
+	config, err := resolveGatewayConfig(gc.Spec.ParametersRef, gatewayConfigs)
+	if err != nil {
+		return &gatewayClass{
+			Source:            gc,
+			Valid:             false,
+			ErrorMsg:          err.Error(),
+			InvalidParameters: true,
+		}
 	}
 
 	return &gatewayClass{
-		Source:   gc,
-		Valid:    err == nil,
-		ErrorMsg: errorMsg,
+		Source: gc,
+		Valid:  true,
+		Config: config,
+	}
+}
+
+// resolveGatewayConfig resolves the parametersRef of the GatewayClass into the GatewayConfig resource.
+// It returns nil if the parametersRef is not set.
+func resolveGatewayConfig(
+	ref *v1alpha2.ParametersReference,
+	gatewayConfigs map[types.NamespacedName]*nginxgwv1alpha1.GatewayConfig,
+) (*nginxgwv1alpha1.GatewayConfig, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	if string(ref.Group) != nginxgw.GroupName || ref.Kind != gatewayConfigKind {
+		return nil, fmt.Errorf("Spec.ParametersRef must reference %s/%s got %s/%s",
+			nginxgw.GroupName, gatewayConfigKind, ref.Group, ref.Kind)
+	}
+
+	// GatewayConfig is a cluster-scoped resource
+	if ref.Namespace != nil {
+		return nil, errors.New("Spec.ParametersRef.Namespace must not be set for the cluster-scoped GatewayConfig")
+	}
+
+	config, exist := gatewayConfigs[types.NamespacedName{Name: ref.Name}]
+	if !exist {
+		return nil, fmt.Errorf("GatewayConfig %s doesn't exist", ref.Name)
 	}
+
+	return config, nil
 }
 
 // bindHTTPRouteToListeners tries to bind an HTTPRoute to listener.
@@ -158,9 +367,8 @@ func buildGatewayClass(gc *v1alpha2.GatewayClass, controllerName string) *gatewa
 // (3) HTTPRoute will be processed and bound to a listener.
 func bindHTTPRouteToListeners(
 	ghr *v1alpha2.HTTPRoute,
-	gw *v1alpha2.Gateway,
-	ignoredGws map[types.NamespacedName]*v1alpha2.Gateway,
-	listeners map[string]*listener,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
 ) (ignored bool, r *route) {
 	if len(ghr.Spec.ParentRefs) == 0 {
 		// ignore HTTPRoute without refs
@@ -168,153 +376,726 @@ func bindHTTPRouteToListeners(
 	}
 
 	r = &route{
-		Source:                 ghr,
-		ValidSectionNameRefs:   make(map[string]struct{}),
-		InvalidSectionNameRefs: make(map[string]struct{}),
+		Source: ghr,
 	}
 
-	// FIXME (pleshakov) Handle the case when parent refs are duplicated
+	var valid bool
+	if err := validateHTTPRoute(ghr); err != nil {
+		r.Conditions = []Condition{newRouteUnsupportedValueCondition(err.Error())}
+	} else {
+		valid = true
+	}
 
-	processed := false
+	// HTTPRoutes can only attach to HTTP and HTTPS listeners
+	bind := func(l *listener) bool {
+		if l.Source.Protocol != v1beta1.HTTPProtocolType && l.Source.Protocol != v1beta1.HTTPSProtocolType {
+			return false
+		}
+		return bindRouteToListener(r, l)
+	}
 
-	for _, p := range ghr.Spec.ParentRefs {
-		// FIXME(pleshakov) Support empty section name
-		if p.SectionName == nil || *p.SectionName == "" {
-			continue
+	refs := bindParentRefs("HTTPRoute", ghr.Namespace, ghr.Spec.ParentRefs, valid, gateways, namespaces, bind)
+	if !refs.processed {
+		return true, nil
+	}
+
+	r.ValidSectionNameRefs = refs.valid
+	r.InvalidSectionNameRefs = refs.invalid
+	r.NotAllowedSectionNameRefs = refs.notAllowed
+
+	return false, r
+}
+
+// bindGRPCRouteToListeners tries to bind a GRPCRoute to the HTTPS listeners, the same way bindHTTPRouteToListeners
+// binds an HTTPRoute. The GRPCRoute is represented by the equivalent HTTPRoute.
+func bindGRPCRouteToListeners(
+	ggr *v1alpha2.GRPCRoute,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) (ignored bool, r *route) {
+	if len(ggr.Spec.ParentRefs) == 0 {
+		// ignore GRPCRoute without refs
+		return true, nil
+	}
+
+	r = &route{
+		Source: convertGRPCRoute(ggr),
+		GRPC:   true,
+	}
+
+	var valid bool
+	if err := validateGRPCRoute(ggr); err != nil {
+		r.Conditions = []Condition{newRouteUnsupportedValueCondition(err.Error())}
+	} else {
+		valid = true
+	}
+
+	// gRPC requires HTTP/2, so GRPCRoutes can only attach to HTTPS listeners. See protocolRouteKinds.
+	bind := func(l *listener) bool {
+		if l.Source.Protocol != v1beta1.HTTPSProtocolType {
+			return false
 		}
+		return bindRouteToListener(r, l)
+	}
+
+	refs := bindParentRefs("GRPCRoute", ggr.Namespace, ggr.Spec.ParentRefs, valid, gateways, namespaces, bind)
+	if !refs.processed {
+		return true, nil
+	}
+
+	r.ValidSectionNameRefs = refs.valid
+	r.InvalidSectionNameRefs = refs.invalid
+	r.NotAllowedSectionNameRefs = refs.notAllowed
+
+	return false, r
+}
+
+// bindTLSRouteToListeners tries to bind a TLSRoute to the TLS listeners, the same way bindHTTPRouteToListeners binds
+// an HTTPRoute.
+func bindTLSRouteToListeners(
+	gtr *v1alpha2.TLSRoute,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) (ignored bool, r *tlsRoute) {
+	if len(gtr.Spec.ParentRefs) == 0 {
+		// ignore TLSRoute without refs
+		return true, nil
+	}
+
+	r = &tlsRoute{
+		Source: gtr,
+	}
+
+	var valid bool
+	if err := validateTLSRoute(gtr); err != nil {
+		r.Conditions = []Condition{newRouteUnsupportedValueCondition(err.Error())}
+	} else {
+		valid = true
+	}
+
+	// TLSRoutes can only attach to TLS listeners
+	bind := func(l *listener) bool {
+		if l.Source.Protocol != v1beta1.TLSProtocolType {
+			return false
+		}
+		return bindTLSRouteToListener(r, l)
+	}
+
+	refs := bindParentRefs("TLSRoute", gtr.Namespace, gtr.Spec.ParentRefs, valid, gateways, namespaces, bind)
+	if !refs.processed {
+		return true, nil
+	}
+
+	r.ValidSectionNameRefs = refs.valid
+	r.InvalidSectionNameRefs = refs.invalid
+	r.NotAllowedSectionNameRefs = refs.notAllowed
+
+	return false, r
+}
+
+// bindTCPRouteToListeners tries to bind a TCPRoute to the TCP listeners, the same way bindHTTPRouteToListeners binds
+// an HTTPRoute.
+func bindTCPRouteToListeners(
+	gtr *v1alpha2.TCPRoute,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) (ignored bool, r *l4Route) {
+	backendRefs := make([][]v1alpha2.BackendRef, 0, len(gtr.Spec.Rules))
+	for _, rule := range gtr.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs)
+	}
+
+	return bindL4RouteToListeners(
+		gtr,
+		gtr.Spec.ParentRefs,
+		backendRefs,
+		v1beta1.TCPProtocolType,
+		gateways,
+		namespaces,
+	)
+}
+
+// bindUDPRouteToListeners tries to bind a UDPRoute to the UDP listeners, the same way bindHTTPRouteToListeners binds
+// an HTTPRoute.
+func bindUDPRouteToListeners(
+	gur *v1alpha2.UDPRoute,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) (ignored bool, r *l4Route) {
+	backendRefs := make([][]v1alpha2.BackendRef, 0, len(gur.Spec.Rules))
+	for _, rule := range gur.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs)
+	}
+
+	return bindL4RouteToListeners(
+		gur,
+		gur.Spec.ParentRefs,
+		backendRefs,
+		v1beta1.UDPProtocolType,
+		gateways,
+		namespaces,
+	)
+}
+
+// bindL4RouteToListeners binds a TCPRoute or a UDPRoute to the listeners with the protocol. The backendRefs hold
+// the backendRefs of each rule of the route.
+func bindL4RouteToListeners(
+	source client.Object,
+	parentRefs []v1alpha2.ParentReference,
+	backendRefs [][]v1alpha2.BackendRef,
+	protocol v1alpha2.ProtocolType,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+) (ignored bool, r *l4Route) {
+	if len(parentRefs) == 0 {
+		// ignore routes without refs
+		return true, nil
+	}
+
+	r = &l4Route{
+		Source: source,
+	}
+
+	var valid bool
+	if err := validateSingleBackendRules(backendRefs); err != nil {
+		r.Conditions = []Condition{newRouteUnsupportedValueCondition(err.Error())}
+	} else {
+		r.BackendRef = backendRefs[0][0]
+		valid = true
+	}
 
-		// if the namespace is missing, assume the namespace of the HTTPRoute
-		ns := ghr.Namespace
+	// TCPRoutes and UDPRoutes don't have hostnames, so they attach to every listener with the matching protocol
+	bind := func(l *listener) bool {
+		if l.Source.Protocol != protocol {
+			return false
+		}
+
+		l.L4Routes[getNamespacedName(source)] = r
+		return true
+	}
+
+	// the TCP and UDP listeners support a single route kind
+	refs := bindParentRefs(protocolRouteKinds[protocol][0], source.GetNamespace(), parentRefs, valid, gateways,
+		namespaces, bind)
+	if !refs.processed {
+		return true, nil
+	}
+
+	r.ValidSectionNameRefs = refs.valid
+	r.InvalidSectionNameRefs = refs.invalid
+	r.NotAllowedSectionNameRefs = refs.notAllowed
+
+	return false, r
+}
+
+// parentRefsBinding is the result of binding the parentRefs of a route to the listeners.
+type parentRefsBinding struct {
+	// processed is false if none of the parentRefs reference a Gateway of the NGINX Gateway, so that the route must be
+	// ignored.
+	processed bool
+	// valid includes the Gateways and the sectionNames of the parentRefs that the route is bound to.
+	valid map[ParentRefKey]struct{}
+	// invalid includes the Gateways and the sectionNames of the parentRefs that the route is not bound to.
+	invalid map[ParentRefKey]struct{}
+	// notAllowed includes the invalid parentRefs whose listeners don't allow the route. It is nil if there are no
+	// such parentRefs.
+	notAllowed map[ParentRefKey]struct{}
+}
+
+// bindParentRefs binds a route of the kind to the listeners referenced by its parentRefs using the bind function,
+// which returns true if it bound the route to the listener. An invalid route is not bound to any listener.
+// The route is only bound to the listeners whose allowedRoutes allow it.
+func bindParentRefs(
+	routeKind v1alpha2.Kind,
+	routeNamespace string,
+	parentRefs []v1alpha2.ParentReference,
+	valid bool,
+	gateways map[types.NamespacedName]*gateway,
+	namespaces map[types.NamespacedName]*apiv1.Namespace,
+	bind func(l *listener) bool,
+) parentRefsBinding {
+	result := parentRefsBinding{
+		valid:   make(map[ParentRefKey]struct{}),
+		invalid: make(map[ParentRefKey]struct{}),
+	}
+
+	// A parentRef can reference the same section name of a Gateway more than once. To keep the result deterministic,
+	// a valid reference always takes precedence over an invalid one, regardless of the order of the parentRefs.
+	markValid := func(key ParentRefKey) {
+		result.valid[key] = struct{}{}
+		delete(result.invalid, key)
+		delete(result.notAllowed, key)
+	}
+	markInvalid := func(key ParentRefKey) {
+		if _, valid := result.valid[key]; !valid {
+			result.invalid[key] = struct{}{}
+		}
+	}
+	markNotAllowed := func(key ParentRefKey) {
+		if _, valid := result.valid[key]; valid {
+			return
+		}
+
+		result.invalid[key] = struct{}{}
+
+		if result.notAllowed == nil {
+			result.notAllowed = make(map[ParentRefKey]struct{})
+		}
+		result.notAllowed[key] = struct{}{}
+	}
+
+	for _, p := range parentRefs {
+		// if the namespace is missing, assume the namespace of the route
+		ns := routeNamespace
 		if p.Namespace != nil {
 			ns = string(*p.Namespace)
 		}
 
-		name := string(*p.SectionName)
+		gwNsName := types.NamespacedName{Namespace: ns, Name: string(p.Name)}
 
-		// Below we will figure out what Gateway resource the parentRef references and act accordingly. There are 3 cases.
+		gw, exist := gateways[gwNsName]
+		if !exist {
+			// the parentRef references some unrelated to this NGINX Gateway Gateway or other resource.
+			continue
+		}
 
-		// Case 1: the parentRef references the winning Gateway.
+		result.processed = true
 
-		if gw != nil && gw.Namespace == ns && gw.Name == string(p.Name) {
+		// An empty section name means the parentRef references the whole Gateway rather than a particular listener.
+		key := ParentRefKey{Gateway: gwNsName}
+		if p.SectionName != nil {
+			key.SectionName = string(*p.SectionName)
+		}
 
-			// Find a listener
+		// Note: when a Route host matches multiple listeners on the same port, only the most specific listener
+		// will serve the requests for that host. For example:
+		// - Route with host foo.example.com;
+		// - listener 1 for port 80 with hostname foo.example.com
+		// - listener 2 for port 80 with hostname *.example.com;
+		// In this case, the Route host foo.example.com is served by listener 1, as it is a more specific match.
+		// The listeners can belong to different Gateways. See buildServers in configuration.go.
 
-			// FIXME(pleshakov)
-			// For now, let's do simple matching.
-			// However, we need to also support wildcard matching.
-			// More over, we need to handle cases when a Route host matches multiple HTTP listeners on the same port when
-			// sectionName is empty and only choose one listener.
-			// For example:
-			// - Route with host foo.example.com;
-			// - listener 1 for port 80 with hostname foo.example.com
-			// - listener 2 for port 80 with hostname *.example.com;
-			// In this case, the Route host foo.example.com should choose listener 1, as it is a more specific match.
+		if !valid {
+			markInvalid(key)
+			continue
+		}
 
-			processed = true
+		allowed := func(l *listener) bool {
+			return isRouteAllowedByListener(l, routeKind, routeNamespace, gw.Source.Namespace, namespaces)
+		}
 
-			l, exists := listeners[name]
-			if !exists {
-				r.InvalidSectionNameRefs[name] = struct{}{}
-				continue
-			}
+		if key.SectionName == "" {
+			// the route attaches to every valid listener of the Gateway that allows it and whose hostname intersects
+			// with the route hostnames
+			attached := false
+			anyValid := false
+			allowedByAny := false
+
+			for _, l := range gw.Listeners {
+				if !l.Valid {
+					continue
+				}
+
+				anyValid = true
 
-			accepted := findAcceptedHostnames(l.Source.Hostname, ghr.Spec.Hostnames)
+				if !allowed(l) {
+					continue
+				}
+
+				allowedByAny = true
 
-			if len(accepted) > 0 {
-				for _, h := range accepted {
-					l.AcceptedHostnames[h] = struct{}{}
+				if bind(l) {
+					attached = true
 				}
-				r.ValidSectionNameRefs[name] = struct{}{}
-				l.Routes[getNamespacedName(ghr)] = r
-			} else {
-				r.InvalidSectionNameRefs[name] = struct{}{}
+			}
+
+			switch {
+			case attached:
+				markValid(key)
+			case anyValid && !allowedByAny:
+				markNotAllowed(key)
+			default:
+				markInvalid(key)
 			}
 
 			continue
 		}
 
-		// Case 2: the parentRef references an ignored Gateway resource.
-
-		key := types.NamespacedName{Namespace: ns, Name: string(p.Name)}
-
-		if _, exist := ignoredGws[key]; exist {
-			r.InvalidSectionNameRefs[name] = struct{}{}
+		// like a parentRef without a section name, a parentRef can't attach the route to an invalid listener
+		l, exists := gw.Listeners[key.SectionName]
+		if !exists || !l.Valid {
+			markInvalid(key)
+			continue
+		}
 
-			processed = true
+		if !allowed(l) {
+			markNotAllowed(key)
 			continue
 		}
 
-		// Case 3: the parentRef references some unrelated to this NGINX Gateway Gateway or other resource.
+		if bind(l) {
+			markValid(key)
+		} else {
+			markInvalid(key)
+		}
+	}
 
-		// Do nothing
+	return result
+}
+
+// bindRouteToListener binds the route to the listener if the hostnames of the route intersect with the hostname of
+// the listener. It returns true if the route was bound.
+func bindRouteToListener(r *route, l *listener) bool {
+	accepted := findAcceptedHostnames(l.Source.Hostname, r.Source.Spec.Hostnames)
+	if len(accepted) == 0 {
+		return false
 	}
 
-	if !processed {
-		return true, nil
+	for _, h := range accepted {
+		l.AcceptedHostnames[h] = struct{}{}
 	}
 
-	return false, r
+	if r.GRPC {
+		l.GRPCRoutes[getNamespacedName(r.Source)] = r
+	} else {
+		l.Routes[getNamespacedName(r.Source)] = r
+	}
+
+	return true
 }
 
-func findAcceptedHostnames(listenerHostname *v1alpha2.Hostname, routeHostnames []v1alpha2.Hostname) []string {
-	hostname := getHostname(listenerHostname)
+// bindTLSRouteToListener binds the TLSRoute to the listener if the hostnames of the route intersect with the hostname
+// of the listener. It returns true if the route was bound.
+func bindTLSRouteToListener(r *tlsRoute, l *listener) bool {
+	accepted := findAcceptedTLSHostnames(l.Source.Hostname, r.Source.Spec.Hostnames)
+	if len(accepted) == 0 {
+		return false
+	}
 
-	match := func(h v1alpha2.Hostname) bool {
-		if hostname == "" {
-			return true
-		}
-		return string(h) == hostname
+	for _, h := range accepted {
+		l.AcceptedHostnames[h] = struct{}{}
 	}
+	l.TLSRoutes[getNamespacedName(r.Source)] = r
+
+	return true
+}
+
+// findAcceptedTLSHostnames returns the hostnames of the TLSRoute accepted by the listener, like findAcceptedHostnames.
+// As the Gateway API defines for the hostnames of all route types, a route without hostnames matches every hostname of
+// the listener, so the hostname of the listener is accepted. The empty hostname means any hostname.
+// FIXME: findAcceptedHostnames doesn't accept the HTTPRoutes without hostnames yet, because an HTTP server
+// requires a hostname, unlike the TLS passthrough servers.
+func findAcceptedTLSHostnames(listenerHostname *v1alpha2.Hostname, routeHostnames []v1alpha2.Hostname) []string {
+	if len(routeHostnames) == 0 {
+		return []string{getHostname(listenerHostname)}
+	}
+
+	return findAcceptedHostnames(listenerHostname, routeHostnames)
+}
+
+// findAcceptedHostnames returns the intersection between the hostname of the listener and the hostnames of the route,
+// following the wildcard semantics of the Gateway API. If a route hostname intersects with the listener hostname,
+// the more specific of the two is accepted. For example:
+// - listener "*.example.com" and route "foo.example.com" -> "foo.example.com"
+// - listener "foo.example.com" and route "*.example.com" -> "foo.example.com"
+// - listener "*.example.com" and route "*.foo.example.com" -> "*.foo.example.com"
+func findAcceptedHostnames(listenerHostname *v1alpha2.Hostname, routeHostnames []v1alpha2.Hostname) []string {
+	hostname := getHostname(listenerHostname)
 
 	var result []string
 
 	for _, h := range routeHostnames {
-		if match(h) {
-			result = append(result, string(h))
+		if accepted, ok := intersectHostnames(hostname, string(h)); ok {
+			result = append(result, accepted)
 		}
 	}
 
 	return result
 }
 
-func buildListeners(gw *v1alpha2.Gateway, gcName string) map[string]*listener {
-	// FIXME(pleshakov): For now we require that all HTTP listeners bind to port 80
+// intersectHostnames returns the intersection of the listener and route hostnames, if there is one.
+// The empty listener hostname matches any route hostname.
+func intersectHostnames(listenerHostname, routeHostname string) (string, bool) {
+	switch {
+	case listenerHostname == "":
+		return routeHostname, true
+	case matchHostname(listenerHostname, routeHostname):
+		return routeHostname, true
+	case matchHostname(routeHostname, listenerHostname):
+		return listenerHostname, true
+	default:
+		return "", false
+	}
+}
+
+// matchHostname returns true if the hostname is covered by the pattern. A pattern with a wildcard label ("*.") is
+// a suffix match: "*.example.com" covers "foo.example.com", "foo.bar.example.com" and "*.foo.example.com", but not
+// "example.com". Any other pattern must be equal to the hostname.
+// The hostname itself can be a wildcard hostname.
+func matchHostname(pattern, hostname string) bool {
+	if !isWildcardHostname(pattern) {
+		return pattern == hostname
+	}
+
+	return strings.HasSuffix(hostname, pattern[1:])
+}
+
+func isWildcardHostname(h string) bool {
+	return strings.HasPrefix(h, "*.")
+}
+
+// isMoreSpecificHostname returns true if the listener hostname a is more specific than the listener hostname b.
+// An exact hostname is more specific than a wildcard hostname; a wildcard hostname with a longer suffix is more
+// specific than a wildcard hostname with a shorter one; any hostname is more specific than the empty hostname.
+func isMoreSpecificHostname(a, b string) bool {
+	switch {
+	case a == b:
+		return false
+	case b == "":
+		return true
+	case a == "":
+		return false
+	case isWildcardHostname(a) != isWildcardHostname(b):
+		return !isWildcardHostname(a)
+	default:
+		return len(a) > len(b)
+	}
+}
+
+// buildListeners builds the listeners of the Gateway. The olderGateways hold the Gateways that were built before and
+// whose valid listeners take precedence over the conflicting listeners of the Gateway.
+func buildListeners(
+	gw *v1alpha2.Gateway,
+	gcName string,
+	olderGateways []*gateway,
+	secrets map[types.NamespacedName]*apiv1.Secret,
+	secretMemoryMgr SecretDiskMemoryManager,
+) map[string]*listener {
 	listeners := make(map[string]*listener)
 
 	if gw == nil || string(gw.Spec.GatewayClassName) != gcName {
 		return listeners
 	}
 
-	usedListenerHostnames := make(map[string]*listener)
+	processed := make([]*listener, 0, len(gw.Spec.Listeners))
 
 	for _, gl := range gw.Spec.Listeners {
-		valid := validateListener(gl)
-
-		h := getHostname(gl.Hostname)
-
-		// FIXME(pleshakov) This check will need to be done per each port once we support multiple ports.
-		if holder, exist := usedListenerHostnames[h]; exist {
-			valid = false
-			holder.Valid = false // all listeners for the same hostname become conflicted
-		}
+		valid, conds := validateListener(gl)
 
 		l := &listener{
 			Source:            gl,
 			Valid:             valid,
+			Conditions:        conds,
 			Routes:            make(map[types.NamespacedName]*route),
+			GRPCRoutes:        make(map[types.NamespacedName]*route),
+			TLSRoutes:         make(map[types.NamespacedName]*tlsRoute),
+			L4Routes:          make(map[types.NamespacedName]*l4Route),
 			AcceptedHostnames: make(map[string]struct{}),
 		}
 
+		for _, other := range processed {
+			// all conflicting listeners of the Gateway become invalid
+			if cond, conflict := findListenerConflict(gl, other.Source); conflict {
+				invalidateListener(l, cond)
+				invalidateListener(other, cond)
+			}
+		}
+
 		listeners[string(gl.Name)] = l
-		usedListenerHostnames[h] = l
+		processed = append(processed, l)
+	}
+
+	for _, og := range olderGateways {
+		for _, other := range og.Listeners {
+			if !other.Valid {
+				continue
+			}
+
+			for _, l := range listeners {
+				// only the listener of the newer Gateway becomes invalid
+				if cond, conflict := findListenerConflict(l.Source, other.Source); conflict {
+					cond.Message = fmt.Sprintf("%s; the conflicting listener %s belongs to the Gateway %s",
+						cond.Message, other.Source.Name, getNamespacedName(og.Source))
+					invalidateListener(l, cond)
+				}
+			}
+		}
+	}
+
+	// Secrets are resolved only for the listeners that remained valid after the conflicts were found,
+	// so that we don't request the Secrets that will not be used.
+	for _, l := range listeners {
+		if l.Valid && l.Source.Protocol == v1beta1.HTTPSProtocolType {
+			resolveListenerSecret(l, gw.Namespace, secrets, secretMemoryMgr)
+		}
 	}
 
 	return listeners
 }
 
-func validateListener(listener v1alpha2.Listener) bool {
-	// FIXME(pleshakov) For now, only support HTTP on port 80.
-	return listener.Protocol == v1alpha2.HTTPProtocolType && listener.Port == 80
+// findListenerConflict returns the condition that explains why the two listeners cannot be used together, if they
+// conflict with each other.
+func findListenerConflict(a, b v1alpha2.Listener) (cond Condition, conflict bool) {
+	// a UDP listener can use the same port as a listener of a TCP-based protocol
+	if a.Port != b.Port || isUDPListener(a) != isUDPListener(b) {
+		return Condition{}, false
+	}
+
+	switch {
+	case a.Protocol != b.Protocol:
+		// the listeners for the same port with different protocols conflict
+		return newListenerProtocolConflictCondition(), true
+	case isL4Listener(a):
+		// the TCP and UDP listeners don't have hostnames, so they cannot share the port at all
+		msg := fmt.Sprintf("Multiple %s listeners use port %d", a.Protocol, a.Port)
+		return newListenerPortUnavailableCondition(msg), true
+	case getHostname(a.Hostname) == getHostname(b.Hostname):
+		// the listeners for the same port with the same hostname conflict
+		return newListenerHostnameConflictCondition(), true
+	default:
+		return Condition{}, false
+	}
+}
+
+// isL4Listener returns true if the listener is a TCP or a UDP listener.
+func isL4Listener(l v1alpha2.Listener) bool {
+	return l.Protocol == v1beta1.TCPProtocolType || l.Protocol == v1beta1.UDPProtocolType
+}
+
+func isUDPListener(l v1alpha2.Listener) bool {
+	return l.Protocol == v1beta1.UDPProtocolType
+}
+
+// invalidateListener makes a valid listener invalid, adding the condition that explains why.
+// If the listener is already invalid, invalidateListener doesn't change it.
+func invalidateListener(l *listener, cond Condition) {
+	if !l.Valid {
+		return
+	}
+
+	l.Valid = false
+	l.Conditions = append(l.Conditions, cond)
+}
+
+// resolveListenerSecret resolves the Secret referenced by the HTTPS listener, requesting it to be written to disk.
+// If the Secret cannot be resolved, the listener becomes invalid.
+// The TLS configuration of the listener must be validated by validateListenerTLS beforehand.
+func resolveListenerSecret(
+	l *listener,
+	gwNamespace string,
+	secrets map[types.NamespacedName]*apiv1.Secret,
+	secretMemoryMgr SecretDiskMemoryManager,
+) {
+	ref := l.Source.TLS.CertificateRefs[0]
+
+	// FIXME(pleshakov): Support Secrets in other namespaces once ReferenceGrant is supported.
+	if ref.Namespace != nil && string(*ref.Namespace) != gwNamespace {
+		invalidateListener(l, newListenerRefNotPermittedCondition(
+			fmt.Sprintf("Secret %s/%s is in a different namespace than the Gateway", *ref.Namespace, ref.Name),
+		))
+		return
+	}
+
+	nsname := types.NamespacedName{Namespace: gwNamespace, Name: string(ref.Name)}
+
+	secret, exist := secrets[nsname]
+	if !exist {
+		invalidateListener(l, newListenerInvalidCertificateRefCondition(
+			fmt.Sprintf("Secret %s does not exist", nsname),
+		))
+		return
+	}
+
+	path, err := secretMemoryMgr.Request(secret)
+	if err != nil {
+		invalidateListener(l, newListenerInvalidCertificateRefCondition(
+			fmt.Sprintf("Failed to use Secret %s: %v", nsname, err),
+		))
+		return
+	}
+
+	l.SecretPath = path
+}
+
+// validateListener validates the listener. If the listener is invalid, validateListener returns the conditions that
+// explain why. A valid listener can also have conditions, for example, if some of its allowed route kinds are not
+// supported.
+func validateListener(listener v1alpha2.Listener) (valid bool, conds []Condition) {
+	switch listener.Protocol {
+	case v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType, v1beta1.TLSProtocolType, v1beta1.TCPProtocolType,
+		v1beta1.UDPProtocolType:
+	default:
+		msg := fmt.Sprintf("Protocol %q is not supported, use %q, %q, %q, %q or %q",
+			listener.Protocol, v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType, v1beta1.TLSProtocolType,
+			v1beta1.TCPProtocolType, v1beta1.UDPProtocolType)
+		return false, []Condition{newListenerUnsupportedProtocolCondition(msg)}
+	}
+
+	// The port is also validated by the CRD schema, but we don't rely on it here.
+	if listener.Port < 1 || listener.Port > 65535 {
+		msg := fmt.Sprintf("Port %d is invalid, use a port between 1 and 65535", listener.Port)
+		return false, []Condition{newListenerPortUnavailableCondition(msg)}
+	}
+
+	if listener.Protocol == v1beta1.HTTPSProtocolType {
+		if err := validateListenerTLS(listener.TLS); err != nil {
+			return false, []Condition{newListenerInvalidCertificateRefCondition(err.Error())}
+		}
+	}
+
+	if listener.Protocol == v1beta1.TLSProtocolType {
+		if err := validateListenerTLSPassthrough(listener.TLS); err != nil {
+			return false, []Condition{newListenerUnsupportedProtocolCondition(err.Error())}
+		}
+	}
+
+	return validateAllowedRoutes(listener)
+}
+
+// validateListenerTLSPassthrough validates the TLS configuration of a TLS listener. NGINX passes the TLS connections
+// through to the backends without terminating TLS, so only the Passthrough mode is supported.
+func validateListenerTLSPassthrough(tls *v1alpha2.GatewayTLSConfig) error {
+	if tls == nil {
+		return errors.New("TLS configuration is required for TLS listeners")
+	}
+
+	// the mode defaults to Terminate
+	if tls.Mode == nil || *tls.Mode != v1beta1.TLSModePassthrough {
+		mode := v1beta1.TLSModeTerminate
+		if tls.Mode != nil {
+			mode = *tls.Mode
+		}
+
+		return fmt.Errorf("TLS mode %q is not supported for TLS listeners, use %q", mode, v1beta1.TLSModePassthrough)
+	}
+
+	return nil
+}
+
+func validateListenerTLS(tls *v1alpha2.GatewayTLSConfig) error {
+	if tls == nil {
+		return errors.New("TLS configuration is required for HTTPS listeners")
+	}
+
+	if tls.Mode != nil && *tls.Mode != v1beta1.TLSModeTerminate {
+		return fmt.Errorf("TLS mode %q is not supported, use %q", *tls.Mode, v1beta1.TLSModeTerminate)
+	}
+
+	if len(tls.CertificateRefs) != 1 {
+		return errors.New("exactly one certificateRef is required")
+	}
+
+	ref := tls.CertificateRefs[0]
+
+	if ref.Kind != nil && *ref.Kind != "Secret" {
+		return fmt.Errorf("certificateRef kind must be Secret, got %q", *ref.Kind)
+	}
+
+	if ref.Group != nil && *ref.Group != "" && *ref.Group != "core" {
+		return fmt.Errorf("certificateRef group must be empty or core, got %q", *ref.Group)
+	}
+
+	return nil
 }
 
 func getHostname(h *v1alpha2.Hostname) string {
@@ -324,6 +1105,469 @@ func getHostname(h *v1alpha2.Hostname) string {
 	return string(*h)
 }
 
+// validateHTTPRoute validates the fields of the HTTPRoute that the Gateway API schema doesn't validate, but NGINX
+// requires to be valid. It also rejects the match types that are not supported, so that a match is never silently
+// ignored.
+func validateHTTPRoute(hr *v1alpha2.HTTPRoute) error {
+	var msgs []string
+
+	for i, rule := range hr.Spec.Rules {
+		for j, m := range rule.Matches {
+			prefix := fmt.Sprintf("spec.rules[%d].matches[%d]", i, j)
+
+			msgs = append(msgs, validatePathMatch(m.Path, prefix+".path")...)
+
+			for k, h := range m.Headers {
+				msgs = append(msgs, validateHeaderMatch(h, fmt.Sprintf("%s.headers[%d]", prefix, k))...)
+			}
+
+			for k, q := range m.QueryParams {
+				msgs = append(msgs, validateQueryParamMatch(q, fmt.Sprintf("%s.queryParams[%d]", prefix, k))...)
+			}
+		}
+
+		for j, f := range rule.Filters {
+			msgs = append(msgs, validateFilter(f, fmt.Sprintf("spec.rules[%d].filters[%d]", i, j))...)
+		}
+
+		// only the ResponseHeaderModifier filter of the backendRefs is supported, the other filters are ignored
+		for j, ref := range rule.BackendRefs {
+			for k, f := range ref.Filters {
+				if f.Type == v1beta1.HTTPRouteFilterResponseHeaderModifier {
+					field := fmt.Sprintf("spec.rules[%d].backendRefs[%d].filters[%d]", i, j, k)
+					msgs = append(msgs, validateFilter(f, field)...)
+				}
+			}
+		}
+
+		msgs = append(msgs, validateRuleURLRewrite(rule, fmt.Sprintf("spec.rules[%d]", i))...)
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+// validateTLSRoute validates the fields of the TLSRoute that NGINX requires to be valid. NGINX passes the connections
+// for a hostname to a single backend, so a TLSRoute must have exactly one rule with exactly one backendRef.
+func validateTLSRoute(tr *v1alpha2.TLSRoute) error {
+	backendRefs := make([][]v1alpha2.BackendRef, 0, len(tr.Spec.Rules))
+	for _, rule := range tr.Spec.Rules {
+		backendRefs = append(backendRefs, rule.BackendRefs)
+	}
+
+	return validateSingleBackendRules(backendRefs)
+}
+
+// validateSingleBackendRules validates that a route that NGINX proxies to a single backend has exactly one rule with
+// exactly one backendRef. The backendRefs hold the backendRefs of each rule of the route.
+func validateSingleBackendRules(backendRefs [][]v1alpha2.BackendRef) error {
+	if len(backendRefs) != 1 {
+		return fmt.Errorf("spec.rules: exactly one rule is supported, got %d", len(backendRefs))
+	}
+
+	if refs := backendRefs[0]; len(refs) != 1 {
+		return fmt.Errorf("spec.rules[0].backendRefs: exactly one backendRef is supported, got %d", len(refs))
+	}
+
+	return nil
+}
+
+func validatePathMatch(path *v1alpha2.HTTPPathMatch, field string) []string {
+	if path == nil || path.Type == nil {
+		return nil
+	}
+
+	switch *path.Type {
+	case v1beta1.PathMatchExact, v1beta1.PathMatchPathPrefix:
+		return nil
+	case v1beta1.PathMatchRegularExpression:
+		if err := validatePathRegex(getPath(path)); err != nil {
+			return []string{fmt.Sprintf("%s.value: %v", field, err)}
+		}
+		return nil
+	default:
+		return []string{fmt.Sprintf("%s.type: unsupported type %q", field, *path.Type)}
+	}
+}
+
+func validateHeaderMatch(header v1alpha2.HTTPHeaderMatch, field string) []string {
+	if header.Type == nil {
+		return nil
+	}
+
+	switch *header.Type {
+	case v1beta1.HeaderMatchExact:
+		return nil
+	case v1beta1.HeaderMatchRegularExpression:
+		if err := validateMatchRegex(header.Value); err != nil {
+			return []string{fmt.Sprintf("%s.value: %v", field, err)}
+		}
+		return nil
+	default:
+		return []string{fmt.Sprintf("%s.type: unsupported type %q", field, *header.Type)}
+	}
+}
+
+func validateQueryParamMatch(param v1alpha2.HTTPQueryParamMatch, field string) []string {
+	if param.Type == nil {
+		return nil
+	}
+
+	switch *param.Type {
+	case v1beta1.QueryParamMatchExact:
+		return nil
+	case v1beta1.QueryParamMatchRegularExpression:
+		if err := validateMatchRegex(param.Value); err != nil {
+			return []string{fmt.Sprintf("%s.value: %v", field, err)}
+		}
+		return nil
+	default:
+		return []string{fmt.Sprintf("%s.type: unsupported type %q", field, *param.Type)}
+	}
+}
+
+// validateFilter validates the filters that NGINX supports. The other filters are ignored.
+func validateFilter(filter v1alpha2.HTTPRouteFilter, field string) []string {
+	switch filter.Type {
+	case v1beta1.HTTPRouteFilterRequestHeaderModifier:
+		return validateRequestHeaderModifier(filter.RequestHeaderModifier, field+".requestHeaderModifier")
+	case v1beta1.HTTPRouteFilterResponseHeaderModifier:
+		return validateResponseHeaderModifier(filter.ResponseHeaderModifier, field+".responseHeaderModifier")
+	case v1beta1.HTTPRouteFilterRequestRedirect:
+		return validateRequestRedirect(filter.RequestRedirect, field+".requestRedirect")
+	case v1beta1.HTTPRouteFilterURLRewrite:
+		return validateURLRewrite(filter.URLRewrite, field+".urlRewrite")
+	case v1beta1.HTTPRouteFilterRequestMirror:
+		// the backend of the mirror is resolved when the configuration is generated, like the other backends
+		if filter.RequestMirror == nil {
+			return []string{fmt.Sprintf("%s.requestMirror: must be specified for the RequestMirror filter", field)}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// validateRequestRedirect validates the fields of the RequestRedirect filter. The fields are also validated by the CRD
+// schema, but we don't rely on it here, because NGINX includes them in the redirect URL.
+func validateRequestRedirect(redirect *v1alpha2.HTTPRequestRedirectFilter, field string) []string {
+	if redirect == nil {
+		return []string{fmt.Sprintf("%s: must be specified for the RequestRedirect filter", field)}
+	}
+
+	var msgs []string
+
+	if redirect.Scheme != nil && *redirect.Scheme != "http" && *redirect.Scheme != "https" {
+		msgs = append(msgs, fmt.Sprintf("%s.scheme: unsupported scheme %q, use \"http\" or \"https\"",
+			field, *redirect.Scheme))
+	}
+
+	if redirect.Hostname != nil {
+		if errs := validation.IsDNS1123Subdomain(string(*redirect.Hostname)); len(errs) > 0 {
+			msgs = append(msgs, fmt.Sprintf("%s.hostname: invalid hostname %q: %s",
+				field, *redirect.Hostname, strings.Join(errs, ", ")))
+		}
+	}
+
+	if redirect.Port != nil && (*redirect.Port < 1 || *redirect.Port > 65535) {
+		msgs = append(msgs, fmt.Sprintf("%s.port: invalid port %d, use a port between 1 and 65535",
+			field, *redirect.Port))
+	}
+
+	if redirect.StatusCode != nil && *redirect.StatusCode != 301 && *redirect.StatusCode != 302 {
+		msgs = append(msgs, fmt.Sprintf("%s.statusCode: unsupported status code %d, use 301 or 302",
+			field, *redirect.StatusCode))
+	}
+
+	return msgs
+}
+
+// rewritePathRegexp matches the paths that the URLRewrite filter can replace the path of a request with. NGINX includes
+// the path in the URI of the proxied request, so the path can only include the characters allowed in the path of
+// a URI, except for '$', which NGINX would interpret as a variable.
+var rewritePathRegexp = regexp.MustCompile(`^/[A-Za-z0-9\-._~%!&'()*+,;=:@/]*$`)
+
+// validateURLRewrite validates the fields of the URLRewrite filter. The fields are also validated by the CRD schema,
+// but we don't rely on it here, because NGINX includes them in the proxied request.
+func validateURLRewrite(rewrite *v1alpha2.HTTPURLRewriteFilter, field string) []string {
+	if rewrite == nil {
+		return []string{fmt.Sprintf("%s: must be specified for the URLRewrite filter", field)}
+	}
+
+	var msgs []string
+
+	if rewrite.Hostname != nil {
+		if errs := validation.IsDNS1123Subdomain(string(*rewrite.Hostname)); len(errs) > 0 {
+			msgs = append(msgs, fmt.Sprintf("%s.hostname: invalid hostname %q: %s",
+				field, *rewrite.Hostname, strings.Join(errs, ", ")))
+		}
+	}
+
+	if rewrite.Path == nil {
+		return msgs
+	}
+
+	validatePath := func(path *string, field string, canBeEmpty bool) {
+		switch {
+		case path == nil:
+			msgs = append(msgs, fmt.Sprintf("%s: must be specified for the path type %q", field, rewrite.Path.Type))
+		case *path == "" && canBeEmpty:
+			// an empty replacement of the prefix removes the prefix from the path
+		case !rewritePathRegexp.MatchString(*path):
+			msgs = append(msgs, fmt.Sprintf("%s: invalid path %q, it must start with '/' and can only include "+
+				"the characters allowed in the path of a URI, except for '$'", field, *path))
+		}
+	}
+
+	switch rewrite.Path.Type {
+	case v1beta1.FullPathHTTPPathModifier:
+		validatePath(rewrite.Path.ReplaceFullPath, field+".path.replaceFullPath", false)
+	case v1beta1.PrefixMatchHTTPPathModifier:
+		validatePath(rewrite.Path.ReplacePrefixMatch, field+".path.replacePrefixMatch", true)
+	default:
+		msgs = append(msgs, fmt.Sprintf("%s.path.type: unsupported type %q", field, rewrite.Path.Type))
+	}
+
+	return msgs
+}
+
+// validateRuleURLRewrite validates that the URLRewrite filter of the rule is compatible with the rule. As required by
+// the Gateway API, a rule that replaces the prefix of the path must only have prefix path matches. A rule cannot both
+// redirect the requests and rewrite them.
+func validateRuleURLRewrite(rule v1alpha2.HTTPRouteRule, field string) []string {
+	var rewrite *v1alpha2.HTTPURLRewriteFilter
+	redirect := false
+
+	for _, f := range rule.Filters {
+		switch {
+		case f.Type == v1beta1.HTTPRouteFilterURLRewrite && f.URLRewrite != nil:
+			rewrite = f.URLRewrite
+		case f.Type == v1beta1.HTTPRouteFilterRequestRedirect:
+			redirect = true
+		}
+	}
+
+	if rewrite == nil {
+		return nil
+	}
+
+	var msgs []string
+
+	if redirect {
+		msgs = append(msgs, fmt.Sprintf("%s.filters: the URLRewrite and the RequestRedirect filters cannot be used "+
+			"together", field))
+	}
+
+	if rewrite.Path == nil || rewrite.Path.Type != v1beta1.PrefixMatchHTTPPathModifier {
+		return msgs
+	}
+
+	for i, m := range rule.Matches {
+		if getPathType(m.Path) != PathTypePrefix {
+			msgs = append(msgs, fmt.Sprintf("%s.matches[%d].path.type: the URLRewrite filter that replaces "+
+				"the prefix match requires the type %q", field, i, v1beta1.PathMatchPathPrefix))
+		}
+	}
+
+	return msgs
+}
+
+// headerNameRegexp matches the header names that the filters can modify. NGINX exposes a request header as
+// the variable $http_<name>, which is required to add a value to the header. The variable name can only be built for
+// the header names that consist of letters, digits and hyphens.
+var headerNameRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// validateRequestHeaderModifier validates the names and the values of the headers of the RequestHeaderModifier filter,
+// so that they cannot inject NGINX configuration. The Host header can only be set, because NGINX sets it for
+// the proxied requests. The Connection header cannot be modified, because NGINX uses it to keep the connections to
+// the backends alive.
+func validateRequestHeaderModifier(modifier *v1alpha2.HTTPHeaderFilter, field string) []string {
+	if modifier == nil {
+		return []string{fmt.Sprintf("%s: must be specified for the RequestHeaderModifier filter", field)}
+	}
+
+	var msgs []string
+
+	validateName := func(name string, field string, canModifyHost bool) {
+		switch {
+		case !headerNameRegexp.MatchString(name):
+			msgs = append(msgs, fmt.Sprintf("%s: invalid header name %q, it must consist of letters, digits and '-'",
+				field, name))
+		case strings.EqualFold(name, "Connection"):
+			msgs = append(msgs, fmt.Sprintf("%s: header %q cannot be modified", field, name))
+		case strings.EqualFold(name, "Host") && !canModifyHost:
+			msgs = append(msgs, fmt.Sprintf("%s: header %q can only be set", field, name))
+		}
+	}
+
+	validateValue := func(value string, field string) {
+		if err := validateHeaderValue(value); err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+
+	for i, h := range modifier.Set {
+		validateName(string(h.Name), fmt.Sprintf("%s.set[%d].name", field, i), true)
+		validateValue(h.Value, fmt.Sprintf("%s.set[%d].value", field, i))
+	}
+
+	for i, h := range modifier.Add {
+		validateName(string(h.Name), fmt.Sprintf("%s.add[%d].name", field, i), false)
+		validateValue(h.Value, fmt.Sprintf("%s.add[%d].value", field, i))
+	}
+
+	for i, name := range modifier.Remove {
+		validateName(name, fmt.Sprintf("%s.remove[%d]", field, i), false)
+	}
+
+	return msgs
+}
+
+// validateResponseHeaderModifier validates the names and the values of the headers of the ResponseHeaderModifier
+// filter, so that they cannot inject NGINX configuration. NGINX exposes a response header of the backend as
+// the variable $upstream_http_<name>, so the names are restricted the same way as for the RequestHeaderModifier
+// filter. The headers that NGINX generates itself for the responses cannot be modified. The Server header can only be
+// removed, because NGINX always adds its own Server header to the responses.
+func validateResponseHeaderModifier(modifier *v1alpha2.HTTPHeaderFilter, field string) []string {
+	if modifier == nil {
+		return []string{fmt.Sprintf("%s: must be specified for the ResponseHeaderModifier filter", field)}
+	}
+
+	var msgs []string
+
+	validateName := func(name string, field string, canRemoveServer bool) {
+		switch {
+		case !headerNameRegexp.MatchString(name):
+			msgs = append(msgs, fmt.Sprintf("%s: invalid header name %q, it must consist of letters, digits and '-'",
+				field, name))
+		case isReservedResponseHeader(name):
+			msgs = append(msgs, fmt.Sprintf("%s: header %q cannot be modified", field, name))
+		case strings.EqualFold(name, "Server") && !canRemoveServer:
+			msgs = append(msgs, fmt.Sprintf("%s: header %q can only be removed", field, name))
+		}
+	}
+
+	validateValue := func(value string, field string) {
+		if err := validateHeaderValue(value); err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+
+	for i, h := range modifier.Set {
+		validateName(string(h.Name), fmt.Sprintf("%s.set[%d].name", field, i), false)
+		validateValue(h.Value, fmt.Sprintf("%s.set[%d].value", field, i))
+	}
+
+	for i, h := range modifier.Add {
+		validateName(string(h.Name), fmt.Sprintf("%s.add[%d].name", field, i), false)
+		validateValue(h.Value, fmt.Sprintf("%s.add[%d].value", field, i))
+	}
+
+	for i, name := range modifier.Remove {
+		validateName(name, fmt.Sprintf("%s.remove[%d]", field, i), true)
+	}
+
+	return msgs
+}
+
+// isReservedResponseHeader returns true if NGINX generates the response header itself, so that the header of
+// the backend cannot be hidden or replaced.
+func isReservedResponseHeader(name string) bool {
+	for _, reserved := range []string{"Connection", "Content-Length", "Date", "Transfer-Encoding"} {
+		if strings.EqualFold(name, reserved) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateHeaderValue validates that the header value doesn't include control characters, which are not allowed in
+// header values, and '$', which NGINX would interpret as a variable.
+func validateHeaderValue(value string) error {
+	for _, c := range value {
+		if c == '$' || (c < ' ' && c != '\t') || c == 0x7f {
+			return fmt.Errorf("invalid header value %q, it must not include control characters and '$'", value)
+		}
+	}
+
+	return nil
+}
+
+// validatePathRegex validates that the path regular expression is supported by NGINX.
+// NGINX uses PCRE, while we parse the expression using the RE2 syntax of Go. The expressions with the PCRE features
+// that RE2 doesn't support, like backreferences and lookarounds, are rejected. Because some of the RE2 syntax means
+// something else in PCRE or is not supported by it, the validation also rejects the flags like (?i) and (?U),
+// POSIX classes like [[:word:]] and the escape sequences \C, \p and \P, so that the accepted expressions use the
+// syntax that RE2 and PCRE interpret the same way.
+func validatePathRegex(path string) error {
+	if _, err := regexp.Compile(path); err != nil {
+		return fmt.Errorf("unsupported regular expression %q: %w", path, err)
+	}
+
+	if unsupported := findUnsupportedRegexSyntax(path, `CpP`, true); unsupported != "" {
+		return fmt.Errorf("unsupported regular expression %q: %q is not supported", path, unsupported)
+	}
+
+	return nil
+}
+
+// validateMatchRegex validates that the regular expression of a header or a query param match is supported by
+// the httpmatches NJS module, which evaluates the expression as a JavaScript RegExp.
+// Like validatePathRegex, it only accepts the RE2 syntax. Additionally, it rejects the parts of the RE2 syntax that
+// JavaScript doesn't support: flags and named groups like (?i) and (?P<name>), POSIX classes like [[:alpha:]] and
+// the escape sequences \A, \z, \C, \Q, \E, \p and \P. The empty expression is rejected too.
+func validateMatchRegex(regex string) error {
+	// the httpmatches NJS module rejects the matches with an empty expression
+	if regex == "" {
+		return errors.New("regular expression must not be empty")
+	}
+
+	if _, err := regexp.Compile(regex); err != nil {
+		return fmt.Errorf("unsupported regular expression %q: %w", regex, err)
+	}
+
+	if unsupported := findUnsupportedRegexSyntax(regex, `AzCQEpP`, false); unsupported != "" {
+		return fmt.Errorf("unsupported regular expression %q: %q is not supported", regex, unsupported)
+	}
+
+	return nil
+}
+
+// findUnsupportedRegexSyntax returns the first part of the valid RE2 regex that another regex engine doesn't support
+// or interprets differently, or an empty string if there is no such part. The unsupported parts are the escape
+// sequences of the characters in escapes, POSIX classes and the groups with flags. The non-capturing groups (?:...)
+// are always supported, while the named groups (?P<name>...) are only supported if namedGroups is true.
+func findUnsupportedRegexSyntax(regex string, escapes string, namedGroups bool) string {
+	inClass := false
+
+	for i := 0; i < len(regex); i++ {
+		switch c := regex[i]; {
+		case c == '\\':
+			if i+1 < len(regex) && strings.IndexByte(escapes, regex[i+1]) != -1 {
+				return regex[i : i+2]
+			}
+			i++ // skip the escaped character
+		case inClass && strings.HasPrefix(regex[i:], "[:"):
+			return "[:"
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case !inClass && strings.HasPrefix(regex[i:], "(?"):
+			if strings.HasPrefix(regex[i:], "(?:") || (namedGroups && strings.HasPrefix(regex[i:], "(?P<")) {
+				continue
+			}
+			return "(?"
+		}
+	}
+
+	return ""
+}
+
 func validateGatewayClass(gc *v1alpha2.GatewayClass, controllerName string) error {
 	if string(gc.Spec.ControllerName) != controllerName {
 		return fmt.Errorf("Spec.ControllerName must be %s got %s", controllerName, gc.Spec.ControllerName)
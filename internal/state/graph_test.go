@@ -1,14 +1,20 @@
 package state
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 func TestBuildGraph(t *testing.T) {
@@ -16,7 +22,9 @@ func TestBuildGraph(t *testing.T) {
 		gcName         = "my-class"
 		controllerName = "my.controller"
 	)
-	createRoute := func(name string, gatewayName string) *v1alpha2.HTTPRoute {
+
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway-1"}
+	createRoute := func(name string, gatewayName string, listenerName string) *v1alpha2.HTTPRoute {
 		return &v1alpha2.HTTPRoute{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: "test",
@@ -24,11 +32,11 @@ func TestBuildGraph(t *testing.T) {
 			},
 			Spec: v1alpha2.HTTPRouteSpec{
 				CommonRouteSpec: v1alpha2.CommonRouteSpec{
-					ParentRefs: []v1alpha2.ParentRef{
+					ParentRefs: []v1alpha2.ParentReference{
 						{
 							Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 							Name:        v1alpha2.ObjectName(gatewayName),
-							SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+							SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer(listenerName)),
 						},
 					},
 				},
@@ -49,8 +57,21 @@ func TestBuildGraph(t *testing.T) {
 			},
 		}
 	}
-	hr1 := createRoute("hr-1", "gateway-1")
-	hr2 := createRoute("hr-2", "wrong-gateway")
+	hr1 := createRoute("hr-1", "gateway-1", "listener-80-1")
+	hr2 := createRoute("hr-2", "wrong-gateway", "listener-80-1")
+	hr3 := createRoute("hr-3", "gateway-1", "listener-443-1") // https listener; should not conflict with hr1
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "secret",
+		},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+		},
+		Type: apiv1.SecretTypeTLS,
+	}
 
 	createGateway := func(name string) *v1alpha2.Gateway {
 		return &v1alpha2.Gateway{
@@ -65,7 +86,23 @@ func TestBuildGraph(t *testing.T) {
 						Name:     "listener-80-1",
 						Hostname: nil,
 						Port:     80,
-						Protocol: v1alpha2.HTTPProtocolType,
+						Protocol: v1beta1.HTTPProtocolType,
+					},
+					{
+						Name:     "listener-443-1",
+						Hostname: nil,
+						Port:     443,
+						Protocol: v1beta1.HTTPSProtocolType,
+						TLS: &v1alpha2.GatewayTLSConfig{
+							Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+							CertificateRefs: []v1alpha2.SecretObjectReference{
+								{
+									Kind:      (*v1alpha2.Kind)(helpers.GetStringPointer("Secret")),
+									Name:      v1alpha2.ObjectName(secret.Name),
+									Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer(secret.Namespace)),
+								},
+							},
+						},
 					},
 				},
 			},
@@ -88,83 +125,210 @@ func TestBuildGraph(t *testing.T) {
 		httpRoutes: map[types.NamespacedName]*v1alpha2.HTTPRoute{
 			{Namespace: "test", Name: "hr-1"}: hr1,
 			{Namespace: "test", Name: "hr-2"}: hr2,
+			{Namespace: "test", Name: "hr-3"}: hr3,
+		},
+		secrets: map[types.NamespacedName]*apiv1.Secret{
+			{Namespace: "test", Name: "secret"}: secret,
 		},
 	}
 
 	routeHR1 := &route{
 		Source: hr1,
-		ValidSectionNameRefs: map[string]struct{}{
-			"listener-80-1": {},
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+	routeHR3 := &route{
+		Source: hr3,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-443-1"}: {},
 		},
-		InvalidSectionNameRefs: map[string]struct{}{},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 	}
+
+	createConflictCondition := func(listenerName string) Condition {
+		cond := newListenerHostnameConflictCondition()
+		cond.Message += "; the conflicting listener " + listenerName + " belongs to the Gateway test/gateway-1"
+		return cond
+	}
+
+	secretMemoryMgr := NewSecretDiskMemoryManager("/etc/nginx/secrets")
+
 	expected := &graph{
 		GatewayClass: &gatewayClass{
 			Source: store.gc,
 			Valid:  true,
 		},
-		Gateway: &gateway{
-			Source: gw1,
-			Listeners: map[string]*listener{
-				"listener-80-1": {
-					Source: gw1.Spec.Listeners[0],
-					Valid:  true,
-					Routes: map[types.NamespacedName]*route{
-						{Namespace: "test", Name: "hr-1"}: routeHR1,
+		Gateways: map[types.NamespacedName]*gateway{
+			gwNsName: {
+				Source: gw1,
+				Listeners: map[string]*listener{
+					"listener-80-1": {
+						Source: gw1.Spec.Listeners[0],
+						Valid:  true,
+						Routes: map[types.NamespacedName]*route{
+							{Namespace: "test", Name: "hr-1"}: routeHR1,
+						},
+						AcceptedHostnames: map[string]struct{}{
+							"foo.example.com": {},
+						},
+						GRPCRoutes: map[types.NamespacedName]*route{},
+						TLSRoutes:  map[types.NamespacedName]*tlsRoute{},
+						L4Routes:   map[types.NamespacedName]*l4Route{},
 					},
-					AcceptedHostnames: map[string]struct{}{
-						"foo.example.com": {},
+					"listener-443-1": {
+						Source:     gw1.Spec.Listeners[1],
+						Valid:      true,
+						SecretPath: "/etc/nginx/secrets/test_secret.pem",
+						Routes: map[types.NamespacedName]*route{
+							{Namespace: "test", Name: "hr-3"}: routeHR3,
+						},
+						AcceptedHostnames: map[string]struct{}{
+							"foo.example.com": {},
+						},
+						GRPCRoutes: map[types.NamespacedName]*route{},
+						TLSRoutes:  map[types.NamespacedName]*tlsRoute{},
+						L4Routes:   map[types.NamespacedName]*l4Route{},
+					},
+				},
+			},
+			{Namespace: "test", Name: "gateway-2"}: {
+				Source: gw2,
+				// the listeners of gateway-2 conflict with the listeners of the older gateway-1
+				Listeners: map[string]*listener{
+					"listener-80-1": {
+						Source: gw2.Spec.Listeners[0],
+						Valid:  false,
+						Conditions: []Condition{
+							createConflictCondition("listener-80-1"),
+						},
+						Routes:            map[types.NamespacedName]*route{},
+						AcceptedHostnames: map[string]struct{}{},
+						GRPCRoutes:        map[types.NamespacedName]*route{},
+						TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+						L4Routes:          map[types.NamespacedName]*l4Route{},
+					},
+					"listener-443-1": {
+						Source: gw2.Spec.Listeners[1],
+						Valid:  false,
+						Conditions: []Condition{
+							createConflictCondition("listener-443-1"),
+						},
+						Routes:            map[types.NamespacedName]*route{},
+						AcceptedHostnames: map[string]struct{}{},
+						GRPCRoutes:        map[types.NamespacedName]*route{},
+						TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+						L4Routes:          map[types.NamespacedName]*l4Route{},
 					},
 				},
 			},
-		},
-		IgnoredGateways: map[types.NamespacedName]*v1alpha2.Gateway{
-			{Namespace: "test", Name: "gateway-2"}: gw2,
 		},
 		Routes: map[types.NamespacedName]*route{
 			{Namespace: "test", Name: "hr-1"}: routeHR1,
+			{Namespace: "test", Name: "hr-3"}: routeHR3,
 		},
+		GRPCRoutes: map[types.NamespacedName]*route{},
+		TLSRoutes:  map[types.NamespacedName]*tlsRoute{},
+		TCPRoutes:  map[types.NamespacedName]*l4Route{},
+		UDPRoutes:  map[types.NamespacedName]*l4Route{},
 	}
 
-	result := buildGraph(store, controllerName, gcName)
+	result := buildGraph(store, controllerName, gcName, secretMemoryMgr)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("buildGraph() mismatch (-want +got):\n%s", diff)
 	}
 }
 
-func TestProcessGateways(t *testing.T) {
+func TestBuildGateways(t *testing.T) {
 	const gcName = "test-gc"
 
-	winner := &v1alpha2.Gateway{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: "test",
-			Name:      "gateway-1",
-		},
-		Spec: v1alpha2.GatewaySpec{
-			GatewayClassName: gcName,
-		},
+	listener80Foo := v1alpha2.Listener{
+		Name:     "listener-80-foo",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+		Port:     80,
+		Protocol: v1beta1.HTTPProtocolType,
 	}
-	loser := &v1alpha2.Gateway{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: "test",
-			Name:      "gateway-2",
-		},
-		Spec: v1alpha2.GatewaySpec{
-			GatewayClassName: gcName,
-		},
+	listener80Bar := v1alpha2.Listener{
+		Name:     "listener-80-bar",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com")),
+		Port:     80,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+	listener53TCP := v1alpha2.Listener{
+		Name:     "listener-53-tcp",
+		Port:     53,
+		Protocol: v1beta1.TCPProtocolType,
+	}
+	listener53UDP := v1alpha2.Listener{
+		Name:     "listener-53-udp",
+		Port:     53,
+		Protocol: v1beta1.UDPProtocolType,
+	}
+	listener8080SCTP := v1alpha2.Listener{
+		Name:     "listener-8080",
+		Port:     8080,
+		Protocol: "SCTP", // invalid protocol
+	}
+	listener8080HTTP := v1alpha2.Listener{
+		Name:     "listener-8080",
+		Port:     8080,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	createGateway := func(name string, created metav1.Time, listeners ...v1alpha2.Listener) *v1alpha2.Gateway {
+		return &v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              name,
+				CreationTimestamp: created,
+			},
+			Spec: v1alpha2.GatewaySpec{
+				GatewayClassName: gcName,
+				Listeners:        listeners,
+			},
+		}
+	}
+
+	older := metav1.Now()
+	newer := metav1.NewTime(older.Add(1))
+
+	// gateway-2 is older than gateway-1, so that its listeners win the conflicts
+	gw1 := createGateway("gateway-1", newer, listener80Foo, listener80Bar, listener53TCP, listener53UDP, listener8080HTTP)
+	gw2 := createGateway("gateway-2", older, listener80Foo, listener53TCP, listener8080SCTP)
+
+	createListener := func(l v1alpha2.Listener, conds ...Condition) *listener {
+		return &listener{
+			Source:            l,
+			Valid:             len(conds) == 0,
+			Conditions:        conds,
+			Routes:            map[types.NamespacedName]*route{},
+			AcceptedHostnames: map[string]struct{}{},
+			GRPCRoutes:        map[types.NamespacedName]*route{},
+			TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+			L4Routes:          map[types.NamespacedName]*l4Route{},
+		}
 	}
 
+	hostnameConflictCond := newListenerHostnameConflictCondition()
+	hostnameConflictCond.Message += "; the conflicting listener listener-80-foo belongs to the Gateway test/gateway-2"
+
+	portConflictCond := newListenerPortUnavailableCondition(
+		"Multiple TCP listeners use port 53; the conflicting listener listener-53-tcp belongs to the Gateway " +
+			"test/gateway-2")
+
+	sctpCond := newListenerUnsupportedProtocolCondition(
+		`Protocol "SCTP" is not supported, use "HTTP", "HTTPS", "TLS", "TCP" or "UDP"`)
+
 	tests := []struct {
-		gws                map[types.NamespacedName]*v1alpha2.Gateway
-		expectedWinner     *v1alpha2.Gateway
-		expectedIgnoredGws map[types.NamespacedName]*v1alpha2.Gateway
-		msg                string
+		gws      map[types.NamespacedName]*v1alpha2.Gateway
+		expected map[types.NamespacedName]*gateway
+		msg      string
 	}{
 		{
-			gws:                nil,
-			expectedWinner:     nil,
-			expectedIgnoredGws: nil,
-			msg:                "no gateways",
+			gws:      nil,
+			expected: map[types.NamespacedName]*gateway{},
+			msg:      "no gateways",
 		},
 		{
 			gws: map[types.NamespacedName]*v1alpha2.Gateway{
@@ -172,39 +336,63 @@ func TestProcessGateways(t *testing.T) {
 					Spec: v1alpha2.GatewaySpec{GatewayClassName: "some-class"},
 				},
 			},
-			expectedWinner:     nil,
-			expectedIgnoredGws: nil,
-			msg:                "unrelated gateway",
+			expected: map[types.NamespacedName]*gateway{},
+			msg:      "unrelated gateway",
 		},
 		{
 			gws: map[types.NamespacedName]*v1alpha2.Gateway{
-				{Namespace: "test", Name: "gateway"}: winner,
+				{Namespace: "test", Name: "gateway-1"}: gw1,
 			},
-			expectedWinner:     winner,
-			expectedIgnoredGws: map[types.NamespacedName]*v1alpha2.Gateway{},
-			msg:                "one gateway",
+			expected: map[types.NamespacedName]*gateway{
+				{Namespace: "test", Name: "gateway-1"}: {
+					Source: gw1,
+					Listeners: map[string]*listener{
+						"listener-80-foo": createListener(listener80Foo),
+						"listener-80-bar": createListener(listener80Bar),
+						"listener-53-tcp": createListener(listener53TCP),
+						"listener-53-udp": createListener(listener53UDP),
+						"listener-8080":   createListener(listener8080HTTP),
+					},
+				},
+			},
+			msg: "one gateway",
 		},
 		{
 			gws: map[types.NamespacedName]*v1alpha2.Gateway{
-				{Namespace: "test", Name: "gateway-1"}: winner,
-				{Namespace: "test", Name: "gateway-2"}: loser,
+				{Namespace: "test", Name: "gateway-1"}: gw1,
+				{Namespace: "test", Name: "gateway-2"}: gw2,
 			},
-			expectedWinner: winner,
-			expectedIgnoredGws: map[types.NamespacedName]*v1alpha2.Gateway{
-				{Namespace: "test", Name: "gateway-2"}: loser,
+			expected: map[types.NamespacedName]*gateway{
+				{Namespace: "test", Name: "gateway-1"}: {
+					Source: gw1,
+					Listeners: map[string]*listener{
+						"listener-80-foo": createListener(listener80Foo, hostnameConflictCond),
+						"listener-80-bar": createListener(listener80Bar),
+						"listener-53-tcp": createListener(listener53TCP, portConflictCond),
+						"listener-53-udp": createListener(listener53UDP),
+						// the invalid listener of the older gateway doesn't conflict
+						"listener-8080": createListener(listener8080HTTP),
+					},
+				},
+				{Namespace: "test", Name: "gateway-2"}: {
+					Source: gw2,
+					Listeners: map[string]*listener{
+						"listener-80-foo": createListener(listener80Foo),
+						"listener-53-tcp": createListener(listener53TCP),
+						"listener-8080":   createListener(listener8080SCTP, sctpCond),
+					},
+				},
 			},
-			msg: "multiple gateways",
+			msg: "multiple gateways with conflicting listeners",
 		},
 	}
 
-	for _, test := range tests {
-		winner, ignoredGws := processGateways(test.gws, gcName)
+	secretMemoryMgr := NewSecretDiskMemoryManager("/etc/nginx/secrets")
 
-		if diff := cmp.Diff(winner, test.expectedWinner); diff != "" {
-			t.Errorf("processGateways() '%s' mismatch for winner (-want +got):\n%s", test.msg, diff)
-		}
-		if diff := cmp.Diff(ignoredGws, test.expectedIgnoredGws); diff != "" {
-			t.Errorf("processGateways() '%s' mismatch for ignored gateways (-want +got):\n%s", test.msg, diff)
+	for _, test := range tests {
+		result := buildGateways(test.gws, gcName, nil, secretMemoryMgr)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildGateways() %q mismatch (-want +got):\n%s", test.msg, diff)
 		}
 	}
 }
@@ -223,6 +411,44 @@ func TestBuildGatewayClass(t *testing.T) {
 		},
 	}
 
+	createGCWithParametersRef := func(ref v1alpha2.ParametersReference) *v1alpha2.GatewayClass {
+		gc := validGC.DeepCopy()
+		gc.Spec.ParametersRef = &ref
+		return gc
+	}
+
+	gcWithConfig := createGCWithParametersRef(v1alpha2.ParametersReference{
+		Group: "gateway.nginx.org",
+		Kind:  "GatewayConfig",
+		Name:  "nginx",
+	})
+	gcWithMissingConfig := createGCWithParametersRef(v1alpha2.ParametersReference{
+		Group: "gateway.nginx.org",
+		Kind:  "GatewayConfig",
+		Name:  "does-not-exist",
+	})
+	gcWithWrongKind := createGCWithParametersRef(v1alpha2.ParametersReference{
+		Group: "",
+		Kind:  "ConfigMap",
+		Name:  "nginx",
+	})
+	gcWithNamespace := createGCWithParametersRef(v1alpha2.ParametersReference{
+		Group:     "gateway.nginx.org",
+		Kind:      "GatewayConfig",
+		Name:      "nginx",
+		Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+	})
+
+	gatewayConfig := &nginxgwv1alpha1.GatewayConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nginx",
+		},
+	}
+
+	gatewayConfigs := map[types.NamespacedName]*nginxgwv1alpha1.GatewayConfig{
+		{Name: "nginx"}: gatewayConfig,
+	}
+
 	tests := []struct {
 		gc       *v1alpha2.GatewayClass
 		expected *gatewayClass
@@ -251,10 +477,49 @@ func TestBuildGatewayClass(t *testing.T) {
 			},
 			msg: "invalid gatewayclass",
 		},
+		{
+			gc: gcWithConfig,
+			expected: &gatewayClass{
+				Source: gcWithConfig,
+				Valid:  true,
+				Config: gatewayConfig,
+			},
+			msg: "gatewayclass with gatewayconfig",
+		},
+		{
+			gc: gcWithMissingConfig,
+			expected: &gatewayClass{
+				Source:            gcWithMissingConfig,
+				Valid:             false,
+				ErrorMsg:          "GatewayConfig does-not-exist doesn't exist",
+				InvalidParameters: true,
+			},
+			msg: "gatewayclass with missing gatewayconfig",
+		},
+		{
+			gc: gcWithWrongKind,
+			expected: &gatewayClass{
+				Source:            gcWithWrongKind,
+				Valid:             false,
+				ErrorMsg:          "Spec.ParametersRef must reference gateway.nginx.org/GatewayConfig got /ConfigMap",
+				InvalidParameters: true,
+			},
+			msg: "gatewayclass with parametersRef of unsupported kind",
+		},
+		{
+			gc: gcWithNamespace,
+			expected: &gatewayClass{
+				Source:            gcWithNamespace,
+				Valid:             false,
+				ErrorMsg:          "Spec.ParametersRef.Namespace must not be set for the cluster-scoped GatewayConfig",
+				InvalidParameters: true,
+			},
+			msg: "gatewayclass with parametersRef with namespace",
+		},
 	}
 
 	for _, test := range tests {
-		result := buildGatewayClass(test.gc, controllerName)
+		result := buildGatewayClass(test.gc, controllerName, gatewayConfigs)
 		if diff := cmp.Diff(test.expected, result); diff != "" {
 			t.Errorf("buildGatewayClass() '%s' mismatch (-want +got):\n%s", test.msg, diff)
 		}
@@ -268,25 +533,119 @@ func TestBuildListeners(t *testing.T) {
 		Name:     "listener-80-1",
 		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
 		Port:     80,
-		Protocol: v1alpha2.HTTPProtocolType,
+		Protocol: v1beta1.HTTPProtocolType,
 	}
 	listener802 := v1alpha2.Listener{
 		Name:     "listener-80-2",
 		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com")),
 		Port:     80,
-		Protocol: v1alpha2.TCPProtocolType, // invalid protocol
+		Protocol: "SCTP", // invalid protocol
 	}
 	listener803 := v1alpha2.Listener{
 		Name:     "listener-80-3",
 		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com")),
 		Port:     80,
-		Protocol: v1alpha2.HTTPProtocolType,
+		Protocol: v1beta1.HTTPProtocolType,
 	}
 	listener804 := v1alpha2.Listener{
 		Name:     "listener-80-4",
 		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
 		Port:     80,
-		Protocol: v1alpha2.HTTPProtocolType,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	createHTTPSListener := func(name, hostname, secretNamespace, secretName string) v1alpha2.Listener {
+		return v1alpha2.Listener{
+			Name:     v1alpha2.SectionName(name),
+			Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer(hostname)),
+			Port:     443,
+			Protocol: v1beta1.HTTPSProtocolType,
+			TLS: &v1alpha2.GatewayTLSConfig{
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{
+						Name:      v1alpha2.ObjectName(secretName),
+						Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer(secretNamespace)),
+					},
+				},
+			},
+		}
+	}
+
+	listener4431 := createHTTPSListener("listener-443-1", "foo.example.com", "test", "secret")
+	listener4432 := createHTTPSListener("listener-443-2", "bar.example.com", "test", "does-not-exist")
+	listener4433 := createHTTPSListener("listener-443-3", "baz.example.com", "other-namespace", "secret")
+	listener4434 := createHTTPSListener("listener-443-4", "qux.example.com", "test", "invalid-secret")
+	listener4435 := createHTTPSListener("listener-443-5", "foo.example.com", "test", "secret")
+
+	// the listener with the same port as listener443x but with a different protocol
+	listener443HTTP := v1alpha2.Listener{
+		Name:     "listener-443-http",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com")),
+		Port:     443,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	listener53TCP1 := v1alpha2.Listener{
+		Name:     "listener-53-tcp-1",
+		Port:     53,
+		Protocol: v1beta1.TCPProtocolType,
+	}
+	listener53TCP2 := v1alpha2.Listener{
+		Name:     "listener-53-tcp-2",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")), // ignored for TCP
+		Port:     53,
+		Protocol: v1beta1.TCPProtocolType,
+	}
+	listener53UDP := v1alpha2.Listener{
+		Name:     "listener-53-udp",
+		Port:     53,
+		Protocol: v1beta1.UDPProtocolType,
+	}
+	listener80UDP := v1alpha2.Listener{
+		Name:     "listener-80-udp",
+		Port:     80,
+		Protocol: v1beta1.UDPProtocolType,
+	}
+
+	listener8080 := v1alpha2.Listener{
+		Name:     "listener-8080",
+		Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+		Port:     8080,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+
+	secrets := map[types.NamespacedName]*apiv1.Secret{
+		{Namespace: "test", Name: "secret"}: {
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "secret",
+			},
+			Data: map[string][]byte{
+				apiv1.TLSCertKey:       cert,
+				apiv1.TLSPrivateKeyKey: key,
+			},
+			Type: apiv1.SecretTypeTLS,
+		},
+		{Namespace: "test", Name: "invalid-secret"}: {
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "invalid-secret",
+			},
+			Type: apiv1.SecretTypeOpaque,
+		},
+	}
+
+	createGateway := func(listeners ...v1alpha2.Listener) *v1alpha2.Gateway {
+		return &v1alpha2.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "gateway",
+			},
+			Spec: v1alpha2.GatewaySpec{
+				GatewayClassName: gcName,
+				Listeners:        listeners,
+			},
+		}
 	}
 
 	tests := []struct {
@@ -295,181 +654,442 @@ func TestBuildListeners(t *testing.T) {
 		msg      string
 	}{
 		{
-			gateway: &v1alpha2.Gateway{
-				Spec: v1alpha2.GatewaySpec{
-					GatewayClassName: gcName,
-					Listeners: []v1alpha2.Listener{
-						listener801,
-					},
-				},
-			},
+			gateway: createGateway(listener801),
 			expected: map[string]*listener{
 				"listener-80-1": {
 					Source:            listener801,
 					Valid:             true,
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
 			msg: "valid listener",
 		},
 		{
-			gateway: &v1alpha2.Gateway{
-				Spec: v1alpha2.GatewaySpec{
-					GatewayClassName: gcName,
-					Listeners: []v1alpha2.Listener{
-						listener802,
-					},
-				},
-			},
+			gateway: createGateway(listener802),
 			expected: map[string]*listener{
 				"listener-80-2": {
-					Source:            listener802,
-					Valid:             false,
+					Source: listener802,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerUnsupportedProtocolCondition(`Protocol "SCTP" is not supported, use "HTTP", "HTTPS", "TLS", "TCP" or "UDP"`),
+					},
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
 			msg: "invalid listener",
 		},
 		{
-			gateway: &v1alpha2.Gateway{
-				Spec: v1alpha2.GatewaySpec{
-					GatewayClassName: gcName,
-					Listeners: []v1alpha2.Listener{
-						listener801, listener803,
-					},
-				},
-			},
+			gateway: createGateway(listener801, listener803),
 			expected: map[string]*listener{
 				"listener-80-1": {
 					Source:            listener801,
 					Valid:             true,
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 				"listener-80-3": {
 					Source:            listener803,
 					Valid:             true,
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
 			msg: "two valid Listeners",
 		},
 		{
-			gateway: &v1alpha2.Gateway{
-				Spec: v1alpha2.GatewaySpec{
-					GatewayClassName: gcName,
-					Listeners: []v1alpha2.Listener{
-						listener801, listener804,
-					},
-				},
-			},
+			gateway: createGateway(listener801, listener804),
 			expected: map[string]*listener{
 				"listener-80-1": {
 					Source:            listener801,
 					Valid:             false,
+					Conditions:        []Condition{newListenerHostnameConflictCondition()},
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 				"listener-80-4": {
 					Source:            listener804,
 					Valid:             false,
+					Conditions:        []Condition{newListenerHostnameConflictCondition()},
 					Routes:            map[types.NamespacedName]*route{},
 					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
 			msg: "collision",
 		},
 		{
-			gateway:  nil,
-			expected: map[string]*listener{},
-			msg:      "no gateway",
+			gateway: createGateway(listener801, listener4431),
+			expected: map[string]*listener{
+				"listener-80-1": {
+					Source:            listener801,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-443-1": {
+					Source:            listener4431,
+					Valid:             true,
+					SecretPath:        "/etc/nginx/secrets/test_secret.pem",
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+			},
+			msg: "same hostname on different ports",
 		},
 		{
-			gateway: &v1alpha2.Gateway{
-				Spec: v1alpha2.GatewaySpec{
-					GatewayClassName: "wrong-class",
-					Listeners: []v1alpha2.Listener{
-						listener801, listener804,
+			gateway: createGateway(listener4432, listener4433, listener4434),
+			expected: map[string]*listener{
+				"listener-443-2": {
+					Source: listener4432,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerInvalidCertificateRefCondition("Secret test/does-not-exist does not exist"),
+					},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-443-3": {
+					Source: listener4433,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerRefNotPermittedCondition(
+							"Secret other-namespace/secret is in a different namespace than the Gateway",
+						),
+					},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-443-4": {
+					Source: listener4434,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerInvalidCertificateRefCondition(
+							`Failed to use Secret test/invalid-secret: secret type must be "kubernetes.io/tls", got "Opaque"`,
+						),
 					},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
-			expected: map[string]*listener{},
-			msg:      "wrong gatewayclass",
+			msg: "unresolvable secrets",
 		},
-	}
-
-	for _, test := range tests {
-		result := buildListeners(test.gateway, gcName)
-		if diff := cmp.Diff(test.expected, result); diff != "" {
-			t.Errorf("buildListeners() %q  mismatch (-want +got):\n%s", test.msg, diff)
-		}
-	}
-}
-
-func TestBindRouteToListeners(t *testing.T) {
-	createRoute := func(hostname string, parentRefs ...v1alpha2.ParentRef) *v1alpha2.HTTPRoute {
-		return &v1alpha2.HTTPRoute{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "test",
-				Name:      "hr-1",
+		{
+			gateway: createGateway(listener801, listener8080),
+			expected: map[string]*listener{
+				"listener-80-1": {
+					Source:            listener801,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-8080": {
+					Source:            listener8080,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
 			},
-			Spec: v1alpha2.HTTPRouteSpec{
-				CommonRouteSpec: v1alpha2.CommonRouteSpec{
-					ParentRefs: parentRefs,
+			msg: "same hostname on different http ports",
+		},
+		{
+			gateway: createGateway(listener4431, listener4435),
+			expected: map[string]*listener{
+				"listener-443-1": {
+					Source:            listener4431,
+					Valid:             false,
+					Conditions:        []Condition{newListenerHostnameConflictCondition()},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
-				Hostnames: []v1alpha2.Hostname{
-					v1alpha2.Hostname(hostname),
+				"listener-443-5": {
+					Source:            listener4435,
+					Valid:             false,
+					Conditions:        []Condition{newListenerHostnameConflictCondition()},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
 				},
 			},
-		}
-	}
+			msg: "https collision",
+		},
+		{
+			gateway: createGateway(listener4431, listener443HTTP),
+			expected: map[string]*listener{
+				"listener-443-1": {
+					Source:            listener4431,
+					Valid:             false,
+					Conditions:        []Condition{newListenerProtocolConflictCondition()},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-443-http": {
+					Source:            listener443HTTP,
+					Valid:             false,
+					Conditions:        []Condition{newListenerProtocolConflictCondition()},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+			},
+			msg: "protocol conflict",
+		},
+		{
+			gateway: createGateway(listener53TCP1, listener53TCP2, listener53UDP),
+			expected: map[string]*listener{
+				"listener-53-tcp-1": {
+					Source: listener53TCP1,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerPortUnavailableCondition("Multiple TCP listeners use port 53"),
+					},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-53-tcp-2": {
+					Source: listener53TCP2,
+					Valid:  false,
+					Conditions: []Condition{
+						newListenerPortUnavailableCondition("Multiple TCP listeners use port 53"),
+					},
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-53-udp": {
+					Source:            listener53UDP,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+			},
+			msg: "tcp port conflict",
+		},
+		{
+			gateway: createGateway(listener801, listener80UDP),
+			expected: map[string]*listener{
+				"listener-80-1": {
+					Source:            listener801,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+				"listener-80-udp": {
+					Source:            listener80UDP,
+					Valid:             true,
+					Routes:            map[types.NamespacedName]*route{},
+					AcceptedHostnames: map[string]struct{}{},
+					GRPCRoutes:        map[types.NamespacedName]*route{},
+					TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+					L4Routes:          map[types.NamespacedName]*l4Route{},
+				},
+			},
+			msg: "udp and http share port",
+		},
+		{
+			gateway:  nil,
+			expected: map[string]*listener{},
+			msg:      "no gateway",
+		},
+		{
+			gateway: &v1alpha2.Gateway{
+				Spec: v1alpha2.GatewaySpec{
+					GatewayClassName: "wrong-class",
+					Listeners: []v1alpha2.Listener{
+						listener801, listener804,
+					},
+				},
+			},
+			expected: map[string]*listener{},
+			msg:      "wrong gatewayclass",
+		},
+	}
+
+	for _, test := range tests {
+		secretMemoryMgr := NewSecretDiskMemoryManager("/etc/nginx/secrets")
+
+		result := buildListeners(test.gateway, gcName, nil, secrets, secretMemoryMgr)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("buildListeners() %q  mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBindRouteToListeners(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+	gw2NsName := types.NamespacedName{Namespace: "test", Name: "gateway-2"}
+
+	createRoute := func(hostname string, parentRefs ...v1alpha2.ParentReference) *v1alpha2.HTTPRoute {
+		return &v1alpha2.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "hr-1",
+			},
+			Spec: v1alpha2.HTTPRouteSpec{
+				CommonRouteSpec: v1alpha2.CommonRouteSpec{
+					ParentRefs: parentRefs,
+				},
+				Hostnames: []v1alpha2.Hostname{
+					v1alpha2.Hostname(hostname),
+				},
+			},
+		}
+	}
 
-	hrNonExistingSectionName := createRoute("foo.example.com", v1alpha2.ParentRef{
+	hrNonExistingSectionName := createRoute("foo.example.com", v1alpha2.ParentReference{
 		Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 		Name:        "gateway",
 		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-2")),
 	})
 
-	hrEmptySectionName := createRoute("foo.example.com", v1alpha2.ParentRef{
+	hrEmptySectionName := createRoute("foo.example.com", v1alpha2.ParentReference{
+		Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+		Name:      "gateway",
+	})
+
+	hrOtherNamespace := createRoute("foo.example.com", v1alpha2.ParentReference{
+		Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+		Name:      "gateway",
+	})
+	hrOtherNamespace.Namespace = "other"
+
+	hrBarEmptySectionName := createRoute("bar.example.com", v1alpha2.ParentReference{
 		Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 		Name:      "gateway",
 	})
 
-	hrIgnoredGateway := createRoute("foo.example.com", v1alpha2.ParentRef{
+	hrDuplicateParentRefs := createRoute("foo.example.com",
+		v1alpha2.ParentReference{
+			Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+			Name:        "gateway-2",
+			SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+		},
+		v1alpha2.ParentReference{
+			Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+			Name:        "gateway",
+			SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+		},
+		v1alpha2.ParentReference{
+			Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+			Name:        "gateway",
+			SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+		},
+	)
+
+	hrGateway2 := createRoute("foo.example.com", v1alpha2.ParentReference{
 		Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
-		Name:        "ignored-gateway",
+		Name:        "gateway-2",
 		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
 	})
 
-	hrFoo := createRoute("foo.example.com", v1alpha2.ParentRef{
+	hrFoo := createRoute("foo.example.com", v1alpha2.ParentReference{
 		Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 		Name:        "gateway",
 		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
 	})
 
-	hrFooImplicitNamespace := createRoute("foo.example.com", v1alpha2.ParentRef{
+	hrFooImplicitNamespace := createRoute("foo.example.com", v1alpha2.ParentReference{
+		Name:        "gateway",
+		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
+	})
+
+	hrBar := createRoute("bar.example.com", v1alpha2.ParentReference{
+		Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 		Name:        "gateway",
 		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
 	})
 
-	hrBar := createRoute("bar.example.com", v1alpha2.ParentRef{
+	hrInvalidRegex := createRoute("foo.example.com", v1alpha2.ParentReference{
 		Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 		Name:        "gateway",
 		SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-80-1")),
 	})
+	hrInvalidRegex.Spec.Rules = []v1alpha2.HTTPRouteRule{
+		{
+			Matches: []v1alpha2.HTTPRouteMatch{
+				{
+					Path: &v1alpha2.HTTPPathMatch{
+						Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+						Value: helpers.GetStringPointer("/foo(bar"),
+					},
+				},
+			},
+		},
+	}
 
 	// we create a new listener each time because the function under test can modify it
 	createListener := func() *listener {
 		return &listener{
 			Source: v1alpha2.Listener{
 				Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+				Protocol: v1beta1.HTTPProtocolType,
 			},
 			Valid:             true,
 			Routes:            map[types.NamespacedName]*route{},
 			AcceptedHostnames: map[string]struct{}{},
+			GRPCRoutes:        map[types.NamespacedName]*route{},
+			TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+			L4Routes:          map[types.NamespacedName]*l4Route{},
 		}
 	}
 
@@ -486,10 +1106,18 @@ func TestBindRouteToListeners(t *testing.T) {
 		},
 	}
 
+	// gw2 is another Gateway of the GatewayClass, which doesn't have any listeners
+	gw2 := &v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "gateway-2",
+		},
+	}
+
 	tests := []struct {
 		httpRoute         *v1alpha2.HTTPRoute
 		gw                *v1alpha2.Gateway
-		ignoredGws        map[types.NamespacedName]*v1alpha2.Gateway
+		otherGateways     map[types.NamespacedName]*gateway
 		listeners         map[string]*listener
 		expectedIgnored   bool
 		expectedRoute     *route
@@ -497,9 +1125,8 @@ func TestBindRouteToListeners(t *testing.T) {
 		msg               string
 	}{
 		{
-			httpRoute:  createRoute("foo.example.com"),
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: createRoute("foo.example.com"),
+			gw:        gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
@@ -511,13 +1138,12 @@ func TestBindRouteToListeners(t *testing.T) {
 			msg: "HTTPRoute without parent refs",
 		},
 		{
-			httpRoute: createRoute("foo.example.com", v1alpha2.ParentRef{
+			httpRoute: createRoute("foo.example.com", v1alpha2.ParentReference{
 				Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 				Name:        "some-gateway", // wrong gateway
 				SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-1")),
 			}),
-			gw:         gw,
-			ignoredGws: nil,
+			gw: gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
@@ -529,18 +1155,17 @@ func TestBindRouteToListeners(t *testing.T) {
 			msg: "HTTPRoute without good parent refs",
 		},
 		{
-			httpRoute:  hrNonExistingSectionName,
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: hrNonExistingSectionName,
+			gw:        gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
 			expectedIgnored: false,
 			expectedRoute: &route{
 				Source:               hrNonExistingSectionName,
-				ValidSectionNameRefs: map[string]struct{}{},
-				InvalidSectionNameRefs: map[string]struct{}{
-					"listener-80-2": {},
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-2"}: {},
 				},
 			},
 			expectedListeners: map[string]*listener{
@@ -549,43 +1174,126 @@ func TestBindRouteToListeners(t *testing.T) {
 			msg: "HTTPRoute with non-existing section name",
 		},
 		{
-			httpRoute:  hrEmptySectionName,
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: hrFoo,
+			gw:        gw,
 			listeners: map[string]*listener{
-				"listener-80-1": createListener(),
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Source.AllowedRoutes = &v1alpha2.AllowedRoutes{
+						Namespaces: &v1alpha2.RouteNamespaces{
+							From: (*v1alpha2.FromNamespaces)(helpers.GetStringPointer(string(v1beta1.NamespacesFromSelector))),
+						},
+					}
+					l.Valid = false
+				}),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               hrFoo,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
 			},
-			expectedIgnored: true,
-			expectedRoute:   nil,
 			expectedListeners: map[string]*listener{
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Source.AllowedRoutes = &v1alpha2.AllowedRoutes{
+						Namespaces: &v1alpha2.RouteNamespaces{
+							From: (*v1alpha2.FromNamespaces)(helpers.GetStringPointer(string(v1beta1.NamespacesFromSelector))),
+						},
+					}
+					l.Valid = false
+				}),
+			},
+			msg: "HTTPRoute with section name of invalid listener",
+		},
+		{
+			httpRoute: hrEmptySectionName,
+			gw:        gw,
+			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source: hrEmptySectionName,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Routes = map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-1"}: {
+							Source: hrEmptySectionName,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName}: {},
+							},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+						},
+					}
+					l.AcceptedHostnames = map[string]struct{}{
+						"foo.example.com": {},
+					}
+				}),
+			},
 			msg: "HTTPRoute with empty section name",
 		},
 		{
-			httpRoute:  hrFoo,
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: hrOtherNamespace,
+			gw:        gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
 			expectedIgnored: false,
 			expectedRoute: &route{
-				Source: hrFoo,
-				ValidSectionNameRefs: map[string]struct{}{
-					"listener-80-1": {},
+				Source:               hrOtherNamespace,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
+				},
+				NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
+				},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			msg: "HTTPRoute in a namespace not allowed by the listener",
+		},
+		{
+			httpRoute: hrOtherNamespace,
+			gw:        gw,
+			listeners: map[string]*listener{
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Source.AllowedRoutes = &v1alpha2.AllowedRoutes{
+						Namespaces: &v1alpha2.RouteNamespaces{
+							From: (*v1alpha2.FromNamespaces)(helpers.GetStringPointer(string(v1beta1.NamespacesFromAll))),
+						},
+					}
+				}),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source: hrOtherNamespace,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
 				},
-				InvalidSectionNameRefs: map[string]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 			},
 			expectedListeners: map[string]*listener{
 				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Source.AllowedRoutes = &v1alpha2.AllowedRoutes{
+						Namespaces: &v1alpha2.RouteNamespaces{
+							From: (*v1alpha2.FromNamespaces)(helpers.GetStringPointer(string(v1beta1.NamespacesFromAll))),
+						},
+					}
 					l.Routes = map[types.NamespacedName]*route{
-						{Namespace: "test", Name: "hr-1"}: {
-							Source: hrFoo,
-							ValidSectionNameRefs: map[string]struct{}{
-								"listener-80-1": {},
+						{Namespace: "other", Name: "hr-1"}: {
+							Source: hrOtherNamespace,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName}: {},
 							},
-							InvalidSectionNameRefs: map[string]struct{}{},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 						},
 					}
 					l.AcceptedHostnames = map[string]struct{}{
@@ -593,185 +1301,2094 @@ func TestBindRouteToListeners(t *testing.T) {
 					}
 				}),
 			},
-			msg: "HTTPRoute with one accepted hostname",
+			msg: "HTTPRoute in a namespace allowed by the listener",
 		},
 		{
-			httpRoute:  hrFooImplicitNamespace,
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: hrEmptySectionName,
+			gw:        gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
+				"listener-80-2": createModifiedListener(func(l *listener) {
+					l.Source.Hostname = (*v1alpha2.Hostname)(helpers.GetStringPointer("*.example.com"))
+				}),
+				"listener-80-3": createModifiedListener(func(l *listener) {
+					l.Source.Hostname = (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com"))
+				}),
+				"listener-80-4": createModifiedListener(func(l *listener) {
+					l.Valid = false
+				}),
 			},
 			expectedIgnored: false,
 			expectedRoute: &route{
-				Source: hrFooImplicitNamespace,
-				ValidSectionNameRefs: map[string]struct{}{
-					"listener-80-1": {},
+				Source: hrEmptySectionName,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
 				},
-				InvalidSectionNameRefs: map[string]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 			},
 			expectedListeners: map[string]*listener{
 				"listener-80-1": createModifiedListener(func(l *listener) {
 					l.Routes = map[types.NamespacedName]*route{
 						{Namespace: "test", Name: "hr-1"}: {
-							Source: hrFooImplicitNamespace,
-							ValidSectionNameRefs: map[string]struct{}{
-								"listener-80-1": {},
+							Source: hrEmptySectionName,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName}: {},
+							},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+						},
+					}
+					l.AcceptedHostnames = map[string]struct{}{
+						"foo.example.com": {},
+					}
+				}),
+				"listener-80-2": createModifiedListener(func(l *listener) {
+					l.Source.Hostname = (*v1alpha2.Hostname)(helpers.GetStringPointer("*.example.com"))
+					l.Routes = map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-1"}: {
+							Source: hrEmptySectionName,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName}: {},
 							},
-							InvalidSectionNameRefs: map[string]struct{}{},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
 						},
 					}
 					l.AcceptedHostnames = map[string]struct{}{
 						"foo.example.com": {},
 					}
 				}),
+				"listener-80-3": createModifiedListener(func(l *listener) {
+					l.Source.Hostname = (*v1alpha2.Hostname)(helpers.GetStringPointer("bar.example.com"))
+				}),
+				"listener-80-4": createModifiedListener(func(l *listener) {
+					l.Valid = false
+				}),
 			},
-			msg: "HTTPRoute with one accepted hostname with implicit namespace in parentRef",
+			msg: "HTTPRoute with empty section name attaches to all matching valid listeners",
 		},
 		{
-			httpRoute:  hrBar,
-			gw:         gw,
-			ignoredGws: nil,
+			httpRoute: hrBarEmptySectionName,
+			gw:        gw,
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
 			expectedIgnored: false,
 			expectedRoute: &route{
-				Source:               hrBar,
-				ValidSectionNameRefs: map[string]struct{}{},
-				InvalidSectionNameRefs: map[string]struct{}{
-					"listener-80-1": {},
+				Source:               hrBarEmptySectionName,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName}: {},
 				},
 			},
 			expectedListeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
-			msg: "HTTPRoute with zero accepted hostnames",
+			msg: "HTTPRoute with empty section name and zero accepted hostnames",
 		},
 		{
-			httpRoute: hrIgnoredGateway,
+			httpRoute: hrDuplicateParentRefs,
 			gw:        gw,
-			ignoredGws: map[types.NamespacedName]*v1alpha2.Gateway{
-				{Namespace: "test", Name: "ignored-gateway"}: {},
+			otherGateways: map[types.NamespacedName]*gateway{
+				gw2NsName: {Source: gw2},
 			},
 			listeners: map[string]*listener{
 				"listener-80-1": createListener(),
 			},
 			expectedIgnored: false,
 			expectedRoute: &route{
-				Source:               hrIgnoredGateway,
-				ValidSectionNameRefs: map[string]struct{}{},
-				InvalidSectionNameRefs: map[string]struct{}{
-					"listener-80-1": {},
+				Source: hrDuplicateParentRefs,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gw2NsName, SectionName: "listener-80-1"}: {},
 				},
 			},
 			expectedListeners: map[string]*listener{
-				"listener-80-1": createListener(),
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Routes = map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-1"}: {
+							Source: hrDuplicateParentRefs,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+							},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gw2NsName, SectionName: "listener-80-1"}: {},
+							},
+						},
+					}
+					l.AcceptedHostnames = map[string]struct{}{
+						"foo.example.com": {},
+					}
+				}),
 			},
-			msg: "HTTPRoute with ignored gateway reference",
+			msg: "HTTPRoute with duplicated parent refs",
 		},
 		{
-			httpRoute:         hrFoo,
-			gw:                nil,
-			ignoredGws:        nil,
-			listeners:         nil,
-			expectedIgnored:   true,
-			expectedRoute:     nil,
-			expectedListeners: nil,
-			msg:               "HTTPRoute when no gateway exists",
+			httpRoute: hrFoo,
+			gw:        gw,
+			listeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source: hrFoo,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Routes = map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-1"}: {
+							Source: hrFoo,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+							},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+						},
+					}
+					l.AcceptedHostnames = map[string]struct{}{
+						"foo.example.com": {},
+					}
+				}),
+			},
+			msg: "HTTPRoute with one accepted hostname",
 		},
-	}
-
-	for _, test := range tests {
-		ignored, route := bindHTTPRouteToListeners(test.httpRoute, test.gw, test.ignoredGws, test.listeners)
-		if diff := cmp.Diff(test.expectedIgnored, ignored); diff != "" {
-			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on ignored (-want +got):\n%s", test.msg, diff)
-		}
-		if diff := cmp.Diff(test.expectedRoute, route); diff != "" {
-			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on route (-want +got):\n%s", test.msg, diff)
-		}
-		if diff := cmp.Diff(test.expectedListeners, test.listeners); diff != "" {
-			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on listeners (-want +got):\n%s", test.msg, diff)
-		}
+		{
+			httpRoute: hrFooImplicitNamespace,
+			gw:        gw,
+			listeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source: hrFooImplicitNamespace,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createModifiedListener(func(l *listener) {
+					l.Routes = map[types.NamespacedName]*route{
+						{Namespace: "test", Name: "hr-1"}: {
+							Source: hrFooImplicitNamespace,
+							ValidSectionNameRefs: map[ParentRefKey]struct{}{
+								{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+							},
+							InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+						},
+					}
+					l.AcceptedHostnames = map[string]struct{}{
+						"foo.example.com": {},
+					}
+				}),
+			},
+			msg: "HTTPRoute with one accepted hostname with implicit namespace in parentRef",
+		},
+		{
+			httpRoute: hrBar,
+			gw:        gw,
+			listeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               hrBar,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			msg: "HTTPRoute with zero accepted hostnames",
+		},
+		{
+			httpRoute: hrGateway2,
+			gw:        gw,
+			otherGateways: map[types.NamespacedName]*gateway{
+				gw2NsName: {Source: gw2},
+			},
+			listeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               hrGateway2,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gw2NsName, SectionName: "listener-80-1"}: {},
+				},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			msg: "HTTPRoute with a reference to a non-existing listener of another gateway",
+		},
+		{
+			httpRoute: hrInvalidRegex,
+			gw:        gw,
+			listeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               hrInvalidRegex,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
+				},
+				Conditions: []Condition{
+					newRouteUnsupportedValueCondition(
+						"spec.rules[0].matches[0].path.value: unsupported regular expression \"/foo(bar\": " +
+							"error parsing regexp: missing closing ): `/foo(bar`",
+					),
+				},
+			},
+			expectedListeners: map[string]*listener{
+				"listener-80-1": createListener(),
+			},
+			msg: "HTTPRoute with invalid regex path",
+		},
+		{
+			httpRoute:         hrFoo,
+			gw:                nil,
+			listeners:         nil,
+			expectedIgnored:   true,
+			expectedRoute:     nil,
+			expectedListeners: nil,
+			msg:               "HTTPRoute when no gateway exists",
+		},
+	}
+
+	for _, test := range tests {
+		gateways := make(map[types.NamespacedName]*gateway)
+		for nsname, g := range test.otherGateways {
+			gateways[nsname] = g
+		}
+		if test.gw != nil {
+			gateways[getNamespacedName(test.gw)] = &gateway{Source: test.gw, Listeners: test.listeners}
+		}
+
+		ignored, route := bindHTTPRouteToListeners(test.httpRoute, gateways, nil)
+		if diff := cmp.Diff(test.expectedIgnored, ignored); diff != "" {
+			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on ignored (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedRoute, route); diff != "" {
+			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on route (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedListeners, test.listeners); diff != "" {
+			t.Errorf("bindHTTPRouteToListeners() %q  mismatch on listeners (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBindTLSRouteToListeners(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	createTLSRoute := func(name string, hostname string, sectionName string) *v1alpha2.TLSRoute {
+		tr := &v1alpha2.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      name,
+			},
+			Spec: v1alpha2.TLSRouteSpec{
+				CommonRouteSpec: v1alpha2.CommonRouteSpec{
+					ParentRefs: []v1alpha2.ParentReference{
+						{
+							Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+							Name:        "gateway",
+							SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer(sectionName)),
+						},
+					},
+				},
+				Rules: []v1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []v1alpha2.BackendRef{
+							{
+								BackendObjectReference: v1alpha2.BackendObjectReference{
+									Name: "backend",
+									Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(443)),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		if hostname != "" {
+			tr.Spec.Hostnames = []v1alpha2.Hostname{v1alpha2.Hostname(hostname)}
+		}
+
+		return tr
+	}
+
+	trFoo := createTLSRoute("tr-foo", "foo.example.com", "listener-443-tls")
+	trAny := createTLSRoute("tr-any", "", "listener-443-tls")
+	trBar := createTLSRoute("tr-bar", "bar.example.com", "listener-443-tls")
+	trHTTPS := createTLSRoute("tr-https", "foo.example.com", "listener-443-https")
+	trInvalid := createTLSRoute("tr-invalid", "foo.example.com", "listener-443-tls")
+	trInvalid.Spec.Rules = append(trInvalid.Spec.Rules, trInvalid.Spec.Rules[0])
+
+	// we create new listeners each time because the function under test can modify them
+	createListeners := func() map[string]*listener {
+		return map[string]*listener{
+			"listener-443-tls": {
+				Source: v1alpha2.Listener{
+					Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+					Protocol: v1beta1.TLSProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+			"listener-443-https": {
+				Source: v1alpha2.Listener{
+					Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+					Protocol: v1beta1.HTTPSProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+		}
+	}
+
+	createModifiedListeners := func(m func(map[string]*listener)) map[string]*listener {
+		l := createListeners()
+		m(l)
+		return l
+	}
+
+	gw := &v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "gateway",
+		},
+	}
+
+	tests := []struct {
+		tlsRoute          *v1alpha2.TLSRoute
+		expectedIgnored   bool
+		expectedRoute     *tlsRoute
+		expectedListeners map[string]*listener
+		msg               string
+	}{
+		{
+			tlsRoute:        trFoo,
+			expectedIgnored: false,
+			expectedRoute: &tlsRoute{
+				Source: trFoo,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: createModifiedListeners(func(listeners map[string]*listener) {
+				l := listeners["listener-443-tls"]
+				l.TLSRoutes = map[types.NamespacedName]*tlsRoute{
+					{Namespace: "test", Name: "tr-foo"}: {
+						Source: trFoo,
+						ValidSectionNameRefs: map[ParentRefKey]struct{}{
+							{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+						},
+						InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+					},
+				}
+				l.AcceptedHostnames = map[string]struct{}{
+					"foo.example.com": {},
+				}
+			}),
+			msg: "TLSRoute with a matching hostname",
+		},
+		{
+			tlsRoute:        trAny,
+			expectedIgnored: false,
+			expectedRoute: &tlsRoute{
+				Source: trAny,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: createModifiedListeners(func(listeners map[string]*listener) {
+				l := listeners["listener-443-tls"]
+				l.TLSRoutes = map[types.NamespacedName]*tlsRoute{
+					{Namespace: "test", Name: "tr-any"}: {
+						Source: trAny,
+						ValidSectionNameRefs: map[ParentRefKey]struct{}{
+							{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+						},
+						InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+					},
+				}
+				l.AcceptedHostnames = map[string]struct{}{
+					"foo.example.com": {},
+				}
+			}),
+			msg: "TLSRoute without hostnames",
+		},
+		{
+			tlsRoute:        trBar,
+			expectedIgnored: false,
+			expectedRoute: &tlsRoute{
+				Source:               trBar,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "TLSRoute with a non-matching hostname",
+		},
+		{
+			tlsRoute:        trHTTPS,
+			expectedIgnored: false,
+			expectedRoute: &tlsRoute{
+				Source:               trHTTPS,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+				},
+				NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "TLSRoute referencing an HTTPS listener",
+		},
+		{
+			tlsRoute:        trInvalid,
+			expectedIgnored: false,
+			expectedRoute: &tlsRoute{
+				Source:               trInvalid,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+				},
+				Conditions: []Condition{
+					newRouteUnsupportedValueCondition("spec.rules: exactly one rule is supported, got 2"),
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "invalid TLSRoute",
+		},
+	}
+
+	for _, test := range tests {
+		listeners := createListeners()
+
+		gateways := map[types.NamespacedName]*gateway{
+			getNamespacedName(gw): {Source: gw, Listeners: listeners},
+		}
+
+		ignored, route := bindTLSRouteToListeners(test.tlsRoute, gateways, nil)
+		if diff := cmp.Diff(test.expectedIgnored, ignored); diff != "" {
+			t.Errorf("bindTLSRouteToListeners() %q mismatch on ignored (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedRoute, route); diff != "" {
+			t.Errorf("bindTLSRouteToListeners() %q mismatch on route (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedListeners, listeners); diff != "" {
+			t.Errorf("bindTLSRouteToListeners() %q mismatch on listeners (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBindGRPCRouteToListeners(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	createGRPCRoute := func(name string, sectionName string) *v1alpha2.GRPCRoute {
+		return &v1alpha2.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      name,
+			},
+			Spec: v1alpha2.GRPCRouteSpec{
+				CommonRouteSpec: v1alpha2.CommonRouteSpec{
+					ParentRefs: []v1alpha2.ParentReference{
+						{
+							Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+							Name:        "gateway",
+							SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer(sectionName)),
+						},
+					},
+				},
+				Hostnames: []v1alpha2.Hostname{"foo.example.com"},
+				Rules: []v1alpha2.GRPCRouteRule{
+					{
+						Matches: []v1alpha2.GRPCRouteMatch{
+							{
+								Method: &v1alpha2.GRPCMethodMatch{
+									Service: helpers.GetStringPointer("helloworld.Greeter"),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	grHTTPS := createGRPCRoute("gr-https", "listener-443-https")
+	grHTTP := createGRPCRoute("gr-http", "listener-80-http")
+	grInvalid := createGRPCRoute("gr-invalid", "listener-443-https")
+	grInvalid.Spec.Rules[0].Matches[0].Method.Service = helpers.GetStringPointer("helloworld/Greeter")
+
+	// we create new listeners each time because the function under test can modify them
+	createListeners := func() map[string]*listener {
+		return map[string]*listener{
+			"listener-443-https": {
+				Source: v1alpha2.Listener{
+					Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+					Protocol: v1beta1.HTTPSProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+			"listener-80-http": {
+				Source: v1alpha2.Listener{
+					Hostname: (*v1alpha2.Hostname)(helpers.GetStringPointer("foo.example.com")),
+					Protocol: v1beta1.HTTPProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+		}
+	}
+
+	createModifiedListeners := func(m func(map[string]*listener)) map[string]*listener {
+		l := createListeners()
+		m(l)
+		return l
+	}
+
+	gw := &v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "gateway",
+		},
+	}
+
+	tests := []struct {
+		grpcRoute         *v1alpha2.GRPCRoute
+		expectedIgnored   bool
+		expectedRoute     *route
+		expectedListeners map[string]*listener
+		msg               string
+	}{
+		{
+			grpcRoute:       grHTTPS,
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source: convertGRPCRoute(grHTTPS),
+				GRPC:   true,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+				},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			},
+			expectedListeners: createModifiedListeners(func(listeners map[string]*listener) {
+				l := listeners["listener-443-https"]
+				l.GRPCRoutes = map[types.NamespacedName]*route{
+					{Namespace: "test", Name: "gr-https"}: {
+						Source: convertGRPCRoute(grHTTPS),
+						GRPC:   true,
+						ValidSectionNameRefs: map[ParentRefKey]struct{}{
+							{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+						},
+						InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+					},
+				}
+				l.AcceptedHostnames = map[string]struct{}{
+					"foo.example.com": {},
+				}
+			}),
+			msg: "GRPCRoute referencing an HTTPS listener",
+		},
+		{
+			grpcRoute:       grHTTP,
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               convertGRPCRoute(grHTTP),
+				GRPC:                 true,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-http"}: {},
+				},
+				NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-80-http"}: {},
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "GRPCRoute referencing an HTTP listener",
+		},
+		{
+			grpcRoute:       grInvalid,
+			expectedIgnored: false,
+			expectedRoute: &route{
+				Source:               convertGRPCRoute(grInvalid),
+				GRPC:                 true,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+				},
+				Conditions: []Condition{
+					newRouteUnsupportedValueCondition(`spec.rules[0].matches[0].method.service: invalid service ` +
+						`"helloworld/Greeter", it must be a valid Protobuf type name`),
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "invalid GRPCRoute",
+		},
+	}
+
+	for _, test := range tests {
+		listeners := createListeners()
+
+		gateways := map[types.NamespacedName]*gateway{
+			getNamespacedName(gw): {Source: gw, Listeners: listeners},
+		}
+
+		ignored, route := bindGRPCRouteToListeners(test.grpcRoute, gateways, nil)
+		if diff := cmp.Diff(test.expectedIgnored, ignored); diff != "" {
+			t.Errorf("bindGRPCRouteToListeners() %q mismatch on ignored (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedRoute, route); diff != "" {
+			t.Errorf("bindGRPCRouteToListeners() %q mismatch on route (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedListeners, listeners); diff != "" {
+			t.Errorf("bindGRPCRouteToListeners() %q mismatch on listeners (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestBindL4RouteToListeners(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	backendRef := v1alpha2.BackendRef{
+		BackendObjectReference: v1alpha2.BackendObjectReference{
+			Name: "backend",
+			Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(53)),
+		},
+	}
+
+	createParentRefs := func(sectionName string) []v1alpha2.ParentReference {
+		ref := v1alpha2.ParentReference{
+			Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+			Name:      "gateway",
+		}
+		if sectionName != "" {
+			ref.SectionName = (*v1alpha2.SectionName)(helpers.GetStringPointer(sectionName))
+		}
+
+		return []v1alpha2.ParentReference{ref}
+	}
+
+	createTCPRoute := func(name string, sectionName string) *v1alpha2.TCPRoute {
+		return &v1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      name,
+			},
+			Spec: v1alpha2.TCPRouteSpec{
+				CommonRouteSpec: v1alpha2.CommonRouteSpec{
+					ParentRefs: createParentRefs(sectionName),
+				},
+				Rules: []v1alpha2.TCPRouteRule{
+					{BackendRefs: []v1alpha2.BackendRef{backendRef}},
+				},
+			},
+		}
+	}
+
+	tcpRoute := createTCPRoute("tcp", "listener-53-tcp")
+	tcpRouteUDPListener := createTCPRoute("tcp-udp-listener", "listener-53-udp")
+	tcpRouteInvalid := createTCPRoute("tcp-invalid", "listener-53-tcp")
+	tcpRouteInvalid.Spec.Rules[0].BackendRefs = append(tcpRouteInvalid.Spec.Rules[0].BackendRefs, backendRef)
+	tcpRouteNoRefs := createTCPRoute("tcp-no-refs", "")
+	tcpRouteNoRefs.Spec.ParentRefs = nil
+
+	udpRoute := &v1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "udp",
+		},
+		Spec: v1alpha2.UDPRouteSpec{
+			CommonRouteSpec: v1alpha2.CommonRouteSpec{
+				ParentRefs: createParentRefs(""),
+			},
+			Rules: []v1alpha2.UDPRouteRule{
+				{BackendRefs: []v1alpha2.BackendRef{backendRef}},
+			},
+		},
+	}
+
+	// we create new listeners each time because the function under test can modify them
+	createListeners := func() map[string]*listener {
+		return map[string]*listener{
+			"listener-53-tcp": {
+				Source: v1alpha2.Listener{
+					Port:     53,
+					Protocol: v1beta1.TCPProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+			"listener-53-udp": {
+				Source: v1alpha2.Listener{
+					Port:     53,
+					Protocol: v1beta1.UDPProtocolType,
+				},
+				Valid:             true,
+				Routes:            map[types.NamespacedName]*route{},
+				GRPCRoutes:        map[types.NamespacedName]*route{},
+				TLSRoutes:         map[types.NamespacedName]*tlsRoute{},
+				L4Routes:          map[types.NamespacedName]*l4Route{},
+				AcceptedHostnames: map[string]struct{}{},
+			},
+		}
+	}
+
+	createModifiedListeners := func(m func(map[string]*listener)) map[string]*listener {
+		l := createListeners()
+		m(l)
+		return l
+	}
+
+	gw := &v1alpha2.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "gateway",
+		},
+	}
+
+	expectedTCPRoute := &l4Route{
+		Source:     tcpRoute,
+		BackendRef: backendRef,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	expectedUDPRoute := &l4Route{
+		Source:     udpRoute,
+		BackendRef: backendRef,
+		ValidSectionNameRefs: map[ParentRefKey]struct{}{
+			{Gateway: gwNsName}: {},
+		},
+		InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+	}
+
+	tests := []struct {
+		route             client.Object
+		expectedIgnored   bool
+		expectedRoute     *l4Route
+		expectedListeners map[string]*listener
+		msg               string
+	}{
+		{
+			route:           tcpRoute,
+			expectedIgnored: false,
+			expectedRoute:   expectedTCPRoute,
+			expectedListeners: createModifiedListeners(func(listeners map[string]*listener) {
+				listeners["listener-53-tcp"].L4Routes = map[types.NamespacedName]*l4Route{
+					{Namespace: "test", Name: "tcp"}: expectedTCPRoute,
+				}
+			}),
+			msg: "TCPRoute referencing a TCP listener",
+		},
+		{
+			route:           udpRoute,
+			expectedIgnored: false,
+			expectedRoute:   expectedUDPRoute,
+			expectedListeners: createModifiedListeners(func(listeners map[string]*listener) {
+				listeners["listener-53-udp"].L4Routes = map[types.NamespacedName]*l4Route{
+					{Namespace: "test", Name: "udp"}: expectedUDPRoute,
+				}
+			}),
+			msg: "UDPRoute referencing the whole Gateway",
+		},
+		{
+			route:           tcpRouteUDPListener,
+			expectedIgnored: false,
+			expectedRoute: &l4Route{
+				Source:               tcpRouteUDPListener,
+				BackendRef:           backendRef,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-53-udp"}: {},
+				},
+				NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-53-udp"}: {},
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "TCPRoute referencing a UDP listener",
+		},
+		{
+			route:           tcpRouteInvalid,
+			expectedIgnored: false,
+			expectedRoute: &l4Route{
+				Source:               tcpRouteInvalid,
+				ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+				InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+					{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+				},
+				Conditions: []Condition{
+					newRouteUnsupportedValueCondition(
+						"spec.rules[0].backendRefs: exactly one backendRef is supported, got 2",
+					),
+				},
+			},
+			expectedListeners: createListeners(),
+			msg:               "invalid TCPRoute",
+		},
+		{
+			route:             tcpRouteNoRefs,
+			expectedIgnored:   true,
+			expectedRoute:     nil,
+			expectedListeners: createListeners(),
+			msg:               "TCPRoute without parentRefs",
+		},
+	}
+
+	for _, test := range tests {
+		listeners := createListeners()
+
+		gateways := map[types.NamespacedName]*gateway{
+			getNamespacedName(gw): {Source: gw, Listeners: listeners},
+		}
+
+		var (
+			ignored bool
+			route   *l4Route
+		)
+
+		switch r := test.route.(type) {
+		case *v1alpha2.TCPRoute:
+			ignored, route = bindTCPRouteToListeners(r, gateways, nil)
+		case *v1alpha2.UDPRoute:
+			ignored, route = bindUDPRouteToListeners(r, gateways, nil)
+		}
+
+		if diff := cmp.Diff(test.expectedIgnored, ignored); diff != "" {
+			t.Errorf("bindL4RouteToListeners() %q mismatch on ignored (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedRoute, route); diff != "" {
+			t.Errorf("bindL4RouteToListeners() %q mismatch on route (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedListeners, listeners); diff != "" {
+			t.Errorf("bindL4RouteToListeners() %q mismatch on listeners (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateTLSRoute(t *testing.T) {
+	backendRef := v1alpha2.BackendRef{
+		BackendObjectReference: v1alpha2.BackendObjectReference{
+			Name: "backend",
+		},
+	}
+
+	tests := []struct {
+		rules     []v1alpha2.TLSRouteRule
+		expectErr bool
+		msg       string
+	}{
+		{
+			rules: []v1alpha2.TLSRouteRule{
+				{BackendRefs: []v1alpha2.BackendRef{backendRef}},
+			},
+			expectErr: false,
+			msg:       "one rule with one backendRef",
+		},
+		{
+			rules:     nil,
+			expectErr: true,
+			msg:       "no rules",
+		},
+		{
+			rules: []v1alpha2.TLSRouteRule{
+				{BackendRefs: []v1alpha2.BackendRef{backendRef}},
+				{BackendRefs: []v1alpha2.BackendRef{backendRef}},
+			},
+			expectErr: true,
+			msg:       "two rules",
+		},
+		{
+			rules: []v1alpha2.TLSRouteRule{
+				{BackendRefs: []v1alpha2.BackendRef{backendRef, backendRef}},
+			},
+			expectErr: true,
+			msg:       "two backendRefs",
+		},
+	}
+
+	for _, test := range tests {
+		tr := &v1alpha2.TLSRoute{
+			Spec: v1alpha2.TLSRouteSpec{
+				Rules: test.rules,
+			},
+		}
+
+		err := validateTLSRoute(tr)
+		if test.expectErr && err == nil {
+			t.Errorf("validateTLSRoute() returned no error for the case of %q", test.msg)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("validateTLSRoute() returned unexpected error %v for the case of %q", err, test.msg)
+		}
+	}
+}
+
+func TestFindAcceptedTLSHostnames(t *testing.T) {
+	var listenerHostname v1alpha2.Hostname = "*.example.com"
+
+	tests := []struct {
+		listenerHostname *v1alpha2.Hostname
+		routeHostnames   []v1alpha2.Hostname
+		expected         []string
+		msg              string
+	}{
+		{
+			listenerHostname: &listenerHostname,
+			routeHostnames:   []v1alpha2.Hostname{"foo.example.com", "foo.other.com"},
+			expected:         []string{"foo.example.com"},
+			msg:              "route hostnames",
+		},
+		{
+			listenerHostname: &listenerHostname,
+			routeHostnames:   nil,
+			expected:         []string{"*.example.com"},
+			msg:              "no route hostnames",
+		},
+		{
+			listenerHostname: nil,
+			routeHostnames:   nil,
+			expected:         []string{""},
+			msg:              "no listener and route hostnames",
+		},
+	}
+
+	for _, test := range tests {
+		result := findAcceptedTLSHostnames(test.listenerHostname, test.routeHostnames)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("findAcceptedTLSHostnames() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestFindAcceptedHostnames(t *testing.T) {
+	var listenerHostnameFoo v1alpha2.Hostname = "foo.example.com"
+	var listenerHostnameCafe v1alpha2.Hostname = "cafe.example.com"
+	var listenerHostnameWildcard v1alpha2.Hostname = "*.example.com"
+	var listenerHostnameWildcardBar v1alpha2.Hostname = "*.bar.example.com"
+	routeHostnames := []v1alpha2.Hostname{"foo.example.com", "bar.example.com"}
+	routeHostnamesWildcard := []v1alpha2.Hostname{"*.example.com", "*.foo.example.com", "*.other.com"}
+
+	tests := []struct {
+		listenerHostname *v1alpha2.Hostname
+		routeHostnames   []v1alpha2.Hostname
+		expected         []string
+		msg              string
+	}{
+		{
+			listenerHostname: &listenerHostnameFoo,
+			routeHostnames:   routeHostnames,
+			expected:         []string{"foo.example.com"},
+			msg:              "one match",
+		},
+		{
+			listenerHostname: &listenerHostnameCafe,
+			routeHostnames:   routeHostnames,
+			expected:         nil,
+			msg:              "no match",
+		},
+		{
+			listenerHostname: nil,
+			routeHostnames:   routeHostnames,
+			expected:         []string{"foo.example.com", "bar.example.com"},
+			msg:              "nil listener hostname",
+		},
+		{
+			listenerHostname: &listenerHostnameWildcard,
+			routeHostnames:   append(routeHostnames, "example.com"),
+			expected:         []string{"foo.example.com", "bar.example.com"},
+			msg:              "wildcard listener hostname",
+		},
+		{
+			listenerHostname: &listenerHostnameFoo,
+			routeHostnames:   routeHostnamesWildcard,
+			expected:         []string{"foo.example.com"},
+			msg:              "wildcard route hostnames",
+		},
+		{
+			listenerHostname: &listenerHostnameWildcard,
+			routeHostnames:   routeHostnamesWildcard,
+			expected:         []string{"*.example.com", "*.foo.example.com"},
+			msg:              "wildcard listener and route hostnames",
+		},
+		{
+			listenerHostname: &listenerHostnameWildcardBar,
+			routeHostnames:   routeHostnamesWildcard,
+			expected:         []string{"*.bar.example.com"},
+			msg:              "wildcard listener hostname more specific than wildcard route hostname",
+		},
+		{
+			listenerHostname: nil,
+			routeHostnames:   routeHostnamesWildcard,
+			expected:         []string{"*.example.com", "*.foo.example.com", "*.other.com"},
+			msg:              "nil listener hostname and wildcard route hostnames",
+		},
+	}
+
+	for _, test := range tests {
+		result := findAcceptedHostnames(test.listenerHostname, test.routeHostnames)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("findAcceptedHostnames() %q  mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+
+}
+
+func TestMatchHostname(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		hostname string
+		expected bool
+	}{
+		{pattern: "foo.example.com", hostname: "foo.example.com", expected: true},
+		{pattern: "foo.example.com", hostname: "bar.example.com", expected: false},
+		{pattern: "foo.example.com", hostname: "*.example.com", expected: false},
+		{pattern: "*.example.com", hostname: "foo.example.com", expected: true},
+		{pattern: "*.example.com", hostname: "foo.bar.example.com", expected: true},
+		{pattern: "*.example.com", hostname: "*.bar.example.com", expected: true},
+		{pattern: "*.example.com", hostname: "*.example.com", expected: true},
+		{pattern: "*.example.com", hostname: "example.com", expected: false},
+		{pattern: "*.example.com", hostname: "fooexample.com", expected: false},
+		{pattern: "*.bar.example.com", hostname: "*.example.com", expected: false},
+	}
+
+	for _, test := range tests {
+		result := matchHostname(test.pattern, test.hostname)
+		if result != test.expected {
+			t.Errorf("matchHostname(%q, %q) returned %v but expected %v",
+				test.pattern, test.hostname, result, test.expected)
+		}
+	}
+}
+
+func TestIsMoreSpecificHostname(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "foo.example.com", b: "*.example.com", expected: true},
+		{a: "*.example.com", b: "foo.example.com", expected: false},
+		{a: "*.foo.example.com", b: "*.example.com", expected: true},
+		{a: "*.example.com", b: "*.foo.example.com", expected: false},
+		{a: "*.example.com", b: "", expected: true},
+		{a: "", b: "*.example.com", expected: false},
+		{a: "foo.example.com", b: "foo.example.com", expected: false},
+		{a: "", b: "", expected: false},
+	}
+
+	for _, test := range tests {
+		result := isMoreSpecificHostname(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("isMoreSpecificHostname(%q, %q) returned %v but expected %v", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestValidateListener(t *testing.T) {
+	validTLS := &v1alpha2.GatewayTLSConfig{
+		Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+		CertificateRefs: []v1alpha2.SecretObjectReference{
+			{
+				Kind: (*v1alpha2.Kind)(helpers.GetStringPointer("Secret")),
+				Name: "secret",
+			},
+		},
+	}
+
+	tests := []struct {
+		l             v1alpha2.Listener
+		expected      bool
+		expectedConds []Condition
+		msg           string
+	}{
+		{
+			l: v1alpha2.Listener{
+				Port:     80,
+				Protocol: v1beta1.HTTPProtocolType,
+			},
+			expected: true,
+			msg:      "valid http",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.HTTPSProtocolType,
+				TLS:      validTLS,
+			},
+			expected: true,
+			msg:      "valid https",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     8080,
+				Protocol: v1beta1.HTTPProtocolType,
+			},
+			expected: true,
+			msg:      "valid http on a non-default port",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     0,
+				Protocol: v1beta1.HTTPProtocolType,
+			},
+			expected: false,
+			expectedConds: []Condition{
+				newListenerPortUnavailableCondition("Port 0 is invalid, use a port between 1 and 65535"),
+			},
+			msg: "invalid port",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     80,
+				Protocol: "SCTP",
+			},
+			expected: false,
+			expectedConds: []Condition{
+				newListenerUnsupportedProtocolCondition(`Protocol "SCTP" is not supported, use "HTTP", "HTTPS", "TLS", "TCP" or "UDP"`),
+			},
+			msg: "invalid protocol",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     53,
+				Protocol: v1beta1.TCPProtocolType,
+			},
+			expected: true,
+			msg:      "valid tcp",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     53,
+				Protocol: v1beta1.UDPProtocolType,
+			},
+			expected: true,
+			msg:      "valid udp",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.HTTPSProtocolType,
+			},
+			expected: false,
+			expectedConds: []Condition{
+				newListenerInvalidCertificateRefCondition("TLS configuration is required for HTTPS listeners"),
+			},
+			msg: "https without tls",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
+				TLS: &v1alpha2.GatewayTLSConfig{
+					Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+				},
+			},
+			expected: true,
+			msg:      "valid tls passthrough",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
+			},
+			expected: false,
+			expectedConds: []Condition{
+				newListenerUnsupportedProtocolCondition("TLS configuration is required for TLS listeners"),
+			},
+			msg: "tls without tls configuration",
+		},
+		{
+			l: v1alpha2.Listener{
+				Port:     443,
+				Protocol: v1beta1.TLSProtocolType,
+				TLS: &v1alpha2.GatewayTLSConfig{
+					CertificateRefs: validTLS.CertificateRefs,
+				},
+			},
+			expected: false,
+			expectedConds: []Condition{
+				newListenerUnsupportedProtocolCondition(
+					`TLS mode "Terminate" is not supported for TLS listeners, use "Passthrough"`,
+				),
+			},
+			msg: "tls with the default terminate mode",
+		},
+	}
+
+	for _, test := range tests {
+		result, conds := validateListener(test.l)
+		if result != test.expected {
+			t.Errorf("validateListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedConds, conds); diff != "" {
+			t.Errorf("validateListener() %q conditions mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateListenerTLS(t *testing.T) {
+	tests := []struct {
+		tls         *v1alpha2.GatewayTLSConfig
+		expectedErr bool
+		msg         string
+	}{
+		{
+			tls: &v1alpha2.GatewayTLSConfig{
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{
+						Group: (*v1alpha2.Group)(helpers.GetStringPointer("core")),
+						Name:  "secret",
+					},
+				},
+			},
+			expectedErr: false,
+			msg:         "valid",
+		},
+		{
+			tls:         nil,
+			expectedErr: true,
+			msg:         "nil tls",
+		},
+		{
+			tls: &v1alpha2.GatewayTLSConfig{
+				Mode: helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{Name: "secret"},
+				},
+			},
+			expectedErr: true,
+			msg:         "passthrough mode",
+		},
+		{
+			tls:         &v1alpha2.GatewayTLSConfig{},
+			expectedErr: true,
+			msg:         "no certificateRefs",
+		},
+		{
+			tls: &v1alpha2.GatewayTLSConfig{
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{Name: "secret-1"},
+					{Name: "secret-2"},
+				},
+			},
+			expectedErr: true,
+			msg:         "multiple certificateRefs",
+		},
+		{
+			tls: &v1alpha2.GatewayTLSConfig{
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{
+						Kind: (*v1alpha2.Kind)(helpers.GetStringPointer("ConfigMap")),
+						Name: "secret",
+					},
+				},
+			},
+			expectedErr: true,
+			msg:         "invalid kind",
+		},
+		{
+			tls: &v1alpha2.GatewayTLSConfig{
+				CertificateRefs: []v1alpha2.SecretObjectReference{
+					{
+						Group: (*v1alpha2.Group)(helpers.GetStringPointer("some-group")),
+						Name:  "secret",
+					},
+				},
+			},
+			expectedErr: true,
+			msg:         "invalid group",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateListenerTLS(test.tls)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("validateListenerTLS() returned error %v but expected error %v for the case of %q",
+				err, test.expectedErr, test.msg)
+		}
+	}
+}
+
+func TestValidateHTTPRoute(t *testing.T) {
+	createRoute := func(paths ...v1alpha2.HTTPPathMatch) *v1alpha2.HTTPRoute {
+		hr := &v1alpha2.HTTPRoute{
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{{}},
+			},
+		}
+
+		for i := range paths {
+			hr.Spec.Rules[0].Matches = append(hr.Spec.Rules[0].Matches, v1alpha2.HTTPRouteMatch{Path: &paths[i]})
+		}
+
+		return hr
+	}
+
+	createRouteWithMatch := func(match v1alpha2.HTTPRouteMatch) *v1alpha2.HTTPRoute {
+		return &v1alpha2.HTTPRoute{
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{
+					{
+						Matches: []v1alpha2.HTTPRouteMatch{match},
+					},
+				},
+			},
+		}
+	}
+
+	createRegexPath := func(regex string) v1alpha2.HTTPPathMatch {
+		return v1alpha2.HTTPPathMatch{
+			Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchRegularExpression),
+			Value: helpers.GetStringPointer(regex),
+		}
+	}
+
+	createRouteWithFilter := func(filter v1alpha2.HTTPRouteFilter) *v1alpha2.HTTPRoute {
+		return &v1alpha2.HTTPRoute{
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{
+					{
+						Filters: []v1alpha2.HTTPRouteFilter{filter},
+					},
+				},
+			},
+		}
+	}
+
+	createRouteWithBackendFilter := func(filter v1alpha2.HTTPRouteFilter) *v1alpha2.HTTPRoute {
+		return &v1alpha2.HTTPRoute{
+			Spec: v1alpha2.HTTPRouteSpec{
+				Rules: []v1alpha2.HTTPRouteRule{
+					{
+						BackendRefs: []v1alpha2.HTTPBackendRef{
+							{
+								Filters: []v1alpha2.HTTPRouteFilter{filter},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		hr        *v1alpha2.HTTPRoute
+		expectErr bool
+		msg       string
+	}{
+		{
+			hr: createRoute(
+				v1alpha2.HTTPPathMatch{Value: helpers.GetStringPointer("/foo(bar")},
+				v1alpha2.HTTPPathMatch{
+					Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+					Value: helpers.GetStringPointer("/foo[bar"),
+				},
+			),
+			expectErr: false,
+			msg:       "prefix and exact paths are not validated as regexes",
+		},
+		{
+			hr:        createRoute(createRegexPath(`^/foo/[a-z]+\.(jpg|png)$`), createRegexPath(`/bar/\d{2,3}`)),
+			expectErr: false,
+			msg:       "valid regexes",
+		},
+		{
+			hr:        createRoute(createRegexPath(`/foo(bar`)),
+			expectErr: true,
+			msg:       "invalid regex",
+		},
+		{
+			hr:        createRoute(createRegexPath(`/foo(?=bar)`)),
+			expectErr: true,
+			msg:       "lookahead is not supported",
+		},
+		{
+			hr:        createRoute(createRegexPath(`/(foo)\1`)),
+			expectErr: true,
+			msg:       "backreference is not supported",
+		},
+		{
+			hr: createRoute(v1alpha2.HTTPPathMatch{
+				Type:  (*v1alpha2.PathMatchType)(helpers.GetStringPointer("Unknown")),
+				Value: helpers.GetStringPointer("/foo"),
+			}),
+			expectErr: true,
+			msg:       "unsupported path type",
+		},
+		{
+			hr: createRouteWithMatch(v1alpha2.HTTPRouteMatch{
+				Headers: []v1alpha2.HTTPHeaderMatch{
+					{
+						Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+						Name:  "header",
+						Value: "(value",
+					},
+					{
+						Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+						Name:  "header-regex",
+						Value: "^v[0-9]+$",
+					},
+				},
+				QueryParams: []v1alpha2.HTTPQueryParamMatch{
+					{
+						Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
+						Name:  "arg",
+						Value: "(value",
+					},
+					{
+						Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchRegularExpression),
+						Name:  "arg-regex",
+						Value: "^[a-z]+$",
+					},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid header and query param matches",
+		},
+		{
+			hr: createRouteWithMatch(v1alpha2.HTTPRouteMatch{
+				Headers: []v1alpha2.HTTPHeaderMatch{
+					{
+						Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+						Name:  "header",
+						Value: "(value",
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid header regex",
+		},
+		{
+			hr: createRouteWithMatch(v1alpha2.HTTPRouteMatch{
+				Headers: []v1alpha2.HTTPHeaderMatch{
+					{
+						Type:  (*v1alpha2.HeaderMatchType)(helpers.GetStringPointer("Unknown")),
+						Name:  "header",
+						Value: "value",
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "unsupported header match type",
+		},
+		{
+			hr: createRouteWithMatch(v1alpha2.HTTPRouteMatch{
+				QueryParams: []v1alpha2.HTTPQueryParamMatch{
+					{
+						Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchRegularExpression),
+						Name:  "arg",
+						Value: "(?i)value",
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid query param regex",
+		},
+		{
+			hr: createRouteWithMatch(v1alpha2.HTTPRouteMatch{
+				QueryParams: []v1alpha2.HTTPQueryParamMatch{
+					{
+						Type:  (*v1alpha2.QueryParamMatchType)(helpers.GetStringPointer("Unknown")),
+						Name:  "arg",
+						Value: "value",
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "unsupported query param match type",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value"}},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid request header modifier",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value; return 200"}},
+					Add: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "$request_uri"}},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid request header modifier",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &v1alpha2.HTTPRequestMirrorFilter{
+					BackendRef: v1alpha2.BackendObjectReference{Name: "staging"},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid request mirror",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterRequestMirror,
+			}),
+			expectErr: true,
+			msg:       "request mirror is not specified",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+					Path: &v1alpha2.HTTPPathModifier{
+						Type:               v1beta1.PrefixMatchHTTPPathModifier,
+						ReplacePrefixMatch: helpers.GetStringPointer("/bar"),
+					},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid url rewrite",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterURLRewrite,
+				URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+					Path: &v1alpha2.HTTPPathModifier{
+						Type:            v1beta1.FullPathHTTPPathModifier,
+						ReplaceFullPath: helpers.GetStringPointer("bar"),
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid url rewrite",
+		},
+		{
+			hr: createRouteWithFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Set: []v1alpha2.HTTPHeader{{Name: "Server", Value: "value"}},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid response header modifier",
+		},
+		{
+			hr: createRouteWithBackendFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Add: []v1alpha2.HTTPHeader{{Name: "X-Backend", Value: "one"}},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid backend response header modifier",
+		},
+		{
+			hr: createRouteWithBackendFilter(v1alpha2.HTTPRouteFilter{
+				Type: v1beta1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Add: []v1alpha2.HTTPHeader{{Name: "X-Backend", Value: "$upstream_addr"}},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid backend response header modifier",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateHTTPRoute(test.hr)
+		if test.expectErr && err == nil {
+			t.Errorf("validateHTTPRoute() returned no error for the case of %q", test.msg)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("validateHTTPRoute() returned unexpected error %v for the case of %q", err, test.msg)
+		}
 	}
 }
 
-func TestFindAcceptedHostnames(t *testing.T) {
-	var listenerHostnameFoo v1alpha2.Hostname = "foo.example.com"
-	var listenerHostnameCafe v1alpha2.Hostname = "cafe.example.com"
-	routeHostnames := []v1alpha2.Hostname{"foo.example.com", "bar.example.com"}
+func TestValidateRequestHeaderModifier(t *testing.T) {
+	const field = "spec.rules[0].filters[0].requestHeaderModifier"
 
 	tests := []struct {
-		listenerHostname *v1alpha2.Hostname
-		routeHostnames   []v1alpha2.Hostname
-		expected         []string
-		msg              string
+		modifier *v1alpha2.HTTPHeaderFilter
+		expected []string
+		msg      string
 	}{
 		{
-			listenerHostname: &listenerHostnameFoo,
-			routeHostnames:   routeHostnames,
-			expected:         []string{"foo.example.com"},
-			msg:              "one match",
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set: []v1alpha2.HTTPHeader{
+					{Name: "My-Header", Value: "my value\twith \"quotes\" and \\"},
+					{Name: "Host", Value: "example.com"},
+				},
+				Add:    []v1alpha2.HTTPHeader{{Name: "x-forwarded-for", Value: "10.0.0.1"}},
+				Remove: []string{"User-Agent"},
+			},
+			expected: nil,
+			msg:      "valid modifier",
 		},
 		{
-			listenerHostname: &listenerHostnameCafe,
-			routeHostnames:   routeHostnames,
-			expected:         nil,
-			msg:              "no match",
+			modifier: nil,
+			expected: []string{
+				field + ": must be specified for the RequestHeaderModifier filter",
+			},
+			msg: "no modifier",
 		},
 		{
-			listenerHostname: nil,
-			routeHostnames:   routeHostnames,
-			expected:         []string{"foo.example.com", "bar.example.com"},
-			msg:              "nil listener hostname",
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set:    []v1alpha2.HTTPHeader{{Name: "My_Header", Value: "value"}},
+				Add:    []v1alpha2.HTTPHeader{{Name: "My Header", Value: "value"}},
+				Remove: []string{"My-Header;"},
+			},
+			expected: []string{
+				field + `.set[0].name: invalid header name "My_Header", it must consist of letters, digits and '-'`,
+				field + `.add[0].name: invalid header name "My Header", it must consist of letters, digits and '-'`,
+				field + `.remove[0]: invalid header name "My-Header;", it must consist of letters, digits and '-'`,
+			},
+			msg: "invalid names",
+		},
+		{
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "$request_uri"}},
+				Add: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value\r\nX-Other: value"}},
+			},
+			expected: []string{
+				field + `.set[0].value: invalid header value "$request_uri", it must not include control characters and '$'`,
+				field + `.add[0].value: invalid header value "value\r\nX-Other: value", it must not include control ` +
+					`characters and '$'`,
+			},
+			msg: "invalid values",
+		},
+		{
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set:    []v1alpha2.HTTPHeader{{Name: "connection", Value: "close"}},
+				Add:    []v1alpha2.HTTPHeader{{Name: "host", Value: "example.com"}},
+				Remove: []string{"Host"},
+			},
+			expected: []string{
+				field + `.set[0].name: header "connection" cannot be modified`,
+				field + `.add[0].name: header "host" can only be set`,
+				field + `.remove[0]: header "Host" can only be set`,
+			},
+			msg: "reserved headers",
 		},
 	}
 
 	for _, test := range tests {
-		result := findAcceptedHostnames(test.listenerHostname, test.routeHostnames)
+		result := validateRequestHeaderModifier(test.modifier, field)
 		if diff := cmp.Diff(test.expected, result); diff != "" {
-			t.Errorf("findAcceptedHostnames() %q  mismatch (-want +got):\n%s", test.msg, diff)
+			t.Errorf("validateRequestHeaderModifier() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateResponseHeaderModifier(t *testing.T) {
+	const field = "spec.rules[0].filters[0].responseHeaderModifier"
+
+	tests := []struct {
+		modifier *v1alpha2.HTTPHeaderFilter
+		expected []string
+		msg      string
+	}{
+		{
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set:    []v1alpha2.HTTPHeader{{Name: "Cache-Control", Value: "no-cache, \"quoted\""}},
+				Add:    []v1alpha2.HTTPHeader{{Name: "Set-Cookie", Value: "id=1"}},
+				Remove: []string{"X-Powered-By", "Server"},
+			},
+			expected: nil,
+			msg:      "valid modifier",
+		},
+		{
+			modifier: nil,
+			expected: []string{
+				field + ": must be specified for the ResponseHeaderModifier filter",
+			},
+			msg: "no modifier",
+		},
+		{
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set:    []v1alpha2.HTTPHeader{{Name: "My_Header", Value: "$upstream_addr"}},
+				Add:    []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value\r\nX-Other: value"}},
+				Remove: []string{"My Header"},
+			},
+			expected: []string{
+				field + `.set[0].name: invalid header name "My_Header", it must consist of letters, digits and '-'`,
+				field + `.set[0].value: invalid header value "$upstream_addr", it must not include control ` +
+					`characters and '$'`,
+				field + `.add[0].value: invalid header value "value\r\nX-Other: value", it must not include control ` +
+					`characters and '$'`,
+				field + `.remove[0]: invalid header name "My Header", it must consist of letters, digits and '-'`,
+			},
+			msg: "invalid names and values",
+		},
+		{
+			modifier: &v1alpha2.HTTPHeaderFilter{
+				Set:    []v1alpha2.HTTPHeader{{Name: "server", Value: "my-server"}},
+				Add:    []v1alpha2.HTTPHeader{{Name: "Date", Value: "today"}, {Name: "Server", Value: "my-server"}},
+				Remove: []string{"Content-Length", "Transfer-Encoding", "Connection"},
+			},
+			expected: []string{
+				field + `.set[0].name: header "server" can only be removed`,
+				field + `.add[0].name: header "Date" cannot be modified`,
+				field + `.add[1].name: header "Server" can only be removed`,
+				field + `.remove[0]: header "Content-Length" cannot be modified`,
+				field + `.remove[1]: header "Transfer-Encoding" cannot be modified`,
+				field + `.remove[2]: header "Connection" cannot be modified`,
+			},
+			msg: "reserved headers",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateResponseHeaderModifier(test.modifier, field)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("validateResponseHeaderModifier() %q mismatch (-want +got):\n%s", test.msg, diff)
 		}
 	}
+}
+
+func TestValidateRequestRedirect(t *testing.T) {
+	const field = "spec.rules[0].filters[0].requestRedirect"
+
+	tests := []struct {
+		redirect *v1alpha2.HTTPRequestRedirectFilter
+		expected []string
+		msg      string
+	}{
+		{
+			redirect: &v1alpha2.HTTPRequestRedirectFilter{
+				Scheme:     helpers.GetStringPointer("https"),
+				Hostname:   (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("example.com")),
+				Port:       (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(8443)),
+				StatusCode: helpers.GetIntPointer(301),
+			},
+			expected: nil,
+			msg:      "valid redirect",
+		},
+		{
+			redirect: &v1alpha2.HTTPRequestRedirectFilter{},
+			expected: nil,
+			msg:      "empty redirect",
+		},
+		{
+			redirect: nil,
+			expected: []string{
+				field + ": must be specified for the RequestRedirect filter",
+			},
+			msg: "no redirect",
+		},
+		{
+			redirect: &v1alpha2.HTTPRequestRedirectFilter{
+				Scheme:     helpers.GetStringPointer("ftp"),
+				Hostname:   (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("example.com\";")),
+				Port:       (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(0)),
+				StatusCode: helpers.GetIntPointer(307),
+			},
+			expected: []string{
+				field + `.scheme: unsupported scheme "ftp", use "http" or "https"`,
+				field + `.hostname: invalid hostname "example.com\";": ` +
+					strings.Join(validation.IsDNS1123Subdomain(`example.com";`), ", "),
+				field + ".port: invalid port 0, use a port between 1 and 65535",
+				field + ".statusCode: unsupported status code 307, use 301 or 302",
+			},
+			msg: "invalid fields",
+		},
+	}
 
+	for _, test := range tests {
+		result := validateRequestRedirect(test.redirect, field)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("validateRequestRedirect() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
 }
 
-func TestValidateListener(t *testing.T) {
+func TestValidateURLRewrite(t *testing.T) {
+	const field = "spec.rules[0].filters[0].urlRewrite"
+
 	tests := []struct {
-		l        v1alpha2.Listener
-		expected bool
+		rewrite  *v1alpha2.HTTPURLRewriteFilter
+		expected []string
 		msg      string
 	}{
 		{
-			l: v1alpha2.Listener{
-				Port:     80,
-				Protocol: v1alpha2.HTTPProtocolType,
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Hostname: (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("example.com")),
+				Path: &v1alpha2.HTTPPathModifier{
+					Type:            v1beta1.FullPathHTTPPathModifier,
+					ReplaceFullPath: helpers.GetStringPointer("/foo/bar-baz_1.0~%20"),
+				},
 			},
-			expected: true,
-			msg:      "valid",
+			expected: nil,
+			msg:      "valid full path rewrite",
 		},
 		{
-			l: v1alpha2.Listener{
-				Port:     81,
-				Protocol: v1alpha2.HTTPProtocolType,
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Path: &v1alpha2.HTTPPathModifier{
+					Type:               v1beta1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: helpers.GetStringPointer(""),
+				},
 			},
-			expected: false,
-			msg:      "invalid port",
+			expected: nil,
+			msg:      "empty prefix replacement",
 		},
 		{
-			l: v1alpha2.Listener{
-				Port:     80,
-				Protocol: v1alpha2.TCPProtocolType,
+			rewrite: nil,
+			expected: []string{
+				field + ": must be specified for the URLRewrite filter",
 			},
-			expected: false,
-			msg:      "invalid protocol",
+			msg: "no rewrite",
+		},
+		{
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Hostname: (*v1alpha2.PreciseHostname)(helpers.GetStringPointer("example.com\";")),
+				Path: &v1alpha2.HTTPPathModifier{
+					Type:            v1beta1.FullPathHTTPPathModifier,
+					ReplaceFullPath: helpers.GetStringPointer(""),
+				},
+			},
+			expected: []string{
+				field + `.hostname: invalid hostname "example.com\";": ` +
+					strings.Join(validation.IsDNS1123Subdomain(`example.com";`), ", "),
+				field + `.path.replaceFullPath: invalid path "", it must start with '/' and can only include ` +
+					`the characters allowed in the path of a URI, except for '$'`,
+			},
+			msg: "invalid hostname and empty full path",
+		},
+		{
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Path: &v1alpha2.HTTPPathModifier{
+					Type:               v1beta1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: helpers.GetStringPointer("/$request_uri"),
+				},
+			},
+			expected: []string{
+				field + `.path.replacePrefixMatch: invalid path "/$request_uri", it must start with '/' and can ` +
+					`only include the characters allowed in the path of a URI, except for '$'`,
+			},
+			msg: "invalid prefix replacement",
+		},
+		{
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Path: &v1alpha2.HTTPPathModifier{
+					Type:               v1beta1.FullPathHTTPPathModifier,
+					ReplacePrefixMatch: helpers.GetStringPointer("/foo"),
+				},
+			},
+			expected: []string{
+				field + `.path.replaceFullPath: must be specified for the path type "ReplaceFullPath"`,
+			},
+			msg: "path of another type",
+		},
+		{
+			rewrite: &v1alpha2.HTTPURLRewriteFilter{
+				Path: &v1alpha2.HTTPPathModifier{
+					Type: "Unknown",
+				},
+			},
+			expected: []string{
+				field + `.path.type: unsupported type "Unknown"`,
+			},
+			msg: "unsupported path type",
 		},
 	}
 
 	for _, test := range tests {
-		result := validateListener(test.l)
-		if result != test.expected {
-			t.Errorf("validateListener() returned %v but expected %v for the case of %q", result, test.expected, test.msg)
+		result := validateURLRewrite(test.rewrite, field)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("validateURLRewrite() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidateRuleURLRewrite(t *testing.T) {
+	const field = "spec.rules[0]"
+
+	prefixRewrite := v1alpha2.HTTPRouteFilter{
+		Type: v1beta1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+			Path: &v1alpha2.HTTPPathModifier{
+				Type:               v1beta1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: helpers.GetStringPointer("/bar"),
+			},
+		},
+	}
+
+	fullPathRewrite := v1alpha2.HTTPRouteFilter{
+		Type: v1beta1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &v1alpha2.HTTPURLRewriteFilter{
+			Path: &v1alpha2.HTTPPathModifier{
+				Type:            v1beta1.FullPathHTTPPathModifier,
+				ReplaceFullPath: helpers.GetStringPointer("/bar"),
+			},
+		},
+	}
+
+	redirect := v1alpha2.HTTPRouteFilter{
+		Type:            v1beta1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: &v1alpha2.HTTPRequestRedirectFilter{},
+	}
+
+	prefixMatch := v1alpha2.HTTPRouteMatch{
+		Path: &v1alpha2.HTTPPathMatch{
+			Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchPathPrefix),
+			Value: helpers.GetStringPointer("/foo"),
+		},
+	}
+
+	exactMatch := v1alpha2.HTTPRouteMatch{
+		Path: &v1alpha2.HTTPPathMatch{
+			Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+			Value: helpers.GetStringPointer("/foo"),
+		},
+	}
+
+	tests := []struct {
+		rule     v1alpha2.HTTPRouteRule
+		expected []string
+		msg      string
+	}{
+		{
+			rule: v1alpha2.HTTPRouteRule{
+				Matches: []v1alpha2.HTTPRouteMatch{prefixMatch, {}},
+				Filters: []v1alpha2.HTTPRouteFilter{prefixRewrite},
+			},
+			expected: nil,
+			msg:      "prefix rewrite with prefix matches",
+		},
+		{
+			rule: v1alpha2.HTTPRouteRule{
+				Matches: []v1alpha2.HTTPRouteMatch{exactMatch},
+				Filters: []v1alpha2.HTTPRouteFilter{fullPathRewrite},
+			},
+			expected: nil,
+			msg:      "full path rewrite with exact match",
+		},
+		{
+			rule: v1alpha2.HTTPRouteRule{
+				Matches: []v1alpha2.HTTPRouteMatch{exactMatch},
+				Filters: []v1alpha2.HTTPRouteFilter{redirect},
+			},
+			expected: nil,
+			msg:      "no rewrite",
+		},
+		{
+			rule: v1alpha2.HTTPRouteRule{
+				Matches: []v1alpha2.HTTPRouteMatch{prefixMatch, exactMatch},
+				Filters: []v1alpha2.HTTPRouteFilter{redirect, prefixRewrite},
+			},
+			expected: []string{
+				field + ".filters: the URLRewrite and the RequestRedirect filters cannot be used together",
+				field + `.matches[1].path.type: the URLRewrite filter that replaces the prefix match requires ` +
+					`the type "PathPrefix"`,
+			},
+			msg: "prefix rewrite with redirect and exact match",
+		},
+	}
+
+	for _, test := range tests {
+		result := validateRuleURLRewrite(test.rule, field)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("validateRuleURLRewrite() %q mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestValidatePathRegex(t *testing.T) {
+	tests := []struct {
+		regex     string
+		expectErr bool
+	}{
+		{regex: `/api/v[0-9]+/.*`, expectErr: false},
+		{regex: `^/(?:foo|bar)/\d{2,3}$`, expectErr: false},
+		{regex: `/(?P<name>[a-z]+)`, expectErr: false},
+		{regex: `/[\[:a-z]+`, expectErr: false},
+		{regex: `\A/foo\z`, expectErr: false},
+		{regex: `/(foo`, expectErr: true},
+		{regex: `(?U)/foo.*`, expectErr: true},
+		{regex: `(?i:/foo)`, expectErr: true},
+		{regex: `/[[:word:]]+`, expectErr: true},
+		{regex: `/\pN+`, expectErr: true},
+		{regex: `/\P{Greek}`, expectErr: true},
+		{regex: `/\C`, expectErr: true},
+	}
+
+	for _, test := range tests {
+		err := validatePathRegex(test.regex)
+		if test.expectErr && err == nil {
+			t.Errorf("validatePathRegex() returned no error for %q", test.regex)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("validatePathRegex() returned unexpected error %v for %q", err, test.regex)
+		}
+	}
+}
+
+func TestValidateMatchRegex(t *testing.T) {
+	tests := []struct {
+		regex     string
+		expectErr bool
+	}{
+		{regex: `^v[0-9]+\.[0-9]+$`, expectErr: false},
+		{regex: `^(?:foo|bar)-\d{2,3}$`, expectErr: false},
+		{regex: `^[\[:a-z]+$`, expectErr: false},
+		{regex: `\(?P`, expectErr: false},
+		{regex: `(foo`, expectErr: true},
+		{regex: `(?i)foo`, expectErr: true},
+		{regex: `(?P<name>foo)`, expectErr: true},
+		{regex: `[[:alpha:]]+`, expectErr: true},
+		{regex: `\Afoo\z`, expectErr: true},
+		{regex: `\pL+`, expectErr: true},
+		{regex: `\Qfoo\E`, expectErr: true},
+		{regex: ``, expectErr: true},
+	}
+
+	for _, test := range tests {
+		err := validateMatchRegex(test.regex)
+		if test.expectErr && err == nil {
+			t.Errorf("validateMatchRegex() returned no error for %q", test.regex)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("validateMatchRegex() returned unexpected error %v for %q", err, test.regex)
 		}
 	}
 }
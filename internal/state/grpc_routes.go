@@ -0,0 +1,237 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+var (
+	// grpcServiceRegexp matches a Protobuf type name, as required by the Gateway API for the service of
+	// a GRPCMethodMatch. A leading '.' makes the name fully-qualified.
+	grpcServiceRegexp = regexp.MustCompile(`^\.?[A-Za-z_][A-Za-z_0-9]*(\.[A-Za-z_][A-Za-z_0-9]*)*$`)
+	// grpcMethodRegexp matches a Protobuf method name, as required by the Gateway API for the method of
+	// a GRPCMethodMatch.
+	grpcMethodRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z_0-9]*$`)
+)
+
+// convertGRPCRoute converts the GRPCRoute into the equivalent HTTPRoute, so that the GRPCRoute is processed like
+// an HTTPRoute. A gRPC client sends the requests for the method Method of the service Service with the path
+// /Service/Method, so that the service and method matches become path matches:
+// - an Exact match of the service and the method matches the path /Service/Method exactly.
+// - an Exact match of only the service matches the paths with the prefix /Service.
+// - an Exact match of only the method and a RegularExpression match match the paths by a regular expression.
+// - a match without the service and the method matches every path.
+// The RequestMirror and ExtensionRef filters are not supported, so they are dropped.
+// The HTTPRoute keeps the TypeMeta of the GRPCRoute, so that the warnings for the HTTPRoute report the GRPCRoute.
+// The GRPCRoute must be validated with validateGRPCRoute.
+func convertGRPCRoute(gr *v1alpha2.GRPCRoute) *v1alpha2.HTTPRoute {
+	hr := &v1alpha2.HTTPRoute{
+		TypeMeta:   gr.TypeMeta,
+		ObjectMeta: gr.ObjectMeta,
+		Spec: v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: gr.Spec.CommonRouteSpec,
+			Hostnames:       gr.Spec.Hostnames,
+		},
+	}
+
+	if len(gr.Spec.Rules) > 0 {
+		hr.Spec.Rules = make([]v1alpha2.HTTPRouteRule, 0, len(gr.Spec.Rules))
+	}
+
+	for _, rule := range gr.Spec.Rules {
+		hrRule := v1alpha2.HTTPRouteRule{
+			Filters: convertGRPCRouteFilters(rule.Filters),
+		}
+
+		for _, m := range rule.Matches {
+			hrRule.Matches = append(hrRule.Matches, convertGRPCRouteMatch(m))
+		}
+
+		// a rule without matches matches every request
+		if len(hrRule.Matches) == 0 {
+			hrRule.Matches = []v1alpha2.HTTPRouteMatch{convertGRPCRouteMatch(v1alpha2.GRPCRouteMatch{})}
+		}
+
+		for _, ref := range rule.BackendRefs {
+			hrRule.BackendRefs = append(hrRule.BackendRefs, v1alpha2.HTTPBackendRef{
+				BackendRef: ref.BackendRef,
+				Filters:    convertGRPCRouteFilters(ref.Filters),
+			})
+		}
+
+		hr.Spec.Rules = append(hr.Spec.Rules, hrRule)
+	}
+
+	return hr
+}
+
+func convertGRPCRouteMatch(m v1alpha2.GRPCRouteMatch) v1alpha2.HTTPRouteMatch {
+	pathType, path := convertGRPCMethodMatch(m.Method)
+
+	match := v1alpha2.HTTPRouteMatch{
+		Path: &v1alpha2.HTTPPathMatch{
+			Type:  &pathType,
+			Value: &path,
+		},
+	}
+
+	for _, h := range m.Headers {
+		match.Headers = append(match.Headers, v1alpha2.HTTPHeaderMatch{
+			Type:  h.Type,
+			Name:  v1alpha2.HTTPHeaderName(h.Name),
+			Value: h.Value,
+		})
+	}
+
+	return match
+}
+
+func convertGRPCMethodMatch(m *v1alpha2.GRPCMethodMatch) (v1alpha2.PathMatchType, string) {
+	if m == nil {
+		return v1beta1.PathMatchPathPrefix, "/"
+	}
+
+	var service, method string
+	if m.Service != nil {
+		service = *m.Service
+	}
+	if m.Method != nil {
+		method = *m.Method
+	}
+
+	if m.Type != nil && *m.Type == v1alpha2.GRPCMethodMatchRegularExpression {
+		if service == "" {
+			service = "[^/]+"
+		}
+		if method == "" {
+			method = "[^/]+"
+		}
+
+		return v1beta1.PathMatchRegularExpression, fmt.Sprintf("^/(?:%s)/(?:%s)$", service, method)
+	}
+
+	// the path of a request includes the service name without the leading '.' of a fully-qualified name
+	service = strings.TrimPrefix(service, ".")
+
+	switch {
+	case service != "" && method != "":
+		return v1beta1.PathMatchExact, "/" + service + "/" + method
+	case service != "":
+		return v1beta1.PathMatchPathPrefix, "/" + service
+	case method != "":
+		return v1beta1.PathMatchRegularExpression, "^/[^/]+/" + regexp.QuoteMeta(method) + "$"
+	default:
+		return v1beta1.PathMatchPathPrefix, "/"
+	}
+}
+
+func convertGRPCRouteFilters(filters []v1alpha2.GRPCRouteFilter) []v1alpha2.HTTPRouteFilter {
+	var result []v1alpha2.HTTPRouteFilter
+
+	for _, f := range filters {
+		switch f.Type {
+		case v1alpha2.GRPCRouteFilterRequestHeaderModifier:
+			result = append(result, v1alpha2.HTTPRouteFilter{
+				Type:                  v1beta1.HTTPRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: f.RequestHeaderModifier,
+			})
+		case v1alpha2.GRPCRouteFilterResponseHeaderModifier:
+			result = append(result, v1alpha2.HTTPRouteFilter{
+				Type:                   v1beta1.HTTPRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: f.ResponseHeaderModifier,
+			})
+		}
+	}
+
+	return result
+}
+
+// validateGRPCRoute validates the fields of the GRPCRoute that NGINX requires to be valid, like validateHTTPRoute.
+// The service and the method of an Exact match become a part of a path, so that they must be valid Protobuf names.
+// The RegularExpression matches are validated as the regular expressions of the paths.
+func validateGRPCRoute(gr *v1alpha2.GRPCRoute) error {
+	var msgs []string
+
+	for i, rule := range gr.Spec.Rules {
+		for j, m := range rule.Matches {
+			prefix := fmt.Sprintf("spec.rules[%d].matches[%d]", i, j)
+
+			msgs = append(msgs, validateGRPCMethodMatch(m.Method, prefix+".method")...)
+
+			for k, h := range convertGRPCRouteMatch(v1alpha2.GRPCRouteMatch{Headers: m.Headers}).Headers {
+				msgs = append(msgs, validateHeaderMatch(h, fmt.Sprintf("%s.headers[%d]", prefix, k))...)
+			}
+		}
+
+		for j, f := range rule.Filters {
+			msgs = append(msgs, validateGRPCRouteFilter(f, fmt.Sprintf("spec.rules[%d].filters[%d]", i, j))...)
+		}
+
+		// only the ResponseHeaderModifier filter of the backendRefs is supported, the other filters are ignored
+		for j, ref := range rule.BackendRefs {
+			for k, f := range ref.Filters {
+				if f.Type == v1alpha2.GRPCRouteFilterResponseHeaderModifier {
+					field := fmt.Sprintf("spec.rules[%d].backendRefs[%d].filters[%d]", i, j, k)
+					msgs = append(msgs, validateGRPCRouteFilter(f, field)...)
+				}
+			}
+		}
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+func validateGRPCMethodMatch(m *v1alpha2.GRPCMethodMatch, field string) []string {
+	if m == nil {
+		return nil
+	}
+
+	if m.Type != nil && *m.Type == v1alpha2.GRPCMethodMatchRegularExpression {
+		_, path := convertGRPCMethodMatch(m)
+		if err := validatePathRegex(path); err != nil {
+			return []string{fmt.Sprintf("%s: %v", field, err)}
+		}
+		return nil
+	}
+
+	if m.Type != nil && *m.Type != v1alpha2.GRPCMethodMatchExact {
+		return []string{fmt.Sprintf("%s.type: unsupported type %q", field, *m.Type)}
+	}
+
+	var msgs []string
+
+	if m.Service != nil && *m.Service != "" && !grpcServiceRegexp.MatchString(*m.Service) {
+		msgs = append(msgs, fmt.Sprintf("%s.service: invalid service %q, it must be a valid Protobuf type name",
+			field, *m.Service))
+	}
+
+	if m.Method != nil && *m.Method != "" && !grpcMethodRegexp.MatchString(*m.Method) {
+		msgs = append(msgs, fmt.Sprintf("%s.method: invalid method %q, it must be a valid Protobuf method name",
+			field, *m.Method))
+	}
+
+	return msgs
+}
+
+// validateGRPCRouteFilter validates the filters that NGINX supports. The ExtensionRef filters are ignored.
+func validateGRPCRouteFilter(filter v1alpha2.GRPCRouteFilter, field string) []string {
+	switch filter.Type {
+	case v1alpha2.GRPCRouteFilterRequestHeaderModifier:
+		return validateRequestHeaderModifier(filter.RequestHeaderModifier, field+".requestHeaderModifier")
+	case v1alpha2.GRPCRouteFilterResponseHeaderModifier:
+		return validateResponseHeaderModifier(filter.ResponseHeaderModifier, field+".responseHeaderModifier")
+	case v1alpha2.GRPCRouteFilterRequestMirror:
+		return []string{fmt.Sprintf("%s.type: unsupported type %q", field, filter.Type)}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,425 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+)
+
+func TestConvertGRPCRoute(t *testing.T) {
+	parentRefs := []v1alpha2.ParentReference{
+		{
+			Name:        "gateway",
+			SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("listener-443")),
+		},
+	}
+
+	headerFilter := &v1alpha2.HTTPHeaderFilter{
+		Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value"}},
+	}
+
+	backendRef := v1alpha2.BackendRef{
+		BackendObjectReference: v1alpha2.BackendObjectReference{
+			Name: "service",
+			Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(50051)),
+		},
+	}
+
+	gr := &v1alpha2.GRPCRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "GRPCRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "test",
+			Name:       "gr",
+			Generation: 3,
+		},
+		Spec: v1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: v1alpha2.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: []v1alpha2.Hostname{"grpc.example.com"},
+			Rules: []v1alpha2.GRPCRouteRule{
+				{
+					Matches: []v1alpha2.GRPCRouteMatch{
+						{
+							Method: &v1alpha2.GRPCMethodMatch{
+								Service: helpers.GetStringPointer("helloworld.Greeter"),
+								Method:  helpers.GetStringPointer("SayHello"),
+							},
+							Headers: []v1alpha2.GRPCHeaderMatch{
+								{
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+									Name:  "version",
+									Value: "v[12]",
+								},
+							},
+						},
+					},
+					Filters: []v1alpha2.GRPCRouteFilter{
+						{
+							Type:                  v1alpha2.GRPCRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: headerFilter,
+						},
+						{
+							Type: v1alpha2.GRPCRouteFilterExtensionRef,
+						},
+					},
+					BackendRefs: []v1alpha2.GRPCBackendRef{
+						{
+							BackendRef: backendRef,
+							Filters: []v1alpha2.GRPCRouteFilter{
+								{
+									Type:                   v1alpha2.GRPCRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: headerFilter,
+								},
+							},
+						},
+					},
+				},
+				{
+					BackendRefs: []v1alpha2.GRPCBackendRef{
+						{
+							BackendRef: backendRef,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := &v1alpha2.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "GRPCRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "test",
+			Name:       "gr",
+			Generation: 3,
+		},
+		Spec: v1alpha2.HTTPRouteSpec{
+			CommonRouteSpec: v1alpha2.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: []v1alpha2.Hostname{"grpc.example.com"},
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchExact),
+								Value: helpers.GetStringPointer("/helloworld.Greeter/SayHello"),
+							},
+							Headers: []v1alpha2.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+									Name:  "version",
+									Value: "v[12]",
+								},
+							},
+						},
+					},
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type:                  v1beta1.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: headerFilter,
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: backendRef,
+							Filters: []v1alpha2.HTTPRouteFilter{
+								{
+									Type:                   v1beta1.HTTPRouteFilterResponseHeaderModifier,
+									ResponseHeaderModifier: headerFilter,
+								},
+							},
+						},
+					},
+				},
+				{
+					Matches: []v1alpha2.HTTPRouteMatch{
+						{
+							Path: &v1alpha2.HTTPPathMatch{
+								Type:  helpers.GetPathMatchTypePointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetStringPointer("/"),
+							},
+						},
+					},
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{
+							BackendRef: backendRef,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := convertGRPCRoute(gr)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("convertGRPCRoute() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertGRPCMethodMatch(t *testing.T) {
+	tests := []struct {
+		match            *v1alpha2.GRPCMethodMatch
+		expectedPathType v1alpha2.PathMatchType
+		expectedPath     string
+		msg              string
+	}{
+		{
+			match:            nil,
+			expectedPathType: v1beta1.PathMatchPathPrefix,
+			expectedPath:     "/",
+			msg:              "no method match",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Type: helpers.GetGRPCMethodMatchTypePointer(v1alpha2.GRPCMethodMatchExact),
+			},
+			expectedPathType: v1beta1.PathMatchPathPrefix,
+			expectedPath:     "/",
+			msg:              "exact without service and method",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Service: helpers.GetStringPointer("helloworld.Greeter"),
+				Method:  helpers.GetStringPointer("SayHello"),
+			},
+			expectedPathType: v1beta1.PathMatchExact,
+			expectedPath:     "/helloworld.Greeter/SayHello",
+			msg:              "exact service and method",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Service: helpers.GetStringPointer(".helloworld.Greeter"),
+			},
+			expectedPathType: v1beta1.PathMatchPathPrefix,
+			expectedPath:     "/helloworld.Greeter",
+			msg:              "exact fully-qualified service",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Method: helpers.GetStringPointer("SayHello"),
+			},
+			expectedPathType: v1beta1.PathMatchRegularExpression,
+			expectedPath:     "^/[^/]+/SayHello$",
+			msg:              "exact method",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Type:    helpers.GetGRPCMethodMatchTypePointer(v1alpha2.GRPCMethodMatchRegularExpression),
+				Service: helpers.GetStringPointer(`helloworld\..+`),
+				Method:  helpers.GetStringPointer("Say.*"),
+			},
+			expectedPathType: v1beta1.PathMatchRegularExpression,
+			expectedPath:     `^/(?:helloworld\..+)/(?:Say.*)$`,
+			msg:              "regex service and method",
+		},
+		{
+			match: &v1alpha2.GRPCMethodMatch{
+				Type:   helpers.GetGRPCMethodMatchTypePointer(v1alpha2.GRPCMethodMatchRegularExpression),
+				Method: helpers.GetStringPointer("Say.*"),
+			},
+			expectedPathType: v1beta1.PathMatchRegularExpression,
+			expectedPath:     `^/(?:[^/]+)/(?:Say.*)$`,
+			msg:              "regex method",
+		},
+	}
+
+	for _, test := range tests {
+		pathType, path := convertGRPCMethodMatch(test.match)
+		if pathType != test.expectedPathType {
+			t.Errorf("convertGRPCMethodMatch() returned %q but expected %q for the case of %q", pathType,
+				test.expectedPathType, test.msg)
+		}
+		if path != test.expectedPath {
+			t.Errorf("convertGRPCMethodMatch() returned %q but expected %q for the case of %q", path,
+				test.expectedPath, test.msg)
+		}
+	}
+}
+
+func TestValidateGRPCRoute(t *testing.T) {
+	createRouteWithMatch := func(match v1alpha2.GRPCRouteMatch) *v1alpha2.GRPCRoute {
+		return &v1alpha2.GRPCRoute{
+			Spec: v1alpha2.GRPCRouteSpec{
+				Rules: []v1alpha2.GRPCRouteRule{
+					{
+						Matches: []v1alpha2.GRPCRouteMatch{match},
+					},
+				},
+			},
+		}
+	}
+
+	createRouteWithFilter := func(filter v1alpha2.GRPCRouteFilter) *v1alpha2.GRPCRoute {
+		return &v1alpha2.GRPCRoute{
+			Spec: v1alpha2.GRPCRouteSpec{
+				Rules: []v1alpha2.GRPCRouteRule{
+					{
+						Filters: []v1alpha2.GRPCRouteFilter{filter},
+					},
+				},
+			},
+		}
+	}
+
+	createRouteWithBackendFilter := func(filter v1alpha2.GRPCRouteFilter) *v1alpha2.GRPCRoute {
+		return &v1alpha2.GRPCRoute{
+			Spec: v1alpha2.GRPCRouteSpec{
+				Rules: []v1alpha2.GRPCRouteRule{
+					{
+						BackendRefs: []v1alpha2.GRPCBackendRef{
+							{
+								Filters: []v1alpha2.GRPCRouteFilter{filter},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		gr        *v1alpha2.GRPCRoute
+		expectErr bool
+		msg       string
+	}{
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Service: helpers.GetStringPointer(".helloworld.Greeter"),
+					Method:  helpers.GetStringPointer("SayHello"),
+				},
+			}),
+			expectErr: false,
+			msg:       "valid exact service and method",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Service: helpers.GetStringPointer("helloworld/Greeter"),
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid exact service",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Method: helpers.GetStringPointer("Say;Hello"),
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid exact method",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Type:    helpers.GetGRPCMethodMatchTypePointer(v1alpha2.GRPCMethodMatchRegularExpression),
+					Service: helpers.GetStringPointer(`helloworld\.[A-Z][a-z]+`),
+				},
+			}),
+			expectErr: false,
+			msg:       "valid regex",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Type:   helpers.GetGRPCMethodMatchTypePointer(v1alpha2.GRPCMethodMatchRegularExpression),
+					Method: helpers.GetStringPointer(`Say(Hello`),
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid regex",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Method: &v1alpha2.GRPCMethodMatch{
+					Type:   helpers.GetGRPCMethodMatchTypePointer("Prefix"),
+					Method: helpers.GetStringPointer("SayHello"),
+				},
+			}),
+			expectErr: true,
+			msg:       "unsupported method match type",
+		},
+		{
+			gr: createRouteWithMatch(v1alpha2.GRPCRouteMatch{
+				Headers: []v1alpha2.GRPCHeaderMatch{
+					{
+						Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+						Name:  "version",
+						Value: "(?i)v1",
+					},
+				},
+			}),
+			expectErr: true,
+			msg:       "unsupported header regex",
+		},
+		{
+			gr: createRouteWithFilter(v1alpha2.GRPCRouteFilter{
+				Type: v1alpha2.GRPCRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "value"}},
+				},
+			}),
+			expectErr: false,
+			msg:       "valid request header modifier",
+		},
+		{
+			gr: createRouteWithFilter(v1alpha2.GRPCRouteFilter{
+				Type: v1alpha2.GRPCRouteFilterRequestHeaderModifier,
+				RequestHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Set: []v1alpha2.HTTPHeader{{Name: "My-Header", Value: "$value"}},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid request header modifier",
+		},
+		{
+			gr: createRouteWithFilter(v1alpha2.GRPCRouteFilter{
+				Type: v1alpha2.GRPCRouteFilterRequestMirror,
+				RequestMirror: &v1alpha2.HTTPRequestMirrorFilter{
+					BackendRef: v1alpha2.BackendObjectReference{Name: "mirror"},
+				},
+			}),
+			expectErr: true,
+			msg:       "unsupported request mirror",
+		},
+		{
+			gr: createRouteWithFilter(v1alpha2.GRPCRouteFilter{
+				Type: v1alpha2.GRPCRouteFilterExtensionRef,
+			}),
+			expectErr: false,
+			msg:       "ignored extension ref",
+		},
+		{
+			gr: createRouteWithBackendFilter(v1alpha2.GRPCRouteFilter{
+				Type: v1alpha2.GRPCRouteFilterResponseHeaderModifier,
+				ResponseHeaderModifier: &v1alpha2.HTTPHeaderFilter{
+					Remove: []string{"Date"},
+				},
+			}),
+			expectErr: true,
+			msg:       "invalid backend response header modifier",
+		},
+	}
+
+	for _, test := range tests {
+		err := validateGRPCRoute(test.gr)
+		if test.expectErr && err == nil {
+			t.Errorf("validateGRPCRoute() returned no error for the case of %q", test.msg)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("validateGRPCRoute() returned unexpected error %v for the case of %q", err, test.msg)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// resolveCrossNamespaceBackendRefs checks the backendRefs of a route of the kind in the namespace that reference
+// the Services in other namespaces. It returns the Services that the ReferenceGrants allow the route to reference
+// and, if some of the references are not allowed, the condition that explains why. The allowed Services are nil if
+// the route doesn't reference any Services in other namespaces.
+func resolveCrossNamespaceBackendRefs(
+	routeKind v1alpha2.Kind,
+	routeNamespace string,
+	refs []v1alpha2.BackendObjectReference,
+	grants map[types.NamespacedName]*v1alpha2.ReferenceGrant,
+) (allowed map[types.NamespacedName]struct{}, conds []Condition) {
+	var notPermitted []string
+	seen := make(map[types.NamespacedName]struct{})
+
+	for _, ref := range refs {
+		if ref.Namespace == nil || string(*ref.Namespace) == routeNamespace {
+			continue
+		}
+
+		// the backends of other kinds are not supported, so they are never resolved
+		if ref.Kind != nil && *ref.Kind != "Service" {
+			continue
+		}
+
+		nsname := types.NamespacedName{Namespace: string(*ref.Namespace), Name: string(ref.Name)}
+
+		if _, exist := seen[nsname]; exist {
+			continue
+		}
+		seen[nsname] = struct{}{}
+
+		if !isCrossNamespaceRefAllowed(grants, routeKind, routeNamespace, "Service", nsname) {
+			notPermitted = append(notPermitted, nsname.String())
+			continue
+		}
+
+		if allowed == nil {
+			allowed = make(map[types.NamespacedName]struct{})
+		}
+		allowed[nsname] = struct{}{}
+	}
+
+	if len(notPermitted) > 0 {
+		msg := fmt.Sprintf("No ReferenceGrant allows the references to the Services in other namespaces: %s",
+			strings.Join(notPermitted, ", "))
+		conds = []Condition{newRouteRefNotPermittedCondition(msg)}
+	}
+
+	return allowed, conds
+}
+
+// isCrossNamespaceRefAllowed returns true if a ReferenceGrant in the namespace of the referenced resource allows
+// the Gateway API resources of the kind in the namespace to reference it. The referenced resource belongs to the
+// core group.
+func isCrossNamespaceRefAllowed(
+	grants map[types.NamespacedName]*v1alpha2.ReferenceGrant,
+	fromKind v1alpha2.Kind,
+	fromNamespace string,
+	toKind v1alpha2.Kind,
+	to types.NamespacedName,
+) bool {
+	for nsname, g := range grants {
+		if nsname.Namespace != to.Namespace {
+			continue
+		}
+
+		if allowsFrom(g.Spec.From, fromKind, fromNamespace) && allowsTo(g.Spec.To, toKind, to.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowsFrom(from []v1alpha2.ReferenceGrantFrom, kind v1alpha2.Kind, namespace string) bool {
+	for _, f := range from {
+		if f.Group == v1alpha2.GroupName && f.Kind == kind && string(f.Namespace) == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowsTo returns true if one of the "to" entries covers the resource. An entry without a name covers all resources
+// of the kind.
+func allowsTo(to []v1alpha2.ReferenceGrantTo, kind v1alpha2.Kind, name string) bool {
+	for _, t := range to {
+		if t.Group != "" && t.Group != "core" {
+			continue
+		}
+
+		if t.Kind != kind {
+			continue
+		}
+
+		if t.Name == nil || *t.Name == "" || string(*t.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getHTTPRouteBackendRefs returns the backends that the HTTPRoute references, including the backends of
+// the RequestMirror filters of its rules.
+func getHTTPRouteBackendRefs(hr *v1alpha2.HTTPRoute) []v1alpha2.BackendObjectReference {
+	var refs []v1alpha2.BackendObjectReference
+
+	for _, rule := range hr.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			refs = append(refs, ref.BackendObjectReference)
+		}
+
+		for _, f := range rule.Filters {
+			if f.Type == v1beta1.HTTPRouteFilterRequestMirror && f.RequestMirror != nil {
+				refs = append(refs, f.RequestMirror.BackendRef)
+			}
+		}
+	}
+
+	return refs
+}
+
+func appendBackendObjectRefs(
+	refs []v1alpha2.BackendObjectReference,
+	backendRefs []v1alpha2.BackendRef,
+) []v1alpha2.BackendObjectReference {
+	for _, ref := range backendRefs {
+		refs = append(refs, ref.BackendObjectReference)
+	}
+
+	return refs
+}
@@ -0,0 +1,242 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+)
+
+func TestResolveCrossNamespaceBackendRefs(t *testing.T) {
+	createRef := func(namespace string, name string) v1alpha2.BackendObjectReference {
+		ref := v1alpha2.BackendObjectReference{
+			Name: v1alpha2.ObjectName(name),
+			Port: (*v1alpha2.PortNumber)(helpers.GetInt32Pointer(80)),
+		}
+		if namespace != "" {
+			ref.Namespace = (*v1alpha2.Namespace)(helpers.GetStringPointer(namespace))
+		}
+		return ref
+	}
+
+	grants := map[types.NamespacedName]*v1alpha2.ReferenceGrant{
+		{Namespace: "backends", Name: "grant"}: {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "backends", Name: "grant"},
+			Spec: v1alpha2.ReferenceGrantSpec{
+				From: []v1alpha2.ReferenceGrantFrom{
+					{Group: v1alpha2.GroupName, Kind: "HTTPRoute", Namespace: "test"},
+				},
+				To: []v1alpha2.ReferenceGrantTo{
+					{Kind: "Service", Name: (*v1alpha2.ObjectName)(helpers.GetStringPointer("allowed"))},
+				},
+			},
+		},
+	}
+
+	notService := createRef("backends", "not-service")
+	notService.Kind = (*v1alpha2.Kind)(helpers.GetStringPointer("NotService"))
+
+	tests := []struct {
+		refs            []v1alpha2.BackendObjectReference
+		expectedAllowed map[types.NamespacedName]struct{}
+		expectedConds   []Condition
+		msg             string
+	}{
+		{
+			refs: []v1alpha2.BackendObjectReference{
+				createRef("", "implicit"),
+				createRef("test", "explicit"),
+				notService,
+			},
+			expectedAllowed: nil,
+			expectedConds:   nil,
+			msg:             "same namespace and unsupported kind",
+		},
+		{
+			refs: []v1alpha2.BackendObjectReference{
+				createRef("backends", "allowed"),
+				createRef("backends", "allowed"),
+			},
+			expectedAllowed: map[types.NamespacedName]struct{}{
+				{Namespace: "backends", Name: "allowed"}: {},
+			},
+			expectedConds: nil,
+			msg:           "allowed",
+		},
+		{
+			refs: []v1alpha2.BackendObjectReference{
+				createRef("backends", "allowed"),
+				createRef("backends", "not-allowed"),
+				createRef("other", "allowed"),
+				createRef("backends", "not-allowed"),
+			},
+			expectedAllowed: map[types.NamespacedName]struct{}{
+				{Namespace: "backends", Name: "allowed"}: {},
+			},
+			expectedConds: []Condition{
+				newRouteRefNotPermittedCondition(
+					"No ReferenceGrant allows the references to the Services in other namespaces: " +
+						"backends/not-allowed, other/allowed",
+				),
+			},
+			msg: "not allowed",
+		},
+	}
+
+	for _, test := range tests {
+		allowed, conds := resolveCrossNamespaceBackendRefs("HTTPRoute", "test", test.refs, grants)
+		if diff := cmp.Diff(test.expectedAllowed, allowed); diff != "" {
+			t.Errorf("resolveCrossNamespaceBackendRefs() %q mismatch on allowed (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedConds, conds); diff != "" {
+			t.Errorf("resolveCrossNamespaceBackendRefs() %q mismatch on conditions (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestIsCrossNamespaceRefAllowed(t *testing.T) {
+	createGrant := func(
+		namespace string,
+		from v1alpha2.ReferenceGrantFrom,
+		to v1alpha2.ReferenceGrantTo,
+	) *v1alpha2.ReferenceGrant {
+		return &v1alpha2.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "grant"},
+			Spec: v1alpha2.ReferenceGrantSpec{
+				From: []v1alpha2.ReferenceGrantFrom{from},
+				To:   []v1alpha2.ReferenceGrantTo{to},
+			},
+		}
+	}
+
+	from := v1alpha2.ReferenceGrantFrom{Group: v1alpha2.GroupName, Kind: "HTTPRoute", Namespace: "test"}
+	to := v1alpha2.ReferenceGrantTo{Kind: "Service"}
+
+	service := types.NamespacedName{Namespace: "backends", Name: "service1"}
+
+	tests := []struct {
+		grant    *v1alpha2.ReferenceGrant
+		expected bool
+		msg      string
+	}{
+		{
+			grant:    createGrant("backends", from, to),
+			expected: true,
+			msg:      "all services",
+		},
+		{
+			grant: createGrant("backends", from, v1alpha2.ReferenceGrantTo{
+				Group: "core",
+				Kind:  "Service",
+				Name:  (*v1alpha2.ObjectName)(helpers.GetStringPointer("service1")),
+			}),
+			expected: true,
+			msg:      "service by name",
+		},
+		{
+			grant: createGrant("backends", from, v1alpha2.ReferenceGrantTo{
+				Kind: "Service",
+				Name: (*v1alpha2.ObjectName)(helpers.GetStringPointer("service2")),
+			}),
+			expected: false,
+			msg:      "different service name",
+		},
+		{
+			grant:    createGrant("test", from, to),
+			expected: false,
+			msg:      "grant in a different namespace",
+		},
+		{
+			grant: createGrant("backends",
+				v1alpha2.ReferenceGrantFrom{Group: v1alpha2.GroupName, Kind: "TLSRoute", Namespace: "test"}, to),
+			expected: false,
+			msg:      "different kind of route",
+		},
+		{
+			grant: createGrant("backends",
+				v1alpha2.ReferenceGrantFrom{Group: v1alpha2.GroupName, Kind: "HTTPRoute", Namespace: "other"}, to),
+			expected: false,
+			msg:      "different namespace of route",
+		},
+		{
+			grant: createGrant("backends",
+				v1alpha2.ReferenceGrantFrom{Group: "example.com", Kind: "HTTPRoute", Namespace: "test"}, to),
+			expected: false,
+			msg:      "different group of route",
+		},
+		{
+			grant:    createGrant("backends", from, v1alpha2.ReferenceGrantTo{Group: "example.com", Kind: "Service"}),
+			expected: false,
+			msg:      "different group of service",
+		},
+		{
+			grant:    createGrant("backends", from, v1alpha2.ReferenceGrantTo{Kind: "Secret"}),
+			expected: false,
+			msg:      "different kind of referenced resource",
+		},
+	}
+
+	for _, test := range tests {
+		grants := map[types.NamespacedName]*v1alpha2.ReferenceGrant{
+			getNamespacedName(test.grant): test.grant,
+		}
+
+		result := isCrossNamespaceRefAllowed(grants, "HTTPRoute", "test", "Service", service)
+		if result != test.expected {
+			t.Errorf("isCrossNamespaceRefAllowed() returned %v but expected %v for the case of %q",
+				result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGetHTTPRouteBackendRefs(t *testing.T) {
+	createRef := func(name string) v1alpha2.BackendObjectReference {
+		return v1alpha2.BackendObjectReference{Name: v1alpha2.ObjectName(name)}
+	}
+
+	hr := &v1alpha2.HTTPRoute{
+		Spec: v1alpha2.HTTPRouteSpec{
+			Rules: []v1alpha2.HTTPRouteRule{
+				{
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{BackendRef: v1alpha2.BackendRef{BackendObjectReference: createRef("backend1")}},
+						{BackendRef: v1alpha2.BackendRef{BackendObjectReference: createRef("backend2")}},
+					},
+					Filters: []v1alpha2.HTTPRouteFilter{
+						{
+							Type: v1beta1.HTTPRouteFilterRequestMirror,
+							RequestMirror: &v1alpha2.HTTPRequestMirrorFilter{
+								BackendRef: createRef("mirror"),
+							},
+						},
+						{
+							Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+						},
+					},
+				},
+				{
+					BackendRefs: []v1alpha2.HTTPBackendRef{
+						{BackendRef: v1alpha2.BackendRef{BackendObjectReference: createRef("backend3")}},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []v1alpha2.BackendObjectReference{
+		createRef("backend1"),
+		createRef("backend2"),
+		createRef("mirror"),
+		createRef("backend3"),
+	}
+
+	result := getHTTPRouteBackendRefs(hr)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("getHTTPRouteBackendRefs() mismatch (-want +got):\n%s", diff)
+	}
+}
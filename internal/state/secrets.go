@@ -0,0 +1,124 @@
+package state
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . SecretDiskMemoryManager
+
+// SecretDiskMemoryManager manages the TLS Secrets referenced by the listeners of the Gateway.
+// It validates the Secrets and writes them to disk, so that NGINX can use them.
+type SecretDiskMemoryManager interface {
+	// Request validates the Secret and marks it to be written to disk by WriteAllRequestedSecrets.
+	// It returns the path to the file that will hold the certificate and the key of the Secret.
+	// If the Secret is invalid, Request returns an error.
+	Request(secret *apiv1.Secret) (string, error)
+	// WriteAllRequestedSecrets writes all requested Secrets to disk and removes any previously written Secrets
+	// that were not requested. Once the Secrets are written, the requests are cleared.
+	WriteAllRequestedSecrets() error
+}
+
+type requestedSecret struct {
+	secret *apiv1.Secret
+	path   string
+}
+
+// SecretDiskMemoryManagerImpl is an implementation of SecretDiskMemoryManager.
+type SecretDiskMemoryManagerImpl struct {
+	requestedSecrets map[types.NamespacedName]requestedSecret
+	secretDirectory  string
+}
+
+// NewSecretDiskMemoryManager creates a new SecretDiskMemoryManagerImpl that writes Secrets to secretDirectory.
+func NewSecretDiskMemoryManager(secretDirectory string) *SecretDiskMemoryManagerImpl {
+	return &SecretDiskMemoryManagerImpl{
+		requestedSecrets: make(map[types.NamespacedName]requestedSecret),
+		secretDirectory:  secretDirectory,
+	}
+}
+
+func (s *SecretDiskMemoryManagerImpl) Request(secret *apiv1.Secret) (string, error) {
+	if err := validateTLSSecret(secret); err != nil {
+		return "", err
+	}
+
+	nsname := getNamespacedName(secret)
+
+	// Namespaces and names of Kubernetes resources cannot include '_', so the file name is unique.
+	path := filepath.Join(s.secretDirectory, fmt.Sprintf("%s_%s.pem", nsname.Namespace, nsname.Name))
+
+	s.requestedSecrets[nsname] = requestedSecret{
+		secret: secret,
+		path:   path,
+	}
+
+	return path, nil
+}
+
+func (s *SecretDiskMemoryManagerImpl) WriteAllRequestedSecrets() error {
+	entries, err := os.ReadDir(s.secretDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to read secret directory %s: %w", s.secretDirectory, err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(s.secretDirectory, e.Name())
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove secret file %s: %w", path, err)
+		}
+	}
+
+	for _, rs := range s.requestedSecrets {
+		if err := writeSecret(rs.path, rs.secret); err != nil {
+			return err
+		}
+	}
+
+	s.requestedSecrets = make(map[types.NamespacedName]requestedSecret)
+
+	return nil
+}
+
+func writeSecret(path string, secret *apiv1.Secret) error {
+	// The file holds the private key, so only its owner is allowed to read it.
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create secret file %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	content := bytes.Join([][]byte{secret.Data[apiv1.TLSCertKey], secret.Data[apiv1.TLSPrivateKeyKey]}, []byte("\n"))
+
+	_, err = file.Write(content)
+	if err != nil {
+		return fmt.Errorf("failed to write secret file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func validateTLSSecret(secret *apiv1.Secret) error {
+	if secret.Type != apiv1.SecretTypeTLS {
+		return fmt.Errorf("secret type must be %q, got %q", apiv1.SecretTypeTLS, secret.Type)
+	}
+
+	_, err := tls.X509KeyPair(secret.Data[apiv1.TLSCertKey], secret.Data[apiv1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("secret doesn't include a valid TLS certificate and key: %w", err)
+	}
+
+	return nil
+}
@@ -2,35 +2,53 @@ package state
 
 import (
 	"fmt"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ServiceStore
 
-// ServiceStore stores services and can be queried for the cluster IP of a service.
+// Endpoint is an address of a ready pod that backs a service port.
+type Endpoint struct {
+	// Address is the IP address of the pod.
+	Address string
+	// Port is the target port of the pod.
+	Port int32
+}
+
+// ServiceStore stores services and their EndpointSlices and can be queried for the endpoints of a service port.
 type ServiceStore interface {
 	// Upsert upserts the service into the store.
 	Upsert(svc *v1.Service)
 	// Delete deletes the service from the store.
 	Delete(nsname types.NamespacedName)
-	// Resolve returns the cluster IP  the service specified by its namespace and name.
-	// If the service doesn't have a cluster IP or it doesn't exist, resolve will return an error.
-	// FIXME(pleshakov): later, we will start using the Endpoints rather than cluster IPs.
-	Resolve(nsname types.NamespacedName) (string, error)
+	// UpsertEndpointSlice upserts the EndpointSlice into the store.
+	UpsertEndpointSlice(slice *discoveryv1.EndpointSlice)
+	// DeleteEndpointSlice deletes the EndpointSlice from the store.
+	DeleteEndpointSlice(nsname types.NamespacedName)
+	// Get returns the service specified by its namespace and name and whether it exists.
+	Get(nsname types.NamespacedName) (*v1.Service, bool)
+	// Resolve returns the endpoints of the ready pods for the port of the service specified by its namespace and
+	// name. The endpoints are sorted by their address and port.
+	// If the service or the port doesn't exist or there are no ready endpoints, Resolve will return an error.
+	Resolve(nsname types.NamespacedName, port int32) ([]Endpoint, error)
 }
 
 // NewServiceStore creates a new ServiceStore.
 func NewServiceStore() ServiceStore {
 	return &serviceStoreImpl{
-		services: make(map[string]*v1.Service),
+		services:       make(map[string]*v1.Service),
+		endpointSlices: make(map[string]*discoveryv1.EndpointSlice),
 	}
 }
 
 type serviceStoreImpl struct {
-	services map[string]*v1.Service
+	services       map[string]*v1.Service
+	endpointSlices map[string]*discoveryv1.EndpointSlice
 }
 
 func (s *serviceStoreImpl) Upsert(svc *v1.Service) {
@@ -41,17 +59,111 @@ func (s *serviceStoreImpl) Delete(nsname types.NamespacedName) {
 	delete(s.services, nsname.String())
 }
 
-func (s *serviceStoreImpl) Resolve(nsname types.NamespacedName) (string, error) {
+func (s *serviceStoreImpl) UpsertEndpointSlice(slice *discoveryv1.EndpointSlice) {
+	s.endpointSlices[getResourceKey(&slice.ObjectMeta)] = slice
+}
+
+func (s *serviceStoreImpl) DeleteEndpointSlice(nsname types.NamespacedName) {
+	delete(s.endpointSlices, nsname.String())
+}
+
+func (s *serviceStoreImpl) Get(nsname types.NamespacedName) (*v1.Service, bool) {
+	svc, exist := s.services[nsname.String()]
+	return svc, exist
+}
+
+func (s *serviceStoreImpl) Resolve(nsname types.NamespacedName, port int32) ([]Endpoint, error) {
 	svc, exist := s.services[nsname.String()]
 	if !exist {
-		return "", fmt.Errorf("service %s doesn't exist", nsname.String())
+		return nil, fmt.Errorf("service %s doesn't exist", nsname.String())
 	}
 
-	if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == "None" {
-		return "", fmt.Errorf("service %s doesn't have ClusterIP", nsname.String())
+	svcPort, exist := findServicePort(svc, port)
+	if !exist {
+		return nil, fmt.Errorf("service %s doesn't have port %d", nsname.String(), port)
+	}
+
+	// The same endpoint can be present in multiple EndpointSlices, for example, while the endpoints are being moved
+	// between the slices.
+	unique := make(map[Endpoint]struct{})
+
+	for _, slice := range s.endpointSlices {
+		if slice.Namespace != nsname.Namespace || slice.Labels[discoveryv1.LabelServiceName] != nsname.Name {
+			continue
+		}
+
+		// FQDN addresses are deprecated and are not supported
+		if slice.AddressType != discoveryv1.AddressTypeIPv4 && slice.AddressType != discoveryv1.AddressTypeIPv6 {
+			continue
+		}
+
+		targetPort, exist := findEndpointSlicePort(slice, svcPort.Name)
+		if !exist {
+			continue
+		}
+
+		for _, ep := range slice.Endpoints {
+			if !isEndpointReady(ep) {
+				continue
+			}
+
+			for _, addr := range ep.Addresses {
+				unique[Endpoint{Address: addr, Port: targetPort}] = struct{}{}
+			}
+		}
 	}
 
-	return svc.Spec.ClusterIP, nil
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("service %s doesn't have ready endpoints for port %d", nsname.String(), port)
+	}
+
+	endpoints := make([]Endpoint, 0, len(unique))
+	for ep := range unique {
+		endpoints = append(endpoints, ep)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Address != endpoints[j].Address {
+			return endpoints[i].Address < endpoints[j].Address
+		}
+		return endpoints[i].Port < endpoints[j].Port
+	})
+
+	return endpoints, nil
+}
+
+func findServicePort(svc *v1.Service, port int32) (v1.ServicePort, bool) {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port {
+			return p, true
+		}
+	}
+
+	return v1.ServicePort{}, false
+}
+
+// findEndpointSlicePort finds the port of the EndpointSlice for the service port with the name.
+// The EndpointSlice ports have the same names as the service ports. The name of the port of a single-port service
+// can be empty.
+func findEndpointSlicePort(slice *discoveryv1.EndpointSlice, name string) (int32, bool) {
+	for _, p := range slice.Ports {
+		var portName string
+		if p.Name != nil {
+			portName = *p.Name
+		}
+
+		if portName == name && p.Port != nil {
+			return *p.Port, true
+		}
+	}
+
+	return 0, false
+}
+
+// isEndpointReady returns true if the endpoint is ready. An unknown ready state is interpreted as ready, as required by
+// the EndpointSlice API.
+func isEndpointReady(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
 }
 
 func getResourceKey(meta *metav1.ObjectMeta) string {
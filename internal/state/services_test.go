@@ -3,22 +3,66 @@ package state
 import (
 	. "github.com/onsi/ginkgo/v2"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 )
 
+func createEndpointSlice(
+	name string,
+	svcName string,
+	addressType discoveryv1.AddressType,
+	portName string,
+	port int32,
+	endpoints ...discoveryv1.Endpoint,
+) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      name,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: svcName,
+			},
+		},
+		AddressType: addressType,
+		Ports: []discoveryv1.EndpointPort{
+			{
+				Name: helpers.GetStringPointer(portName),
+				Port: helpers.GetInt32Pointer(port),
+			},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func createEndpoint(ready *bool, addresses ...string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses: addresses,
+		Conditions: discoveryv1.EndpointConditions{
+			Ready: ready,
+		},
+	}
+}
+
 var _ = Describe("ServiceStore", func() {
 	var store ServiceStore
 
+	svcNsName := types.NamespacedName{Namespace: "test", Name: "service1"}
+
 	BeforeEach(OncePerOrdered, func() {
 		store = NewServiceStore()
 	})
 
 	Describe("Resolve Service", Ordered, func() {
-		var svc *apiv1.Service
-		var svcUpdated *apiv1.Service
+		var (
+			svc          *apiv1.Service
+			slice        *discoveryv1.EndpointSlice
+			sliceUpdated *discoveryv1.EndpointSlice
+		)
 
 		BeforeAll(func() {
 			svc = &apiv1.Service{
@@ -27,78 +71,202 @@ var _ = Describe("ServiceStore", func() {
 					Name:      "service1",
 				},
 				Spec: apiv1.ServiceSpec{
-					ClusterIP: "10.0.0.1",
+					Ports: []apiv1.ServicePort{
+						{
+							Name: "http",
+							Port: 80,
+						},
+					},
 				},
 			}
 
-			svcUpdated = svc.DeepCopy()
-			// In reality, ClusterIP cannot change for a regular service.
-			// However, it is ok to use this change to test the Upsert function.
-			svcUpdated.Spec.ClusterIP = "10.0.0.2"
+			slice = createEndpointSlice(
+				"service1-abcde",
+				"service1",
+				discoveryv1.AddressTypeIPv4,
+				"http",
+				8080,
+				createEndpoint(helpers.GetBoolPointer(true), "10.0.0.2"),
+				createEndpoint(nil, "10.0.0.1"),
+			)
+
+			sliceUpdated = slice.DeepCopy()
+			sliceUpdated.Endpoints[1].Conditions.Ready = helpers.GetBoolPointer(false)
 		})
 
 		It("should add a service", func() {
 			store.Upsert(svc)
 		})
 
+		It("should get the service", func() {
+			result, exist := store.Get(svcNsName)
+
+			Expect(result).To(Equal(svc))
+			Expect(exist).To(BeTrue())
+		})
+
+		It("should fail to resolve the service without endpoints", func() {
+			_, err := store.Resolve(svcNsName, 80)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should add an EndpointSlice", func() {
+			store.UpsertEndpointSlice(slice)
+		})
+
 		It("should resolve the service", func() {
-			address, err := store.Resolve(types.NamespacedName{Namespace: "test", Name: "service1"})
+			endpoints, err := store.Resolve(svcNsName, 80)
 
-			Expect(address).To(Equal("10.0.0.1"))
+			Expect(endpoints).To(Equal([]Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080},
+			}))
 			Expect(err).To(BeNil())
 		})
 
-		It("should update the service", func() {
-			store.Upsert(svcUpdated)
+		It("should update the EndpointSlice", func() {
+			store.UpsertEndpointSlice(sliceUpdated)
 		})
 
-		It("should resolve the updated service", func() {
-			address, err := store.Resolve(types.NamespacedName{Namespace: "test", Name: "service1"})
+		It("should resolve the service to the ready endpoints", func() {
+			endpoints, err := store.Resolve(svcNsName, 80)
 
-			Expect(address).To(Equal("10.0.0.2"))
+			Expect(endpoints).To(Equal([]Endpoint{
+				{Address: "10.0.0.2", Port: 8080},
+			}))
 			Expect(err).To(BeNil())
+		})
 
+		It("should delete the EndpointSlice", func() {
+			store.DeleteEndpointSlice(types.NamespacedName{Namespace: "test", Name: "service1-abcde"})
+		})
+
+		It("should fail to resolve the service after the EndpointSlice is deleted", func() {
+			_, err := store.Resolve(svcNsName, 80)
+
+			Expect(err).To(HaveOccurred())
 		})
 
 		It("should delete the service", func() {
-			store.Delete(types.NamespacedName{Namespace: "test", Name: "service1"})
+			store.UpsertEndpointSlice(slice)
+			store.Delete(svcNsName)
 		})
 
 		It("should fail to resolve the service", func() {
-			_, err := store.Resolve(types.NamespacedName{Namespace: "test", Name: "service1"})
+			_, err := store.Resolve(svcNsName, 80)
 
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should not get the deleted service", func() {
+			_, exist := store.Get(svcNsName)
+
+			Expect(exist).To(BeFalse())
+		})
 	})
 
-	Describe("Edge cases", func() {
+	Describe("Multiple EndpointSlices", func() {
 		BeforeEach(func() {
 			store.Upsert(&apiv1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test",
-					Name:      "empty-ip",
+					Name:      "service1",
+				},
+				Spec: apiv1.ServiceSpec{
+					Ports: []apiv1.ServicePort{
+						{
+							Name: "http",
+							Port: 80,
+						},
+						{
+							Name: "admin",
+							Port: 81,
+						},
+					},
 				},
 			})
 
+			ready := helpers.GetBoolPointer(true)
+
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-http-1", "service1", discoveryv1.AddressTypeIPv4, "http", 8080,
+				createEndpoint(ready, "10.0.0.1"),
+				createEndpoint(ready, "10.0.0.2"),
+			))
+			// the endpoint is being moved between the slices
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-http-2", "service1", discoveryv1.AddressTypeIPv4, "http", 8080,
+				createEndpoint(ready, "10.0.0.2"),
+				createEndpoint(ready, "10.0.0.3"),
+			))
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-http-3", "service1", discoveryv1.AddressTypeIPv6, "http", 8080,
+				createEndpoint(ready, "fd00::1"),
+			))
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-admin", "service1", discoveryv1.AddressTypeIPv4, "admin", 9090,
+				createEndpoint(ready, "10.0.0.1"),
+			))
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-fqdn", "service1", discoveryv1.AddressTypeFQDN, "http", 8080,
+				createEndpoint(ready, "example.com"),
+			))
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service2-http", "service2", discoveryv1.AddressTypeIPv4, "http", 8080,
+				createEndpoint(ready, "10.0.0.10"),
+			))
+		})
+
+		DescribeTable("Resolve returns endpoints",
+			func(port int32, expected []Endpoint) {
+				endpoints, err := store.Resolve(svcNsName, port)
+
+				Expect(endpoints).To(Equal(expected))
+				Expect(err).To(BeNil())
+			},
+			Entry("http port", int32(80), []Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080},
+				{Address: "10.0.0.3", Port: 8080},
+				{Address: "fd00::1", Port: 8080},
+			}),
+			Entry("admin port", int32(81), []Endpoint{
+				{Address: "10.0.0.1", Port: 9090},
+			}),
+		)
+	})
+
+	Describe("Edge cases", func() {
+		BeforeEach(func() {
 			store.Upsert(&apiv1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test",
-					Name:      "none-ip",
+					Name:      "service1",
 				},
 				Spec: apiv1.ServiceSpec{
-					ClusterIP: "None",
+					Ports: []apiv1.ServicePort{
+						{
+							Port: 80,
+						},
+					},
 				},
 			})
+
+			store.UpsertEndpointSlice(createEndpointSlice(
+				"service1-abcde", "service1", discoveryv1.AddressTypeIPv4, "other", 8080,
+				createEndpoint(helpers.GetBoolPointer(true), "10.0.0.1"),
+			))
 		})
 		DescribeTable("Resolve returns error",
-			func(nsname types.NamespacedName) {
-				_, err := store.Resolve(nsname)
+			func(nsname types.NamespacedName, port int32) {
+				_, err := store.Resolve(nsname, port)
 
 				Expect(err).To(HaveOccurred())
 			},
-			Entry("cluster ip is empty", types.NamespacedName{Namespace: "test", Name: "empty-ip"}),
-			Entry("cluster ip is none", types.NamespacedName{Namespace: "test", Name: "none-ip"}),
-			Entry("service doesn't exist", types.NamespacedName{Namespace: "test", Name: "service"}),
+			Entry("service doesn't exist", types.NamespacedName{Namespace: "test", Name: "service"}, int32(80)),
+			Entry("port doesn't exist", svcNsName, int32(81)),
+			Entry("no EndpointSlice port for the service port", svcNsName, int32(80)),
 		)
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func sortMatchRules(matchRules []MatchRule) {
@@ -71,3 +72,16 @@ func lessObjectMeta(meta1 *metav1.ObjectMeta, meta2 *metav1.ObjectMeta) bool {
 
 	return meta1.CreationTimestamp.Before(&meta2.CreationTimestamp)
 }
+
+// lessObject compares the objects the same way as lessObjectMeta, so that the oldest object comes first.
+func lessObject(obj1 client.Object, obj2 client.Object) bool {
+	t1, t2 := obj1.GetCreationTimestamp(), obj2.GetCreationTimestamp()
+	if t1.Equal(&t2) {
+		if obj1.GetNamespace() == obj2.GetNamespace() {
+			return obj1.GetName() < obj2.GetName()
+		}
+		return obj1.GetNamespace() < obj2.GetNamespace()
+	}
+
+	return t1.Before(&t2)
+}
@@ -21,17 +21,17 @@ type FakeChangeProcessor struct {
 	captureUpsertChangeArgsForCall []struct {
 		arg1 client.Object
 	}
-	ProcessStub        func() (bool, state.Configuration, state.Statuses)
+	ProcessStub        func() (state.ChangeType, state.Configuration, state.Statuses)
 	processMutex       sync.RWMutex
 	processArgsForCall []struct {
 	}
 	processReturns struct {
-		result1 bool
+		result1 state.ChangeType
 		result2 state.Configuration
 		result3 state.Statuses
 	}
 	processReturnsOnCall map[int]struct {
-		result1 bool
+		result1 state.ChangeType
 		result2 state.Configuration
 		result3 state.Statuses
 	}
@@ -104,7 +104,7 @@ func (fake *FakeChangeProcessor) CaptureUpsertChangeArgsForCall(i int) client.Ob
 	return argsForCall.arg1
 }
 
-func (fake *FakeChangeProcessor) Process() (bool, state.Configuration, state.Statuses) {
+func (fake *FakeChangeProcessor) Process() (state.ChangeType, state.Configuration, state.Statuses) {
 	fake.processMutex.Lock()
 	ret, specificReturn := fake.processReturnsOnCall[len(fake.processArgsForCall)]
 	fake.processArgsForCall = append(fake.processArgsForCall, struct {
@@ -128,36 +128,36 @@ func (fake *FakeChangeProcessor) ProcessCallCount() int {
 	return len(fake.processArgsForCall)
 }
 
-func (fake *FakeChangeProcessor) ProcessCalls(stub func() (bool, state.Configuration, state.Statuses)) {
+func (fake *FakeChangeProcessor) ProcessCalls(stub func() (state.ChangeType, state.Configuration, state.Statuses)) {
 	fake.processMutex.Lock()
 	defer fake.processMutex.Unlock()
 	fake.ProcessStub = stub
 }
 
-func (fake *FakeChangeProcessor) ProcessReturns(result1 bool, result2 state.Configuration, result3 state.Statuses) {
+func (fake *FakeChangeProcessor) ProcessReturns(result1 state.ChangeType, result2 state.Configuration, result3 state.Statuses) {
 	fake.processMutex.Lock()
 	defer fake.processMutex.Unlock()
 	fake.ProcessStub = nil
 	fake.processReturns = struct {
-		result1 bool
+		result1 state.ChangeType
 		result2 state.Configuration
 		result3 state.Statuses
 	}{result1, result2, result3}
 }
 
-func (fake *FakeChangeProcessor) ProcessReturnsOnCall(i int, result1 bool, result2 state.Configuration, result3 state.Statuses) {
+func (fake *FakeChangeProcessor) ProcessReturnsOnCall(i int, result1 state.ChangeType, result2 state.Configuration, result3 state.Statuses) {
 	fake.processMutex.Lock()
 	defer fake.processMutex.Unlock()
 	fake.ProcessStub = nil
 	if fake.processReturnsOnCall == nil {
 		fake.processReturnsOnCall = make(map[int]struct {
-			result1 bool
+			result1 state.ChangeType
 			result2 state.Configuration
 			result3 state.Statuses
 		})
 	}
 	fake.processReturnsOnCall[i] = struct {
-		result1 bool
+		result1 state.ChangeType
 		result2 state.Configuration
 		result3 state.Statuses
 	}{result1, result2, result3}
@@ -0,0 +1,182 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package statefakes
+
+import (
+	"sync"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+	v1 "k8s.io/api/core/v1"
+)
+
+type FakeSecretDiskMemoryManager struct {
+	RequestStub        func(*v1.Secret) (string, error)
+	requestMutex       sync.RWMutex
+	requestArgsForCall []struct {
+		arg1 *v1.Secret
+	}
+	requestReturns struct {
+		result1 string
+		result2 error
+	}
+	requestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	WriteAllRequestedSecretsStub        func() error
+	writeAllRequestedSecretsMutex       sync.RWMutex
+	writeAllRequestedSecretsArgsForCall []struct {
+	}
+	writeAllRequestedSecretsReturns struct {
+		result1 error
+	}
+	writeAllRequestedSecretsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretDiskMemoryManager) Request(arg1 *v1.Secret) (string, error) {
+	fake.requestMutex.Lock()
+	ret, specificReturn := fake.requestReturnsOnCall[len(fake.requestArgsForCall)]
+	fake.requestArgsForCall = append(fake.requestArgsForCall, struct {
+		arg1 *v1.Secret
+	}{arg1})
+	stub := fake.RequestStub
+	fakeReturns := fake.requestReturns
+	fake.recordInvocation("Request", []interface{}{arg1})
+	fake.requestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCallCount() int {
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	return len(fake.requestArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestCalls(stub func(*v1.Secret) (string, error)) {
+	fake.requestMutex.Lock()
+	defer fake.requestMutex.Unlock()
+	fake.RequestStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestArgsForCall(i int) *v1.Secret {
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	argsForCall := fake.requestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestReturns(result1 string, result2 error) {
+	fake.requestMutex.Lock()
+	defer fake.requestMutex.Unlock()
+	fake.RequestStub = nil
+	fake.requestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretDiskMemoryManager) RequestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.requestMutex.Lock()
+	defer fake.requestMutex.Unlock()
+	fake.RequestStub = nil
+	if fake.requestReturnsOnCall == nil {
+		fake.requestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.requestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecrets() error {
+	fake.writeAllRequestedSecretsMutex.Lock()
+	ret, specificReturn := fake.writeAllRequestedSecretsReturnsOnCall[len(fake.writeAllRequestedSecretsArgsForCall)]
+	fake.writeAllRequestedSecretsArgsForCall = append(fake.writeAllRequestedSecretsArgsForCall, struct {
+	}{})
+	stub := fake.WriteAllRequestedSecretsStub
+	fakeReturns := fake.writeAllRequestedSecretsReturns
+	fake.recordInvocation("WriteAllRequestedSecrets", []interface{}{})
+	fake.writeAllRequestedSecretsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecretsCallCount() int {
+	fake.writeAllRequestedSecretsMutex.RLock()
+	defer fake.writeAllRequestedSecretsMutex.RUnlock()
+	return len(fake.writeAllRequestedSecretsArgsForCall)
+}
+
+func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecretsCalls(stub func() error) {
+	fake.writeAllRequestedSecretsMutex.Lock()
+	defer fake.writeAllRequestedSecretsMutex.Unlock()
+	fake.WriteAllRequestedSecretsStub = stub
+}
+
+func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecretsReturns(result1 error) {
+	fake.writeAllRequestedSecretsMutex.Lock()
+	defer fake.writeAllRequestedSecretsMutex.Unlock()
+	fake.WriteAllRequestedSecretsStub = nil
+	fake.writeAllRequestedSecretsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) WriteAllRequestedSecretsReturnsOnCall(i int, result1 error) {
+	fake.writeAllRequestedSecretsMutex.Lock()
+	defer fake.writeAllRequestedSecretsMutex.Unlock()
+	fake.WriteAllRequestedSecretsStub = nil
+	if fake.writeAllRequestedSecretsReturnsOnCall == nil {
+		fake.writeAllRequestedSecretsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeAllRequestedSecretsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretDiskMemoryManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	fake.writeAllRequestedSecretsMutex.RLock()
+	defer fake.writeAllRequestedSecretsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretDiskMemoryManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ state.SecretDiskMemoryManager = new(FakeSecretDiskMemoryManager)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 	v1 "k8s.io/api/core/v1"
+	v1a "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -15,17 +16,36 @@ type FakeServiceStore struct {
 	deleteArgsForCall []struct {
 		arg1 types.NamespacedName
 	}
-	ResolveStub        func(types.NamespacedName) (string, error)
+	DeleteEndpointSliceStub        func(types.NamespacedName)
+	deleteEndpointSliceMutex       sync.RWMutex
+	deleteEndpointSliceArgsForCall []struct {
+		arg1 types.NamespacedName
+	}
+	GetStub        func(types.NamespacedName) (*v1.Service, bool)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 types.NamespacedName
+	}
+	getReturns struct {
+		result1 *v1.Service
+		result2 bool
+	}
+	getReturnsOnCall map[int]struct {
+		result1 *v1.Service
+		result2 bool
+	}
+	ResolveStub        func(types.NamespacedName, int32) ([]state.Endpoint, error)
 	resolveMutex       sync.RWMutex
 	resolveArgsForCall []struct {
 		arg1 types.NamespacedName
+		arg2 int32
 	}
 	resolveReturns struct {
-		result1 string
+		result1 []state.Endpoint
 		result2 error
 	}
 	resolveReturnsOnCall map[int]struct {
-		result1 string
+		result1 []state.Endpoint
 		result2 error
 	}
 	UpsertStub        func(*v1.Service)
@@ -33,6 +53,11 @@ type FakeServiceStore struct {
 	upsertArgsForCall []struct {
 		arg1 *v1.Service
 	}
+	UpsertEndpointSliceStub        func(*v1a.EndpointSlice)
+	upsertEndpointSliceMutex       sync.RWMutex
+	upsertEndpointSliceArgsForCall []struct {
+		arg1 *v1a.EndpointSlice
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -69,18 +94,115 @@ func (fake *FakeServiceStore) DeleteArgsForCall(i int) types.NamespacedName {
 	return argsForCall.arg1
 }
 
-func (fake *FakeServiceStore) Resolve(arg1 types.NamespacedName) (string, error) {
+func (fake *FakeServiceStore) DeleteEndpointSlice(arg1 types.NamespacedName) {
+	fake.deleteEndpointSliceMutex.Lock()
+	fake.deleteEndpointSliceArgsForCall = append(fake.deleteEndpointSliceArgsForCall, struct {
+		arg1 types.NamespacedName
+	}{arg1})
+	stub := fake.DeleteEndpointSliceStub
+	fake.recordInvocation("DeleteEndpointSlice", []interface{}{arg1})
+	fake.deleteEndpointSliceMutex.Unlock()
+	if stub != nil {
+		fake.DeleteEndpointSliceStub(arg1)
+	}
+}
+
+func (fake *FakeServiceStore) DeleteEndpointSliceCallCount() int {
+	fake.deleteEndpointSliceMutex.RLock()
+	defer fake.deleteEndpointSliceMutex.RUnlock()
+	return len(fake.deleteEndpointSliceArgsForCall)
+}
+
+func (fake *FakeServiceStore) DeleteEndpointSliceCalls(stub func(types.NamespacedName)) {
+	fake.deleteEndpointSliceMutex.Lock()
+	defer fake.deleteEndpointSliceMutex.Unlock()
+	fake.DeleteEndpointSliceStub = stub
+}
+
+func (fake *FakeServiceStore) DeleteEndpointSliceArgsForCall(i int) types.NamespacedName {
+	fake.deleteEndpointSliceMutex.RLock()
+	defer fake.deleteEndpointSliceMutex.RUnlock()
+	argsForCall := fake.deleteEndpointSliceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServiceStore) Get(arg1 types.NamespacedName) (*v1.Service, bool) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 types.NamespacedName
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeServiceStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeServiceStore) GetCalls(stub func(types.NamespacedName) (*v1.Service, bool)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeServiceStore) GetArgsForCall(i int) types.NamespacedName {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServiceStore) GetReturns(result1 *v1.Service, result2 bool) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 *v1.Service
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeServiceStore) GetReturnsOnCall(i int, result1 *v1.Service, result2 bool) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 *v1.Service
+			result2 bool
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 *v1.Service
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeServiceStore) Resolve(arg1 types.NamespacedName, arg2 int32) ([]state.Endpoint, error) {
 	fake.resolveMutex.Lock()
 	ret, specificReturn := fake.resolveReturnsOnCall[len(fake.resolveArgsForCall)]
 	fake.resolveArgsForCall = append(fake.resolveArgsForCall, struct {
 		arg1 types.NamespacedName
-	}{arg1})
+		arg2 int32
+	}{arg1, arg2})
 	stub := fake.ResolveStub
 	fakeReturns := fake.resolveReturns
-	fake.recordInvocation("Resolve", []interface{}{arg1})
+	fake.recordInvocation("Resolve", []interface{}{arg1, arg2})
 	fake.resolveMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -94,41 +216,41 @@ func (fake *FakeServiceStore) ResolveCallCount() int {
 	return len(fake.resolveArgsForCall)
 }
 
-func (fake *FakeServiceStore) ResolveCalls(stub func(types.NamespacedName) (string, error)) {
+func (fake *FakeServiceStore) ResolveCalls(stub func(types.NamespacedName, int32) ([]state.Endpoint, error)) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = stub
 }
 
-func (fake *FakeServiceStore) ResolveArgsForCall(i int) types.NamespacedName {
+func (fake *FakeServiceStore) ResolveArgsForCall(i int) (types.NamespacedName, int32) {
 	fake.resolveMutex.RLock()
 	defer fake.resolveMutex.RUnlock()
 	argsForCall := fake.resolveArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeServiceStore) ResolveReturns(result1 string, result2 error) {
+func (fake *FakeServiceStore) ResolveReturns(result1 []state.Endpoint, result2 error) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = nil
 	fake.resolveReturns = struct {
-		result1 string
+		result1 []state.Endpoint
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeServiceStore) ResolveReturnsOnCall(i int, result1 string, result2 error) {
+func (fake *FakeServiceStore) ResolveReturnsOnCall(i int, result1 []state.Endpoint, result2 error) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = nil
 	if fake.resolveReturnsOnCall == nil {
 		fake.resolveReturnsOnCall = make(map[int]struct {
-			result1 string
+			result1 []state.Endpoint
 			result2 error
 		})
 	}
 	fake.resolveReturnsOnCall[i] = struct {
-		result1 string
+		result1 []state.Endpoint
 		result2 error
 	}{result1, result2}
 }
@@ -165,15 +287,53 @@ func (fake *FakeServiceStore) UpsertArgsForCall(i int) *v1.Service {
 	return argsForCall.arg1
 }
 
+func (fake *FakeServiceStore) UpsertEndpointSlice(arg1 *v1a.EndpointSlice) {
+	fake.upsertEndpointSliceMutex.Lock()
+	fake.upsertEndpointSliceArgsForCall = append(fake.upsertEndpointSliceArgsForCall, struct {
+		arg1 *v1a.EndpointSlice
+	}{arg1})
+	stub := fake.UpsertEndpointSliceStub
+	fake.recordInvocation("UpsertEndpointSlice", []interface{}{arg1})
+	fake.upsertEndpointSliceMutex.Unlock()
+	if stub != nil {
+		fake.UpsertEndpointSliceStub(arg1)
+	}
+}
+
+func (fake *FakeServiceStore) UpsertEndpointSliceCallCount() int {
+	fake.upsertEndpointSliceMutex.RLock()
+	defer fake.upsertEndpointSliceMutex.RUnlock()
+	return len(fake.upsertEndpointSliceArgsForCall)
+}
+
+func (fake *FakeServiceStore) UpsertEndpointSliceCalls(stub func(*v1a.EndpointSlice)) {
+	fake.upsertEndpointSliceMutex.Lock()
+	defer fake.upsertEndpointSliceMutex.Unlock()
+	fake.UpsertEndpointSliceStub = stub
+}
+
+func (fake *FakeServiceStore) UpsertEndpointSliceArgsForCall(i int) *v1a.EndpointSlice {
+	fake.upsertEndpointSliceMutex.RLock()
+	defer fake.upsertEndpointSliceMutex.RUnlock()
+	argsForCall := fake.upsertEndpointSliceArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeServiceStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteEndpointSliceMutex.RLock()
+	defer fake.deleteEndpointSliceMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
 	fake.resolveMutex.RLock()
 	defer fake.resolveMutex.RUnlock()
 	fake.upsertMutex.RLock()
 	defer fake.upsertMutex.RUnlock()
+	fake.upsertEndpointSliceMutex.RLock()
+	defer fake.upsertEndpointSliceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
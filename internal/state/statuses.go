@@ -1,6 +1,9 @@
 package state
 
-import "k8s.io/apimachinery/pkg/types"
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
 
 // ListenerStatuses holds the statuses of listeners where the key is the name of a listener in the Gateway resource.
 type ListenerStatuses map[string]ListenerStatus
@@ -8,26 +11,37 @@ type ListenerStatuses map[string]ListenerStatus
 // HTTPRouteStatuses holds the statuses of HTTPRoutes where the key is the namespaced name of an HTTPRoute.
 type HTTPRouteStatuses map[types.NamespacedName]HTTPRouteStatus
 
+// GRPCRouteStatuses holds the statuses of GRPCRoutes where the key is the namespaced name of a GRPCRoute.
+type GRPCRouteStatuses map[types.NamespacedName]GRPCRouteStatus
+
+// TLSRouteStatuses holds the statuses of TLSRoutes where the key is the namespaced name of a TLSRoute.
+type TLSRouteStatuses map[types.NamespacedName]TLSRouteStatus
+
+// TCPRouteStatuses holds the statuses of TCPRoutes where the key is the namespaced name of a TCPRoute.
+type TCPRouteStatuses map[types.NamespacedName]TCPRouteStatus
+
+// UDPRouteStatuses holds the statuses of UDPRoutes where the key is the namespaced name of a UDPRoute.
+type UDPRouteStatuses map[types.NamespacedName]UDPRouteStatus
+
+// GatewayStatuses holds the statuses of Gateways where the key is the namespaced name of a Gateway.
+type GatewayStatuses map[types.NamespacedName]GatewayStatus
+
 // Statuses holds the status-related information about Gateway API resources.
 type Statuses struct {
-	GatewayClassStatus     *GatewayClassStatus
-	GatewayStatus          *GatewayStatus
-	IgnoredGatewayStatuses IgnoredGatewayStatuses
-	HTTPRouteStatuses      HTTPRouteStatuses
+	GatewayClassStatus *GatewayClassStatus
+	GatewayStatuses    GatewayStatuses
+	HTTPRouteStatuses  HTTPRouteStatuses
+	GRPCRouteStatuses  GRPCRouteStatuses
+	TLSRouteStatuses   TLSRouteStatuses
+	TCPRouteStatuses   TCPRouteStatuses
+	UDPRouteStatuses   UDPRouteStatuses
 }
 
-// GatewayStatus holds the status of the winning Gateway resource.
+// GatewayStatus holds the status of a Gateway resource.
 type GatewayStatus struct {
-	NsName           types.NamespacedName
 	ListenerStatuses ListenerStatuses
-}
-
-// IgnoredGatewayStatuses holds the statuses of the ignored Gateway resources.
-type IgnoredGatewayStatuses map[types.NamespacedName]IgnoredGatewayStatus
-
-// IgnoredGatewayStatus holds the status of an ignored Gateway resource.
-type IgnoredGatewayStatus struct {
-	ObservedGeneration int64
+	// Addresses holds the addresses where the Gateway is reachable.
+	Addresses []v1alpha2.GatewayAddress
 }
 
 // ListenerStatus holds the status-related information about a listener in the Gateway resource.
@@ -36,19 +50,54 @@ type ListenerStatus struct {
 	Valid bool
 	// AttachedRoutes is the number of routes attached to the listener.
 	AttachedRoutes int32
+	// SupportedKinds holds the kinds of the routes that can attach to the listener.
+	SupportedKinds []v1alpha2.RouteGroupKind
+	// Conditions holds the conditions that explain why the listener is not valid.
+	Conditions []Condition
 }
 
-// ParentStatuses holds the statuses of parents where the key is the section name in a parentRef.
-type ParentStatuses map[string]ParentStatus
+// ParentRefKey identifies a parentRef of a route by the Gateway and the section name it references.
+type ParentRefKey struct {
+	// Gateway is the namespaced name of the Gateway.
+	Gateway types.NamespacedName
+	// SectionName is the section name. The empty section name is used for a parentRef without a section name.
+	SectionName string
+}
+
+// ParentStatuses holds the statuses of parents where the key identifies a parentRef.
+type ParentStatuses map[ParentRefKey]ParentStatus
 
 type HTTPRouteStatus struct {
 	ParentStatuses ParentStatuses
 }
 
-// ParentStatus holds status-related information related to how the HTTPRoute binds to a specific parentRef.
+// GRPCRouteStatus holds the status-related information about a GRPCRoute.
+type GRPCRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+// TLSRouteStatus holds the status-related information about a TLSRoute.
+type TLSRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+// TCPRouteStatus holds the status-related information about a TCPRoute.
+type TCPRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+// UDPRouteStatus holds the status-related information about a UDPRoute.
+type UDPRouteStatus struct {
+	ParentStatuses ParentStatuses
+}
+
+// ParentStatus holds status-related information related to how the route binds to a specific parentRef.
 type ParentStatus struct {
 	// Attached is true if the route attaches to the parent (listener).
 	Attached bool
+	// Conditions holds the conditions that explain why the route doesn't attach to the parent.
+	// For an attached route, they explain why some of the backendRefs of the route cannot be resolved.
+	Conditions []Condition
 }
 
 // GatewayClassStatus holds status-related infortmation about the GatewayClass resource.
@@ -57,65 +106,149 @@ type GatewayClassStatus struct {
 	Valid bool
 	// ErrorMsg describe the error when the resource is invalid.
 	ErrorMsg string
+	// InvalidParameters shows that the resource is invalid because its parametersRef cannot be resolved.
+	InvalidParameters bool
 	// ObservedGeneration is the generation of the resource that was processed.
 	ObservedGeneration int64
 }
 
-// buildStatuses builds statuses from a graph.
-func buildStatuses(graph *graph) Statuses {
+// buildStatuses builds statuses from a graph. All Gateways share the same addresses, because NGINX serves all of them.
+func buildStatuses(graph *graph, addresses []v1alpha2.GatewayAddress) Statuses {
 	statuses := Statuses{
-		HTTPRouteStatuses:      make(map[types.NamespacedName]HTTPRouteStatus),
-		IgnoredGatewayStatuses: make(map[types.NamespacedName]IgnoredGatewayStatus),
+		GatewayStatuses:   make(map[types.NamespacedName]GatewayStatus),
+		HTTPRouteStatuses: make(map[types.NamespacedName]HTTPRouteStatus),
+		GRPCRouteStatuses: make(map[types.NamespacedName]GRPCRouteStatus),
+		TLSRouteStatuses:  make(map[types.NamespacedName]TLSRouteStatus),
+		TCPRouteStatuses:  make(map[types.NamespacedName]TCPRouteStatus),
+		UDPRouteStatuses:  make(map[types.NamespacedName]UDPRouteStatus),
 	}
 
 	if graph.GatewayClass != nil {
 		statuses.GatewayClassStatus = &GatewayClassStatus{
 			Valid:              graph.GatewayClass.Valid,
 			ErrorMsg:           graph.GatewayClass.ErrorMsg,
+			InvalidParameters:  graph.GatewayClass.InvalidParameters,
 			ObservedGeneration: graph.GatewayClass.Source.Generation,
 		}
 	}
 
 	gcValidAndExist := graph.GatewayClass != nil && graph.GatewayClass.Valid
 
-	if graph.Gateway != nil {
+	for nsname, gw := range graph.Gateways {
 		listenerStatuses := make(map[string]ListenerStatus)
 
-		for name, l := range graph.Gateway.Listeners {
+		for name, l := range gw.Listeners {
+			supportedKinds, _ := getSupportedKinds(l.Source)
+
 			listenerStatuses[name] = ListenerStatus{
 				Valid:          l.Valid && gcValidAndExist,
-				AttachedRoutes: int32(len(l.Routes)),
+				AttachedRoutes: int32(len(l.Routes) + len(l.GRPCRoutes) + len(l.TLSRoutes) + len(l.L4Routes)),
+				SupportedKinds: supportedKinds,
+				Conditions:     l.Conditions,
 			}
 		}
 
-		statuses.GatewayStatus = &GatewayStatus{
-			NsName:           getNamespacedName(graph.Gateway.Source),
+		statuses.GatewayStatuses[nsname] = GatewayStatus{
 			ListenerStatuses: listenerStatuses,
+			Addresses:        addresses,
 		}
 	}
 
-	for nsname, gw := range graph.IgnoredGateways {
-		statuses.IgnoredGatewayStatuses[nsname] = IgnoredGatewayStatus{ObservedGeneration: gw.Generation}
+	for nsname, r := range graph.Routes {
+		statuses.HTTPRouteStatuses[nsname] = HTTPRouteStatus{
+			ParentStatuses: buildParentStatuses(
+				r.ValidSectionNameRefs,
+				r.InvalidSectionNameRefs,
+				r.NotAllowedSectionNameRefs,
+				r.Conditions,
+				r.RefConditions,
+				gcValidAndExist,
+			),
+		}
 	}
 
-	for nsname, r := range graph.Routes {
-		parentStatuses := make(map[string]ParentStatus)
+	for nsname, r := range graph.GRPCRoutes {
+		statuses.GRPCRouteStatuses[nsname] = GRPCRouteStatus{
+			ParentStatuses: buildParentStatuses(
+				r.ValidSectionNameRefs,
+				r.InvalidSectionNameRefs,
+				r.NotAllowedSectionNameRefs,
+				r.Conditions,
+				r.RefConditions,
+				gcValidAndExist,
+			),
+		}
+	}
 
-		for ref := range r.ValidSectionNameRefs {
-			parentStatuses[ref] = ParentStatus{
-				Attached: gcValidAndExist, // Attached only when GatewayClass is valid and exists
-			}
+	for nsname, r := range graph.TLSRoutes {
+		statuses.TLSRouteStatuses[nsname] = TLSRouteStatus{
+			ParentStatuses: buildParentStatuses(
+				r.ValidSectionNameRefs,
+				r.InvalidSectionNameRefs,
+				r.NotAllowedSectionNameRefs,
+				r.Conditions,
+				r.RefConditions,
+				gcValidAndExist,
+			),
 		}
-		for ref := range r.InvalidSectionNameRefs {
-			parentStatuses[ref] = ParentStatus{
-				Attached: false,
-			}
+	}
+
+	for nsname, r := range graph.TCPRoutes {
+		statuses.TCPRouteStatuses[nsname] = TCPRouteStatus{
+			ParentStatuses: buildParentStatuses(
+				r.ValidSectionNameRefs,
+				r.InvalidSectionNameRefs,
+				r.NotAllowedSectionNameRefs,
+				r.Conditions,
+				r.RefConditions,
+				gcValidAndExist,
+			),
 		}
+	}
 
-		statuses.HTTPRouteStatuses[nsname] = HTTPRouteStatus{
-			ParentStatuses: parentStatuses,
+	for nsname, r := range graph.UDPRoutes {
+		statuses.UDPRouteStatuses[nsname] = UDPRouteStatus{
+			ParentStatuses: buildParentStatuses(
+				r.ValidSectionNameRefs,
+				r.InvalidSectionNameRefs,
+				r.NotAllowedSectionNameRefs,
+				r.Conditions,
+				r.RefConditions,
+				gcValidAndExist,
+			),
 		}
 	}
 
 	return statuses
 }
+
+func buildParentStatuses(
+	validRefs map[ParentRefKey]struct{},
+	invalidRefs map[ParentRefKey]struct{},
+	notAllowedRefs map[ParentRefKey]struct{},
+	conds []Condition,
+	refConds []Condition,
+	gcValidAndExist bool,
+) ParentStatuses {
+	parentStatuses := make(map[ParentRefKey]ParentStatus)
+
+	for ref := range validRefs {
+		parentStatuses[ref] = ParentStatus{
+			Attached:   gcValidAndExist, // Attached only when GatewayClass is valid and exists
+			Conditions: refConds,
+		}
+	}
+	for ref := range invalidRefs {
+		refParentConds := conds
+		if _, notAllowed := notAllowedRefs[ref]; notAllowed {
+			refParentConds = []Condition{newRouteNotAllowedByListenersCondition()}
+		}
+
+		parentStatuses[ref] = ParentStatus{
+			Attached:   false,
+			Conditions: append(append([]Condition(nil), refParentConds...), refConds...),
+		}
+	}
+
+	return parentStatuses
+}
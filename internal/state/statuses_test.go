@@ -10,34 +10,46 @@ import (
 )
 
 func TestBuildStatuses(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
 	listeners := map[string]*listener{
 		"listener-80-1": {
 			Valid: true,
 			Routes: map[types.NamespacedName]*route{
 				{Namespace: "test", Name: "hr-1"}: {}},
 		},
+		"listener-443-1": {
+			Valid:      false,
+			Conditions: []Condition{newListenerInvalidCertificateRefCondition("Secret test/secret does not exist")},
+			Routes:     map[types.NamespacedName]*route{},
+		},
 	}
 
 	routes := map[types.NamespacedName]*route{
 		{Namespace: "test", Name: "hr-1"}: {
-			ValidSectionNameRefs: map[string]struct{}{
-				"listener-80-1": {},
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 			},
-			InvalidSectionNameRefs: map[string]struct{}{
-				"listener-80-2": {},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-2"}: {},
 			},
 		},
 	}
 
+	invalidRouteConds := []Condition{newRouteUnsupportedValueCondition("invalid route")}
+
 	routesAllRefsInvalid := map[types.NamespacedName]*route{
 		{Namespace: "test", Name: "hr-1"}: {
-			InvalidSectionNameRefs: map[string]struct{}{
-				"listener-80-2": {},
-				"listener-80-1": {},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-2"}: {},
+				{Gateway: gwNsName, SectionName: "listener-80-1"}: {},
 			},
+			Conditions: invalidRouteConds,
 		},
 	}
 
+	addresses := []v1alpha2.GatewayAddress{newGatewayAddress(v1alpha2.IPAddressType, "10.0.0.1")}
+
 	gw := &v1alpha2.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "test",
@@ -45,11 +57,10 @@ func TestBuildStatuses(t *testing.T) {
 		},
 	}
 
-	ignoredGw := &v1alpha2.Gateway{
+	gw2 := &v1alpha2.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:  "test",
-			Name:       "ignored-gateway",
-			Generation: 1,
+			Namespace: "test",
+			Name:      "gateway-2",
 		},
 	}
 
@@ -66,12 +77,20 @@ func TestBuildStatuses(t *testing.T) {
 					},
 					Valid: true,
 				},
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1alpha2.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
+					{Namespace: "test", Name: "gateway-2"}: {
+						Source: gw2,
+						Listeners: map[string]*listener{
+							"listener-8080": {
+								Valid:  true,
+								Routes: map[types.NamespacedName]*route{},
+							},
+						},
+					},
 				},
 				Routes: routes,
 			},
@@ -80,71 +99,92 @@ func TestBuildStatuses(t *testing.T) {
 					Valid:              true,
 					ObservedGeneration: 1,
 				},
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          true,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						Addresses: addresses,
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          true,
+								AttachedRoutes: 1,
+							},
+							"listener-443-1": {
+								Valid:      false,
+								Conditions: []Condition{newListenerInvalidCertificateRefCondition("Secret test/secret does not exist")},
+							},
+						},
+					},
+					{Namespace: "test", Name: "gateway-2"}: {
+						Addresses: addresses,
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-8080": {
+								Valid: true,
+							},
 						},
 					},
-				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
 				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: ParentStatuses{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: true,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
 			msg: "normal case",
 		},
 		{
 			graph: &graph{
 				GatewayClass: nil,
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1alpha2.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
 				},
 				Routes: routes,
 			},
 			expected: Statuses{
 				GatewayClassStatus: nil,
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          false,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						Addresses: addresses,
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          false,
+								AttachedRoutes: 1,
+							},
+							"listener-443-1": {
+								Valid:      false,
+								Conditions: []Condition{newListenerInvalidCertificateRefCondition("Secret test/secret does not exist")},
+							},
 						},
 					},
 				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
-				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: ParentStatuses{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: false,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
 			msg: "gatewayclass doesn't exist",
 		},
@@ -157,12 +197,11 @@ func TestBuildStatuses(t *testing.T) {
 					Valid:    false,
 					ErrorMsg: "error",
 				},
-				Gateway: &gateway{
-					Source:    gw,
-					Listeners: listeners,
-				},
-				IgnoredGateways: map[types.NamespacedName]*v1alpha2.Gateway{
-					{Namespace: "test", Name: "ignored-gateway"}: ignoredGw,
+				Gateways: map[types.NamespacedName]*gateway{
+					gwNsName: {
+						Source:    gw,
+						Listeners: listeners,
+					},
 				},
 				Routes: routes,
 			},
@@ -172,30 +211,37 @@ func TestBuildStatuses(t *testing.T) {
 					ErrorMsg:           "error",
 					ObservedGeneration: 1,
 				},
-				GatewayStatus: &GatewayStatus{
-					NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-					ListenerStatuses: map[string]ListenerStatus{
-						"listener-80-1": {
-							Valid:          false,
-							AttachedRoutes: 1,
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+					gwNsName: {
+						Addresses: addresses,
+						ListenerStatuses: map[string]ListenerStatus{
+							"listener-80-1": {
+								Valid:          false,
+								AttachedRoutes: 1,
+							},
+							"listener-443-1": {
+								Valid:      false,
+								Conditions: []Condition{newListenerInvalidCertificateRefCondition("Secret test/secret does not exist")},
+							},
 						},
 					},
 				},
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{
-					{Namespace: "test", Name: "ignored-gateway"}: {ObservedGeneration: 1},
-				},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
+						ParentStatuses: ParentStatuses{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
 								Attached: false,
 							},
-							"listener-80-2": {
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
 								Attached: false,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
 			msg: "gatewayclass is not valid",
 		},
@@ -207,38 +253,350 @@ func TestBuildStatuses(t *testing.T) {
 					},
 					Valid: true,
 				},
-				Gateway:         nil,
-				IgnoredGateways: nil,
-				Routes:          routesAllRefsInvalid,
+				Routes: routesAllRefsInvalid,
 			},
 			expected: Statuses{
 				GatewayClassStatus: &GatewayClassStatus{
 					Valid:              true,
 					ObservedGeneration: 1,
 				},
-				GatewayStatus:          nil,
-				IgnoredGatewayStatuses: map[types.NamespacedName]IgnoredGatewayStatus{},
+				GatewayStatuses: map[types.NamespacedName]GatewayStatus{},
 				HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
 					{Namespace: "test", Name: "hr-1"}: {
-						ParentStatuses: map[string]ParentStatus{
-							"listener-80-1": {
-								Attached: false,
+						ParentStatuses: ParentStatuses{
+							{Gateway: gwNsName, SectionName: "listener-80-1"}: {
+								Attached:   false,
+								Conditions: invalidRouteConds,
 							},
-							"listener-80-2": {
-								Attached: false,
+							{Gateway: gwNsName, SectionName: "listener-80-2"}: {
+								Attached:   false,
+								Conditions: invalidRouteConds,
 							},
 						},
 					},
 				},
+				GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+				TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+				TCPRouteStatuses:  map[types.NamespacedName]TCPRouteStatus{},
+				UDPRouteStatuses:  map[types.NamespacedName]UDPRouteStatus{},
 			},
-			msg: "gateway and ignored gateways don't exist",
+			msg: "gateways don't exist",
 		},
 	}
 
 	for _, test := range tests {
-		result := buildStatuses(test.graph)
+		result := buildStatuses(test.graph, addresses)
 		if diff := cmp.Diff(test.expected, result); diff != "" {
 			t.Errorf("buildStatuses() '%v' mismatch (-want +got):\n%s", test.msg, diff)
 		}
 	}
 }
+
+func TestBuildStatusesTLSRoutes(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	tlsRoutes := map[types.NamespacedName]*tlsRoute{
+		{Namespace: "test", Name: "tr-1"}: {
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-443-tls"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+		},
+	}
+
+	g := &graph{
+		GatewayClass: &gatewayClass{
+			Source: &v1alpha2.GatewayClass{},
+			Valid:  true,
+		},
+		Gateways: map[types.NamespacedName]*gateway{
+			gwNsName: {
+				Source: &v1alpha2.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "gateway",
+					},
+				},
+				Listeners: map[string]*listener{
+					"listener-443-tls": {
+						Valid:     true,
+						TLSRoutes: tlsRoutes,
+					},
+				},
+			},
+		},
+		TLSRoutes: tlsRoutes,
+	}
+
+	expected := Statuses{
+		GatewayClassStatus: &GatewayClassStatus{
+			Valid: true,
+		},
+		GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+			gwNsName: {
+				ListenerStatuses: map[string]ListenerStatus{
+					"listener-443-tls": {
+						Valid:          true,
+						AttachedRoutes: 1,
+					},
+				},
+			},
+		},
+		HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{},
+		GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+		TLSRouteStatuses: map[types.NamespacedName]TLSRouteStatus{
+			{Namespace: "test", Name: "tr-1"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-443-tls"}: {
+						Attached: true,
+					},
+				},
+			},
+		},
+		TCPRouteStatuses: map[types.NamespacedName]TCPRouteStatus{},
+		UDPRouteStatuses: map[types.NamespacedName]UDPRouteStatus{},
+	}
+
+	result := buildStatuses(g, nil)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("buildStatuses() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildStatusesGRPCRoutes(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	// the HTTPRoute and the GRPCRoute have the same name
+	routes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "route-1"}: {
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+		},
+	}
+
+	attachedGRPCRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "route-1"}: {
+			GRPC: true,
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-443-https"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+		},
+	}
+
+	grpcRoutes := map[types.NamespacedName]*route{
+		{Namespace: "test", Name: "route-1"}: attachedGRPCRoutes[types.NamespacedName{Namespace: "test", Name: "route-1"}],
+		{Namespace: "test", Name: "gr-http"}: {
+			GRPC:                 true,
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-http"}: {},
+			},
+			NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-80-http"}: {},
+			},
+		},
+	}
+
+	g := &graph{
+		GatewayClass: &gatewayClass{
+			Source: &v1alpha2.GatewayClass{},
+			Valid:  true,
+		},
+		Gateways: map[types.NamespacedName]*gateway{
+			gwNsName: {
+				Source: &v1alpha2.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "gateway",
+					},
+				},
+				Listeners: map[string]*listener{
+					"listener-443-https": {
+						Valid:      true,
+						Routes:     routes,
+						GRPCRoutes: attachedGRPCRoutes,
+					},
+					"listener-80-http": {
+						Valid: true,
+					},
+				},
+			},
+		},
+		Routes:     routes,
+		GRPCRoutes: grpcRoutes,
+	}
+
+	expected := Statuses{
+		GatewayClassStatus: &GatewayClassStatus{
+			Valid: true,
+		},
+		GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+			gwNsName: {
+				ListenerStatuses: map[string]ListenerStatus{
+					"listener-443-https": {
+						Valid:          true,
+						AttachedRoutes: 2,
+					},
+					"listener-80-http": {
+						Valid: true,
+					},
+				},
+			},
+		},
+		HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{
+			{Namespace: "test", Name: "route-1"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {
+						Attached: true,
+					},
+				},
+			},
+		},
+		GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{
+			{Namespace: "test", Name: "route-1"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-443-https"}: {
+						Attached: true,
+					},
+				},
+			},
+			{Namespace: "test", Name: "gr-http"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-80-http"}: {
+						Conditions: []Condition{newRouteNotAllowedByListenersCondition()},
+					},
+				},
+			},
+		},
+		TLSRouteStatuses: map[types.NamespacedName]TLSRouteStatus{},
+		TCPRouteStatuses: map[types.NamespacedName]TCPRouteStatus{},
+		UDPRouteStatuses: map[types.NamespacedName]UDPRouteStatus{},
+	}
+
+	result := buildStatuses(g, nil)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("buildStatuses() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildStatusesL4Routes(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	tcpRoutes := map[types.NamespacedName]*l4Route{
+		{Namespace: "test", Name: "tcp-1"}: {
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+			},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{},
+			RefConditions: []Condition{
+				newRouteRefNotPermittedCondition("not permitted"),
+			},
+		},
+	}
+	udpRoutes := map[types.NamespacedName]*l4Route{
+		{Namespace: "test", Name: "udp-1"}: {
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+			},
+			Conditions: []Condition{
+				newRouteUnsupportedValueCondition("spec.rules: exactly one rule is supported, got 0"),
+			},
+		},
+		{Namespace: "test", Name: "udp-2"}: {
+			ValidSectionNameRefs: map[ParentRefKey]struct{}{},
+			InvalidSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+			},
+			NotAllowedSectionNameRefs: map[ParentRefKey]struct{}{
+				{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {},
+			},
+		},
+	}
+
+	g := &graph{
+		GatewayClass: &gatewayClass{
+			Source: &v1alpha2.GatewayClass{},
+			Valid:  true,
+		},
+		Gateways: map[types.NamespacedName]*gateway{
+			gwNsName: {
+				Source: &v1alpha2.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "gateway",
+					},
+				},
+				Listeners: map[string]*listener{
+					"listener-53-tcp": {
+						Valid:    true,
+						L4Routes: tcpRoutes,
+					},
+				},
+			},
+		},
+		TCPRoutes: tcpRoutes,
+		UDPRoutes: udpRoutes,
+	}
+
+	expected := Statuses{
+		GatewayClassStatus: &GatewayClassStatus{
+			Valid: true,
+		},
+		GatewayStatuses: map[types.NamespacedName]GatewayStatus{
+			gwNsName: {
+				ListenerStatuses: map[string]ListenerStatus{
+					"listener-53-tcp": {
+						Valid:          true,
+						AttachedRoutes: 1,
+					},
+				},
+			},
+		},
+		HTTPRouteStatuses: map[types.NamespacedName]HTTPRouteStatus{},
+		GRPCRouteStatuses: map[types.NamespacedName]GRPCRouteStatus{},
+		TLSRouteStatuses:  map[types.NamespacedName]TLSRouteStatus{},
+		TCPRouteStatuses: map[types.NamespacedName]TCPRouteStatus{
+			{Namespace: "test", Name: "tcp-1"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {
+						Attached: true,
+						Conditions: []Condition{
+							newRouteRefNotPermittedCondition("not permitted"),
+						},
+					},
+				},
+			},
+		},
+		UDPRouteStatuses: map[types.NamespacedName]UDPRouteStatus{
+			{Namespace: "test", Name: "udp-1"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {
+						Attached: false,
+						Conditions: []Condition{
+							newRouteUnsupportedValueCondition("spec.rules: exactly one rule is supported, got 0"),
+						},
+					},
+				},
+			},
+			{Namespace: "test", Name: "udp-2"}: {
+				ParentStatuses: ParentStatuses{
+					{Gateway: gwNsName, SectionName: "listener-53-tcp"}: {
+						Attached: false,
+						Conditions: []Condition{
+							newRouteNotAllowedByListenersCondition(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := buildStatuses(g, nil)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("buildStatuses() mismatch (-want +got):\n%s", diff)
+	}
+}
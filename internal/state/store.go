@@ -1,8 +1,11 @@
 package state
 
 import (
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	nginxgwv1alpha1 "github.com/nginxinc/nginx-kubernetes-gateway/pkg/apis/gateway/v1alpha1"
 )
 
 // store contains the resources that represent the state of the Gateway.
@@ -10,11 +13,70 @@ type store struct {
 	gc         *v1alpha2.GatewayClass
 	gateways   map[types.NamespacedName]*v1alpha2.Gateway
 	httpRoutes map[types.NamespacedName]*v1alpha2.HTTPRoute
+	grpcRoutes map[types.NamespacedName]*v1alpha2.GRPCRoute
+	tlsRoutes  map[types.NamespacedName]*v1alpha2.TLSRoute
+	tcpRoutes  map[types.NamespacedName]*v1alpha2.TCPRoute
+	udpRoutes  map[types.NamespacedName]*v1alpha2.UDPRoute
+	secrets    map[types.NamespacedName]*apiv1.Secret
+
+	referenceGrants map[types.NamespacedName]*v1alpha2.ReferenceGrant
+	// namespaces holds the Namespaces, which are cluster-scoped, so that the key only includes the name.
+	namespaces map[types.NamespacedName]*apiv1.Namespace
+	// gatewayConfigs holds the GatewayConfigs, which are cluster-scoped, so that the key only includes the name.
+	gatewayConfigs map[types.NamespacedName]*nginxgwv1alpha1.GatewayConfig
+	// gatewayService is the Service that exposes NGINX. It is nil if the Service is not configured or doesn't exist.
+	gatewayService *apiv1.Service
 }
 
 func newStore() *store {
 	return &store{
 		gateways:   make(map[types.NamespacedName]*v1alpha2.Gateway),
 		httpRoutes: make(map[types.NamespacedName]*v1alpha2.HTTPRoute),
+		grpcRoutes: make(map[types.NamespacedName]*v1alpha2.GRPCRoute),
+		tlsRoutes:  make(map[types.NamespacedName]*v1alpha2.TLSRoute),
+		tcpRoutes:  make(map[types.NamespacedName]*v1alpha2.TCPRoute),
+		udpRoutes:  make(map[types.NamespacedName]*v1alpha2.UDPRoute),
+		secrets:    make(map[types.NamespacedName]*apiv1.Secret),
+
+		referenceGrants: make(map[types.NamespacedName]*v1alpha2.ReferenceGrant),
+		namespaces:      make(map[types.NamespacedName]*apiv1.Namespace),
+		gatewayConfigs:  make(map[types.NamespacedName]*nginxgwv1alpha1.GatewayConfig),
+	}
+}
+
+// isReferencedSecret returns true if the Secret is referenced by a listener of a Gateway of the GatewayClass.
+func (s *store) isReferencedSecret(nsname types.NamespacedName, gcName string) bool {
+	for _, gw := range s.gateways {
+		if string(gw.Spec.GatewayClassName) != gcName {
+			continue
+		}
+
+		for _, l := range gw.Spec.Listeners {
+			if l.TLS == nil {
+				continue
+			}
+
+			for _, ref := range l.TLS.CertificateRefs {
+				ns := gw.Namespace
+				if ref.Namespace != nil {
+					ns = string(*ref.Namespace)
+				}
+
+				if ns == nsname.Namespace && string(ref.Name) == nsname.Name {
+					return true
+				}
+			}
+		}
 	}
+
+	return false
+}
+
+// isReferencedGatewayConfig returns true if the GatewayConfig is referenced by the parametersRef of the GatewayClass.
+func (s *store) isReferencedGatewayConfig(nsname types.NamespacedName) bool {
+	if s.gc == nil || s.gc.Spec.ParametersRef == nil {
+		return false
+	}
+
+	return s.gc.Spec.ParametersRef.Name == nsname.Name
 }
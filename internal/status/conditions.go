@@ -0,0 +1,29 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// convertConditions converts the conditions from the state package to metav1.Conditions.
+func convertConditions(
+	conds []state.Condition,
+	observedGeneration int64,
+	transitionTime metav1.Time,
+) []metav1.Condition {
+	apiConds := make([]metav1.Condition, 0, len(conds))
+
+	for _, c := range conds {
+		apiConds = append(apiConds, metav1.Condition{
+			Type:               c.Type,
+			Status:             c.Status,
+			ObservedGeneration: observedGeneration,
+			LastTransitionTime: transitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+
+	return apiConds
+}
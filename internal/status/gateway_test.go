@@ -7,7 +7,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
 
@@ -17,32 +19,62 @@ func TestPrepareGatewayStatus(t *testing.T) {
 			"valid-listener": {
 				Valid:          true,
 				AttachedRoutes: 2,
+				SupportedKinds: []v1alpha2.RouteGroupKind{
+					{
+						Kind: "HTTPRoute",
+					},
+				},
 			},
 			"invalid-listener": {
 				Valid:          false,
 				AttachedRoutes: 1,
+				Conditions: []state.Condition{
+					{
+						Type:    string(v1beta1.ListenerConditionDetached),
+						Status:  metav1.ConditionTrue,
+						Reason:  string(v1beta1.ListenerReasonUnsupportedProtocol),
+						Message: "unsupported protocol",
+					},
+				},
+			},
+		},
+		Addresses: []v1alpha2.GatewayAddress{
+			{
+				Type:  (*v1alpha2.AddressType)(helpers.GetStringPointer(string(v1alpha2.IPAddressType))),
+				Value: "10.0.0.1",
 			},
-		}}
+		},
+	}
 
 	transitionTime := metav1.NewTime(time.Now())
 
 	expected := v1alpha2.GatewayStatus{
+		Addresses: []v1alpha2.GatewayAddress{
+			{
+				Type:  (*v1alpha2.AddressType)(helpers.GetStringPointer(string(v1alpha2.IPAddressType))),
+				Value: "10.0.0.1",
+			},
+		},
 		Listeners: []v1alpha2.ListenerStatus{
 			{
-				Name: "invalid-listener",
-				SupportedKinds: []v1alpha2.RouteGroupKind{
-					{
-						Kind: "HTTPRoute",
-					},
-				},
+				Name:           "invalid-listener",
+				SupportedKinds: []v1alpha2.RouteGroupKind{},
 				AttachedRoutes: 1,
 				Conditions: []metav1.Condition{
 					{
-						Type:               string(v1alpha2.ListenerConditionReady),
+						Type:               string(v1beta1.ListenerConditionReady),
 						Status:             metav1.ConditionFalse,
 						ObservedGeneration: 123,
 						LastTransitionTime: transitionTime,
-						Reason:             string(v1alpha2.ListenerReasonInvalid),
+						Reason:             string(v1beta1.ListenerReasonInvalid),
+					},
+					{
+						Type:               string(v1beta1.ListenerConditionDetached),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 123,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.ListenerReasonUnsupportedProtocol),
+						Message:            "unsupported protocol",
 					},
 				},
 			},
@@ -56,11 +88,11 @@ func TestPrepareGatewayStatus(t *testing.T) {
 				AttachedRoutes: 2,
 				Conditions: []metav1.Condition{
 					{
-						Type:               string(v1alpha2.ListenerConditionReady),
+						Type:               string(v1beta1.ListenerConditionReady),
 						Status:             metav1.ConditionTrue,
 						ObservedGeneration: 123,
 						LastTransitionTime: transitionTime,
-						Reason:             string(v1alpha2.ListenerReasonReady),
+						Reason:             string(v1beta1.ListenerReasonReady),
 					},
 				},
 			},
@@ -72,29 +104,3 @@ func TestPrepareGatewayStatus(t *testing.T) {
 		t.Errorf("prepareGatewayStatus() mismatch (-want +got):\n%s", diff)
 	}
 }
-
-func TestPrepareIgnoredGatewayStatus(t *testing.T) {
-	status := state.IgnoredGatewayStatus{
-		ObservedGeneration: 1,
-	}
-
-	transitionTime := metav1.NewTime(time.Now())
-
-	expected := v1alpha2.GatewayStatus{
-		Conditions: []metav1.Condition{
-			{
-				Type:               string(v1alpha2.GatewayConditionReady),
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: status.ObservedGeneration,
-				LastTransitionTime: transitionTime,
-				Reason:             string(GetawayReasonGatewayConflict),
-				Message:            GatewayMessageGatewayConflict,
-			},
-		},
-	}
-
-	result := prepareIgnoredGatewayStatus(status, transitionTime)
-	if diff := cmp.Diff(expected, result); diff != "" {
-		t.Errorf("prepareIgnoredGatewayStatus() mismatch (-want +got):\n%s", diff)
-	}
-}
@@ -5,6 +5,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
@@ -16,20 +17,26 @@ func prepareGatewayClassStatus(status state.GatewayClassStatus, transitionTime m
 		msg        string
 	)
 
+	reason := v1beta1.GatewayClassReasonAccepted
+
 	if status.Valid {
 		condStatus = metav1.ConditionTrue
 		msg = "GatewayClass has been accepted"
 	} else {
 		condStatus = metav1.ConditionFalse
 		msg = fmt.Sprintf("GatewayClass has been rejected: %s", status.ErrorMsg)
+
+		if status.InvalidParameters {
+			reason = v1beta1.GatewayClassReasonInvalidParameters
+		}
 	}
 
 	cond := metav1.Condition{
-		Type:               string(v1alpha2.GatewayClassConditionStatusAccepted),
+		Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
 		Status:             condStatus,
 		ObservedGeneration: status.ObservedGeneration,
 		LastTransitionTime: transitionTime,
-		Reason:             string(v1alpha2.GatewayClassReasonAccepted),
+		Reason:             string(reason),
 		Message:            msg,
 	}
 
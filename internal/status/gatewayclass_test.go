@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
@@ -27,11 +28,11 @@ func TestPrepareGatewayClassStatus(t *testing.T) {
 			expected: v1alpha2.GatewayClassStatus{
 				Conditions: []metav1.Condition{
 					{
-						Type:               string(v1alpha2.GatewayClassConditionStatusAccepted),
+						Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
 						Status:             metav1.ConditionTrue,
 						ObservedGeneration: 1,
 						LastTransitionTime: transitionTime,
-						Reason:             string(v1alpha2.GatewayClassReasonAccepted),
+						Reason:             string(v1beta1.GatewayClassReasonAccepted),
 						Message:            "GatewayClass has been accepted",
 					},
 				},
@@ -47,17 +48,38 @@ func TestPrepareGatewayClassStatus(t *testing.T) {
 			expected: v1alpha2.GatewayClassStatus{
 				Conditions: []metav1.Condition{
 					{
-						Type:               string(v1alpha2.GatewayClassConditionStatusAccepted),
+						Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
 						Status:             metav1.ConditionFalse,
 						ObservedGeneration: 2,
 						LastTransitionTime: transitionTime,
-						Reason:             string(v1alpha2.GatewayClassReasonAccepted),
+						Reason:             string(v1beta1.GatewayClassReasonAccepted),
 						Message:            "GatewayClass has been rejected: error",
 					},
 				},
 			},
 			msg: "invalid GatewayClass",
 		},
+		{
+			status: state.GatewayClassStatus{
+				Valid:              false,
+				ErrorMsg:           "GatewayConfig nginx doesn't exist",
+				InvalidParameters:  true,
+				ObservedGeneration: 3,
+			},
+			expected: v1alpha2.GatewayClassStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 3,
+						LastTransitionTime: transitionTime,
+						Reason:             string(v1beta1.GatewayClassReasonInvalidParameters),
+						Message:            "GatewayClass has been rejected: GatewayConfig nginx doesn't exist",
+					},
+				},
+			},
+			msg: "GatewayClass with invalid parameters",
+		},
 	}
 
 	for _, test := range tests {
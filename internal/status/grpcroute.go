@@ -0,0 +1,19 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// prepareGRPCRouteStatus prepares the status for a GRPCRoute resource.
+func prepareGRPCRouteStatus(
+	status state.GRPCRouteStatus,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.GRPCRouteStatus {
+	return v1alpha2.GRPCRouteStatus{
+		RouteStatus: prepareRouteStatus(status.ParentStatuses, gatewayCtlrName, transitionTime),
+	}
+}
@@ -0,0 +1,89 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+func TestPrepareGRPCRouteStatus(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
+	status := state.GRPCRouteStatus{
+		ParentStatuses: state.ParentStatuses{
+			{Gateway: gwNsName, SectionName: "attached"}: {
+				Attached: true,
+			},
+			{Gateway: gwNsName, SectionName: "not-attached-invalid"}: {
+				Attached: false,
+				Conditions: []state.Condition{
+					{
+						Type:    string(v1beta1.RouteConditionAccepted),
+						Status:  metav1.ConditionFalse,
+						Reason:  "UnsupportedValue",
+						Message: "spec.rules[0].filters[0].type: unsupported type \"RequestMirror\"",
+					},
+				},
+			},
+		},
+	}
+
+	gatewayCtlrName := "test.example.com"
+
+	transitionTime := metav1.NewTime(time.Now())
+
+	expected := v1alpha2.GRPCRouteStatus{
+		RouteStatus: v1alpha2.RouteStatus{
+			Parents: []v1alpha2.RouteParentStatus{
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("attached")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("not-attached-invalid")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "UnsupportedValue",
+							Message:            "spec.rules[0].filters[0].type: unsupported type \"RequestMirror\"",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := prepareGRPCRouteStatus(status, gatewayCtlrName, transitionTime)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("prepareGRPCRouteStatus() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -4,74 +4,121 @@ import (
 	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
 
 // prepareHTTPRouteStatus prepares the status for an HTTPRoute resource.
-// FIXME(pleshakov): Be compliant with in the Gateway API.
-// Currently, we only support simple attached/not attached status per each parentRef.
-// Extend support to cover more cases.
 func prepareHTTPRouteStatus(
 	status state.HTTPRouteStatus,
-	gwNsName types.NamespacedName,
 	gatewayCtlrName string,
 	transitionTime metav1.Time,
 ) v1alpha2.HTTPRouteStatus {
-	parents := make([]v1alpha2.RouteParentStatus, 0, len(status.ParentStatuses))
+	return v1alpha2.HTTPRouteStatus{
+		RouteStatus: prepareRouteStatus(status.ParentStatuses, gatewayCtlrName, transitionTime),
+	}
+}
+
+// prepareRouteStatus prepares the common status of a route resource from the statuses of its parentRefs.
+// FIXME(pleshakov): Be compliant with in the Gateway API.
+// Currently, we only support simple attached/not attached status per each parentRef.
+// Extend support to cover more cases.
+func prepareRouteStatus(
+	parentStatuses state.ParentStatuses,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.RouteStatus {
+	parents := make([]v1alpha2.RouteParentStatus, 0, len(parentStatuses))
 
-	// FIXME(pleshakov) Maintain the order from the HTTPRoute resource
-	names := make([]string, 0, len(status.ParentStatuses))
-	for name := range status.ParentStatuses {
-		names = append(names, name)
+	// FIXME(pleshakov) Maintain the order from the route resource
+	keys := make([]state.ParentRefKey, 0, len(parentStatuses))
+	for key := range parentStatuses {
+		keys = append(keys, key)
 	}
-	sort.Strings(names)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Gateway != keys[j].Gateway {
+			if keys[i].Gateway.Namespace != keys[j].Gateway.Namespace {
+				return keys[i].Gateway.Namespace < keys[j].Gateway.Namespace
+			}
+			return keys[i].Gateway.Name < keys[j].Gateway.Name
+		}
+		return keys[i].SectionName < keys[j].SectionName
+	})
 
-	for _, name := range names {
-		ps := status.ParentStatuses[name]
+	for _, key := range keys {
+		ps := parentStatuses[key]
 
 		var (
 			status metav1.ConditionStatus
-			reason string // FIXME(pleshakov) use RouteConditionReason once we upgrade to v1beta1
+			reason v1beta1.RouteConditionReason
 		)
 
+		// unless the conditions of the parent explain it more precisely, a route doesn't attach to the parent because
+		// the parentRef doesn't match any valid listener
 		if ps.Attached {
 			status = metav1.ConditionTrue
-			reason = "Accepted" // FIXME(pleshakov): use RouteReasonAccepted once we upgrade to v1beta1
+			reason = v1beta1.RouteReasonAccepted
 		} else {
 			status = metav1.ConditionFalse
-			reason = "NotAttached" // FIXME(pleshakov): use a more specific message from the defined constants (available in v1beta1)
+			reason = v1beta1.RouteReasonNoMatchingParent
+		}
+
+		// the empty name represents a parentRef without a section name
+		var sectionName *v1alpha2.SectionName
+		if key.SectionName != "" {
+			sn := v1alpha2.SectionName(key.SectionName)
+			sectionName = &sn
 		}
 
-		sectionName := name
+		gwNamespace := key.Gateway.Namespace
 
 		p := v1alpha2.RouteParentStatus{
-			ParentRef: v1alpha2.ParentRef{
-				Namespace:   (*v1alpha2.Namespace)(&gwNsName.Namespace),
-				Name:        v1alpha2.ObjectName(gwNsName.Name),
-				SectionName: (*v1alpha2.SectionName)(&sectionName),
+			ParentRef: v1alpha2.ParentReference{
+				Namespace:   (*v1alpha2.Namespace)(&gwNamespace),
+				Name:        v1alpha2.ObjectName(key.Gateway.Name),
+				SectionName: sectionName,
 			},
 			ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
 			Conditions: []metav1.Condition{
 				{
-					Type:   string(v1alpha2.ConditionRouteAccepted),
+					Type:   string(v1beta1.RouteConditionAccepted),
 					Status: status,
-					// FIXME(pleshakov) Set the observed generation to the last processed generation of the HTTPRoute resource.
+					// FIXME(pleshakov) Set the observed generation to the last processed generation of the route resource.
 					ObservedGeneration: 123,
 					LastTransitionTime: transitionTime,
-					Reason:             reason,
+					Reason:             string(reason),
 					Message:            "", // FIXME(pleshakov): Figure out a good message
 				},
 			},
 		}
+
+		// an Accepted condition of the parent explains why the route doesn't attach to it more precisely than
+		// the generic condition above. The other conditions, like ResolvedRefs, are reported alongside it.
+		if len(ps.Conditions) > 0 {
+			conds := convertConditions(ps.Conditions, 123, transitionTime)
+
+			if hasConditionType(ps.Conditions, string(v1beta1.RouteConditionAccepted)) {
+				p.Conditions = conds
+			} else {
+				p.Conditions = append(p.Conditions, conds...)
+			}
+		}
 		parents = append(parents, p)
 	}
 
-	return v1alpha2.HTTPRouteStatus{
-		RouteStatus: v1alpha2.RouteStatus{
-			Parents: parents,
-		},
+	return v1alpha2.RouteStatus{
+		Parents: parents,
+	}
+}
+
+func hasConditionType(conds []state.Condition, condType string) bool {
+	for _, c := range conds {
+		if c.Type == condType {
+			return true
+		}
 	}
+
+	return false
 }
@@ -8,24 +8,54 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
 )
 
 func TestPrepareHTTPRouteStatus(t *testing.T) {
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
+
 	status := state.HTTPRouteStatus{
-		ParentStatuses: map[string]state.ParentStatus{
-			"attached": {
+		ParentStatuses: state.ParentStatuses{
+			{Gateway: gwNsName}: {
+				Attached: true,
+			},
+			{Gateway: gwNsName, SectionName: "attached"}: {
+				Attached: true,
+			},
+			{Gateway: gwNsName, SectionName: "attached-ref-not-permitted"}: {
 				Attached: true,
+				Conditions: []state.Condition{
+					{
+						Type:    string(v1beta1.RouteConditionResolvedRefs),
+						Status:  metav1.ConditionFalse,
+						Reason:  "RefNotPermitted",
+						Message: "not permitted",
+					},
+				},
 			},
-			"not-attached": {
+			{Gateway: gwNsName, SectionName: "not-attached"}: {
 				Attached: false,
 			},
+			{Gateway: gwNsName, SectionName: "not-attached-invalid"}: {
+				Attached: false,
+				Conditions: []state.Condition{
+					{
+						Type:    string(v1beta1.RouteConditionAccepted),
+						Status:  metav1.ConditionFalse,
+						Reason:  "UnsupportedValue",
+						Message: "invalid regex",
+					},
+				},
+			},
+			{Gateway: types.NamespacedName{Namespace: "test", Name: "another-gateway"}, SectionName: "attached"}: {
+				Attached: true,
+			},
 		},
 	}
 
-	gwNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
 	gatewayCtlrName := "test.example.com"
 
 	transitionTime := metav1.NewTime(time.Now())
@@ -34,7 +64,40 @@ func TestPrepareHTTPRouteStatus(t *testing.T) {
 		RouteStatus: v1alpha2.RouteStatus{
 			Parents: []v1alpha2.RouteParentStatus{
 				{
-					ParentRef: v1alpha2.ParentRef{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "another-gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("attached")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace: (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:      "gateway",
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
 						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 						Name:        "gateway",
 						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("attached")),
@@ -42,16 +105,41 @@ func TestPrepareHTTPRouteStatus(t *testing.T) {
 					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
 					Conditions: []metav1.Condition{
 						{
-							Type:               string(v1alpha2.ConditionRouteAccepted),
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "Accepted",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("attached-ref-not-permitted")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
 							Status:             metav1.ConditionTrue,
 							ObservedGeneration: 123,
 							LastTransitionTime: transitionTime,
 							Reason:             "Accepted",
 						},
+						{
+							Type:               string(v1beta1.RouteConditionResolvedRefs),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "RefNotPermitted",
+							Message:            "not permitted",
+						},
 					},
 				},
 				{
-					ParentRef: v1alpha2.ParentRef{
+					ParentRef: v1alpha2.ParentReference{
 						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
 						Name:        "gateway",
 						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("not-attached")),
@@ -59,11 +147,29 @@ func TestPrepareHTTPRouteStatus(t *testing.T) {
 					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
 					Conditions: []metav1.Condition{
 						{
-							Type:               string(v1alpha2.ConditionRouteAccepted),
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: 123,
+							LastTransitionTime: transitionTime,
+							Reason:             "NoMatchingParent",
+						},
+					},
+				},
+				{
+					ParentRef: v1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        "gateway",
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("not-attached-invalid")),
+					},
+					ControllerName: v1alpha2.GatewayController(gatewayCtlrName),
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
 							Status:             metav1.ConditionFalse,
 							ObservedGeneration: 123,
 							LastTransitionTime: transitionTime,
-							Reason:             "NotAttached",
+							Reason:             "UnsupportedValue",
+							Message:            "invalid regex",
 						},
 					},
 				},
@@ -71,7 +177,7 @@ func TestPrepareHTTPRouteStatus(t *testing.T) {
 		},
 	}
 
-	result := prepareHTTPRouteStatus(status, gwNsName, gatewayCtlrName, transitionTime)
+	result := prepareHTTPRouteStatus(status, gatewayCtlrName, transitionTime)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("prepareHTTPRouteStatus() mismatch (-want +got):\n%s", diff)
 	}
@@ -0,0 +1,19 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// prepareTCPRouteStatus prepares the status for a TCPRoute resource.
+func prepareTCPRouteStatus(
+	status state.TCPRouteStatus,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.TCPRouteStatus {
+	return v1alpha2.TCPRouteStatus{
+		RouteStatus: prepareRouteStatus(status.ParentStatuses, gatewayCtlrName, transitionTime),
+	}
+}
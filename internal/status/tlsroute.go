@@ -0,0 +1,19 @@
+package status
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
+)
+
+// prepareTLSRouteStatus prepares the status for a TLSRoute resource.
+func prepareTLSRouteStatus(
+	status state.TLSRouteStatus,
+	gatewayCtlrName string,
+	transitionTime metav1.Time,
+) v1alpha2.TLSRouteStatus {
+	return v1alpha2.TLSRouteStatus{
+		RouteStatus: prepareRouteStatus(status.ParentStatuses, gatewayCtlrName, transitionTime),
+	}
+}
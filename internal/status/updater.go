@@ -93,14 +93,7 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 		})
 	}
 
-	if statuses.GatewayStatus != nil {
-		upd.update(ctx, statuses.GatewayStatus.NsName, &v1alpha2.Gateway{}, func(object client.Object) {
-			gw := object.(*v1alpha2.Gateway)
-			gw.Status = prepareGatewayStatus(*statuses.GatewayStatus, upd.cfg.Clock.Now())
-		})
-	}
-
-	for nsname, gs := range statuses.IgnoredGatewayStatuses {
+	for nsname, gs := range statuses.GatewayStatuses {
 		select {
 		case <-ctx.Done():
 			return
@@ -109,7 +102,7 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 
 		upd.update(ctx, nsname, &v1alpha2.Gateway{}, func(object client.Object) {
 			gw := object.(*v1alpha2.Gateway)
-			gw.Status = prepareIgnoredGatewayStatus(gs, upd.cfg.Clock.Now())
+			gw.Status = prepareGatewayStatus(gs, upd.cfg.Clock.Now())
 		})
 	}
 
@@ -122,8 +115,59 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses state.Statuses) {
 
 		upd.update(ctx, nsname, &v1alpha2.HTTPRoute{}, func(object client.Object) {
 			hr := object.(*v1alpha2.HTTPRoute)
-			// statuses.GatewayStatus is never nil when len(statuses.HTTPRouteStatuses) > 0
-			hr.Status = prepareHTTPRouteStatus(rs, statuses.GatewayStatus.NsName, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+			hr.Status = prepareHTTPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.GRPCRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.GRPCRoute{}, func(object client.Object) {
+			gr := object.(*v1alpha2.GRPCRoute)
+			gr.Status = prepareGRPCRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.TLSRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.TLSRoute{}, func(object client.Object) {
+			tr := object.(*v1alpha2.TLSRoute)
+			tr.Status = prepareTLSRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.TCPRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.TCPRoute{}, func(object client.Object) {
+			tr := object.(*v1alpha2.TCPRoute)
+			tr.Status = prepareTCPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
+		})
+	}
+
+	for nsname, rs := range statuses.UDPRouteStatuses {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		upd.update(ctx, nsname, &v1alpha2.UDPRoute{}, func(object client.Object) {
+			ur := object.(*v1alpha2.UDPRoute)
+			ur.Status = prepareUDPRouteStatus(rs, upd.cfg.GatewayCtlrName, upd.cfg.Clock.Now())
 		})
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/state"
@@ -60,9 +61,9 @@ var _ = Describe("Updater", func() {
 
 	Describe("Process status updates", Ordered, func() {
 		var (
-			gc            *v1alpha2.GatewayClass
-			gw, ignoredGw *v1alpha2.Gateway
-			hr            *v1alpha2.HTTPRoute
+			gc      *v1alpha2.GatewayClass
+			gw, gw2 *v1alpha2.Gateway
+			hr      *v1alpha2.HTTPRoute
 
 			createStatuses = func(valid bool, generation int64) state.Statuses {
 				var gcErrorMsg string
@@ -70,30 +71,37 @@ var _ = Describe("Updater", func() {
 					gcErrorMsg = "error"
 				}
 
+				gs := state.GatewayStatus{
+					ListenerStatuses: map[string]state.ListenerStatus{
+						"http": {
+							Valid:          valid,
+							AttachedRoutes: 1,
+							SupportedKinds: []v1alpha2.RouteGroupKind{
+								{
+									Kind: "HTTPRoute",
+								},
+							},
+						},
+					},
+				}
+
 				return state.Statuses{
 					GatewayClassStatus: &state.GatewayClassStatus{
 						Valid:              valid,
 						ErrorMsg:           gcErrorMsg,
 						ObservedGeneration: generation,
 					},
-					GatewayStatus: &state.GatewayStatus{
-						NsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
-						ListenerStatuses: map[string]state.ListenerStatus{
-							"http": {
-								Valid:          valid,
-								AttachedRoutes: 1,
-							},
-						},
-					},
-					IgnoredGatewayStatuses: map[types.NamespacedName]state.IgnoredGatewayStatus{
-						{Namespace: "test", Name: "ignored-gateway"}: {
-							ObservedGeneration: generation,
-						},
+					GatewayStatuses: map[types.NamespacedName]state.GatewayStatus{
+						{Namespace: "test", Name: "gateway"}:   gs,
+						{Namespace: "test", Name: "gateway-2"}: gs,
 					},
 					HTTPRouteStatuses: map[types.NamespacedName]state.HTTPRouteStatus{
 						{Namespace: "test", Name: "route1"}: {
-							ParentStatuses: map[string]state.ParentStatus{
-								"http": {
+							ParentStatuses: state.ParentStatuses{
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway"}, SectionName: "http"}: {
+									Attached: valid,
+								},
+								{Gateway: types.NamespacedName{Namespace: "test", Name: "gateway-2"}, SectionName: "http"}: {
 									Attached: valid,
 								},
 							},
@@ -114,11 +122,11 @@ var _ = Describe("Updater", func() {
 					Status: v1alpha2.GatewayClassStatus{
 						Conditions: []metav1.Condition{
 							{
-								Type:               string(v1alpha2.GatewayClassConditionStatusAccepted),
+								Type:               string(v1beta1.GatewayClassConditionStatusAccepted),
 								Status:             status,
 								ObservedGeneration: generation,
 								LastTransitionTime: fakeClockTime,
-								Reason:             string(v1alpha2.GatewayClassReasonAccepted),
+								Reason:             string(v1beta1.GatewayClassReasonAccepted),
 								Message:            msg,
 							},
 						},
@@ -126,11 +134,11 @@ var _ = Describe("Updater", func() {
 				}
 			}
 
-			createExpectedGw = func(status metav1.ConditionStatus, reason string) *v1alpha2.Gateway {
+			createExpectedGw = func(name string, status metav1.ConditionStatus, reason string) *v1alpha2.Gateway {
 				return &v1alpha2.Gateway{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "test",
-						Name:      "gateway",
+						Name:      name,
 					},
 					TypeMeta: metav1.TypeMeta{
 						Kind:       "Gateway",
@@ -148,7 +156,7 @@ var _ = Describe("Updater", func() {
 								AttachedRoutes: 1,
 								Conditions: []metav1.Condition{
 									{
-										Type:               string(v1alpha2.ListenerConditionReady),
+										Type:               string(v1beta1.ListenerConditionReady),
 										Status:             status,
 										ObservedGeneration: 123,
 										LastTransitionTime: fakeClockTime,
@@ -161,26 +169,21 @@ var _ = Describe("Updater", func() {
 				}
 			}
 
-			createExpectedIgnoredGw = func() *v1alpha2.Gateway {
-				return &v1alpha2.Gateway{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "test",
-						Name:      "ignored-gateway",
-					},
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "Gateway",
-						APIVersion: "gateway.networking.k8s.io/v1alpha2",
+			createExpectedParent = func(gwName string) gatewayv1alpha2.RouteParentStatus {
+				return gatewayv1alpha2.RouteParentStatus{
+					ControllerName: gatewayv1alpha2.GatewayController(gatewayCtrlName),
+					ParentRef: gatewayv1alpha2.ParentReference{
+						Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
+						Name:        gatewayv1alpha2.ObjectName(gwName),
+						SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("http")),
 					},
-					Status: v1alpha2.GatewayStatus{
-						Conditions: []metav1.Condition{
-							{
-								Type:               string(v1alpha2.GatewayConditionReady),
-								Status:             metav1.ConditionFalse,
-								ObservedGeneration: 1,
-								LastTransitionTime: fakeClockTime,
-								Reason:             string(status.GetawayReasonGatewayConflict),
-								Message:            status.GatewayMessageGatewayConflict,
-							},
+					Conditions: []metav1.Condition{
+						{
+							Type:               string(v1beta1.RouteConditionAccepted),
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: 123,
+							LastTransitionTime: fakeClockTime,
+							Reason:             "Accepted",
 						},
 					},
 				}
@@ -199,23 +202,8 @@ var _ = Describe("Updater", func() {
 					Status: gatewayv1alpha2.HTTPRouteStatus{
 						RouteStatus: gatewayv1alpha2.RouteStatus{
 							Parents: []gatewayv1alpha2.RouteParentStatus{
-								{
-									ControllerName: gatewayv1alpha2.GatewayController(gatewayCtrlName),
-									ParentRef: gatewayv1alpha2.ParentRef{
-										Namespace:   (*v1alpha2.Namespace)(helpers.GetStringPointer("test")),
-										Name:        "gateway",
-										SectionName: (*v1alpha2.SectionName)(helpers.GetStringPointer("http")),
-									},
-									Conditions: []metav1.Condition{
-										{
-											Type:               string(gatewayv1alpha2.ConditionRouteAccepted),
-											Status:             metav1.ConditionTrue,
-											ObservedGeneration: 123,
-											LastTransitionTime: fakeClockTime,
-											Reason:             "Accepted",
-										},
-									},
-								},
+								createExpectedParent("gateway"),
+								createExpectedParent("gateway-2"),
 							},
 						},
 					},
@@ -243,10 +231,10 @@ var _ = Describe("Updater", func() {
 					APIVersion: "gateway.networking.k8s.io/v1alpha2",
 				},
 			}
-			ignoredGw = &v1alpha2.Gateway{
+			gw2 = &v1alpha2.Gateway{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "test",
-					Name:      "ignored-gateway",
+					Name:      "gateway-2",
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Gateway",
@@ -268,7 +256,7 @@ var _ = Describe("Updater", func() {
 		It("should create resources in the API server", func() {
 			Expect(client.Create(context.Background(), gc)).Should(Succeed())
 			Expect(client.Create(context.Background(), gw)).Should(Succeed())
-			Expect(client.Create(context.Background(), ignoredGw)).Should(Succeed())
+			Expect(client.Create(context.Background(), gw2)).Should(Succeed())
 			Expect(client.Create(context.Background(), hr)).Should(Succeed())
 		})
 
@@ -278,7 +266,7 @@ var _ = Describe("Updater", func() {
 
 		It("should have the updated status of GatewayClass in the API server", func() {
 			latestGc := &v1alpha2.GatewayClass{}
-			expectedGc := createExpectedGc(metav1.ConditionTrue, 1, string(v1alpha2.GatewayClassConditionStatusAccepted), "GatewayClass has been accepted")
+			expectedGc := createExpectedGc(metav1.ConditionTrue, 1, string(v1beta1.GatewayClassConditionStatusAccepted), "GatewayClass has been accepted")
 
 			err := client.Get(context.Background(), types.NamespacedName{Name: gcName}, latestGc)
 			Expect(err).Should(Not(HaveOccurred()))
@@ -288,28 +276,18 @@ var _ = Describe("Updater", func() {
 			Expect(helpers.Diff(expectedGc, latestGc)).To(BeEmpty())
 		})
 
-		It("should have the updated status of Gateway in the API server", func() {
-			latestGw := &v1alpha2.Gateway{}
-			expectedGw := createExpectedGw(metav1.ConditionTrue, string(v1alpha2.ListenerReasonReady))
-
-			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "gateway"}, latestGw)
-			Expect(err).Should(Not(HaveOccurred()))
-
-			expectedGw.ResourceVersion = latestGw.ResourceVersion
-
-			Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
-		})
-
-		It("should have the updated status of ignored Gateway in the API server", func() {
-			latestGw := &v1alpha2.Gateway{}
-			expectedGw := createExpectedIgnoredGw()
+		It("should have the updated statuses of Gateways in the API server", func() {
+			for _, name := range []string{"gateway", "gateway-2"} {
+				latestGw := &v1alpha2.Gateway{}
+				expectedGw := createExpectedGw(name, metav1.ConditionTrue, string(v1beta1.ListenerReasonReady))
 
-			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "ignored-gateway"}, latestGw)
-			Expect(err).Should(Not(HaveOccurred()))
+				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: name}, latestGw)
+				Expect(err).Should(Not(HaveOccurred()))
 
-			expectedGw.ResourceVersion = latestGw.ResourceVersion
+				expectedGw.ResourceVersion = latestGw.ResourceVersion
 
-			Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+			}
 		})
 
 		It("should have the updated status of HTTPRoute in the API server", func() {
@@ -333,7 +311,7 @@ var _ = Describe("Updater", func() {
 		When("updating with canceled context", func() {
 			It("should have the updated status of GatewayClass in the API server", func() {
 				latestGc := &v1alpha2.GatewayClass{}
-				expectedGc := createExpectedGc(metav1.ConditionFalse, 2, string(v1alpha2.GatewayClassConditionStatusAccepted), "GatewayClass has been rejected: error")
+				expectedGc := createExpectedGc(metav1.ConditionFalse, 2, string(v1beta1.GatewayClassConditionStatusAccepted), "GatewayClass has been rejected: error")
 
 				err := client.Get(context.Background(), types.NamespacedName{Name: gcName}, latestGc)
 				Expect(err).Should(Not(HaveOccurred()))
@@ -343,29 +321,19 @@ var _ = Describe("Updater", func() {
 				Expect(helpers.Diff(expectedGc, latestGc)).To(BeEmpty())
 			})
 
-			It("should have the updated status of Gateway in the API server", func() {
-				latestGw := &v1alpha2.Gateway{}
-				expectedGw := createExpectedGw(metav1.ConditionFalse, string(v1alpha2.ListenerReasonInvalid))
-
-				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "gateway"}, latestGw)
-				Expect(err).Should(Not(HaveOccurred()))
-
-				expectedGw.ResourceVersion = latestGw.ResourceVersion
-
-				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
-			})
+			It("should not have the updated statuses of Gateways in the API server", func() {
+				for _, name := range []string{"gateway", "gateway-2"} {
+					latestGw := &v1alpha2.Gateway{}
+					expectedGw := createExpectedGw(name, metav1.ConditionTrue, string(v1beta1.ListenerReasonReady))
 
-			It("should not have the updated status of ignored Gateway in the API server", func() {
-				latestGw := &v1alpha2.Gateway{}
-				expectedGw := createExpectedIgnoredGw()
+					err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: name}, latestGw)
+					Expect(err).Should(Not(HaveOccurred()))
 
-				err := client.Get(context.Background(), types.NamespacedName{Namespace: "test", Name: "ignored-gateway"}, latestGw)
-				Expect(err).Should(Not(HaveOccurred()))
+					expectedGw.ResourceVersion = latestGw.ResourceVersion
 
-				expectedGw.ResourceVersion = latestGw.ResourceVersion
-
-				// if the status was updated, we would see a different ObservedGeneration
-				Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+					// if the status was updated, we would see the listener invalid (Ready = false)
+					Expect(helpers.Diff(expectedGw, latestGw)).To(BeEmpty())
+				}
 			})
 
 			It("should not have the updated status of HTTPRoute in the API server", func() {
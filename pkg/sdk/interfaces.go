@@ -2,6 +2,7 @@ package sdk
 
 import (
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 
@@ -29,7 +30,47 @@ type HTTPRouteImpl interface {
 	Remove(types.NamespacedName)
 }
 
+type GRPCRouteImpl interface {
+	Upsert(gr *v1alpha2.GRPCRoute)
+	Remove(nsname types.NamespacedName)
+}
+
+type TLSRouteImpl interface {
+	Upsert(tr *v1alpha2.TLSRoute)
+	Remove(nsname types.NamespacedName)
+}
+
+type TCPRouteImpl interface {
+	Upsert(tr *v1alpha2.TCPRoute)
+	Remove(nsname types.NamespacedName)
+}
+
+type UDPRouteImpl interface {
+	Upsert(ur *v1alpha2.UDPRoute)
+	Remove(nsname types.NamespacedName)
+}
+
+type ReferenceGrantImpl interface {
+	Upsert(rg *v1alpha2.ReferenceGrant)
+	Remove(nsname types.NamespacedName)
+}
+
 type ServiceImpl interface {
 	Upsert(svc *apiv1.Service)
 	Remove(nsname types.NamespacedName)
 }
+
+type EndpointSliceImpl interface {
+	Upsert(slice *discoveryv1.EndpointSlice)
+	Remove(nsname types.NamespacedName)
+}
+
+type SecretImpl interface {
+	Upsert(secret *apiv1.Secret)
+	Remove(nsname types.NamespacedName)
+}
+
+type NamespaceImpl interface {
+	Upsert(namespace *apiv1.Namespace)
+	Remove(nsname types.NamespacedName)
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctlr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type namespaceReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	impl   NamespaceImpl
+}
+
+// RegisterNamespaceController registers the NamespaceController in the manager.
+func RegisterNamespaceController(mgr manager.Manager, impl NamespaceImpl) error {
+	r := &namespaceReconciler{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		impl:   impl,
+	}
+
+	return ctlr.NewControllerManagedBy(mgr).
+		For(&apiv1.Namespace{}).
+		Complete(r)
+}
+
+func (r *namespaceReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx).WithValues("namespace", req.NamespacedName)
+
+	log.V(3).Info("Reconciling Namespace")
+
+	found := true
+	var namespace apiv1.Namespace
+	err := r.Get(ctx, req.NamespacedName, &namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get Namespace")
+			return reconcile.Result{}, err
+		}
+		found = false
+	}
+
+	if !found {
+		log.V(3).Info("Removing Namespace")
+
+		r.impl.Remove(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	log.V(3).Info("Upserting Namespace")
+
+	r.impl.Upsert(&namespace)
+	return reconcile.Result{}, nil
+}
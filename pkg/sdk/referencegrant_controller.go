@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctlr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type referenceGrantReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	impl   ReferenceGrantImpl
+}
+
+// RegisterReferenceGrantController registers the ReferenceGrantController in the manager.
+func RegisterReferenceGrantController(mgr manager.Manager, impl ReferenceGrantImpl) error {
+	r := &referenceGrantReconciler{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		impl:   impl,
+	}
+
+	return ctlr.NewControllerManagedBy(mgr).
+		For(&v1alpha2.ReferenceGrant{}).
+		Complete(r)
+}
+
+func (r *referenceGrantReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx).WithValues("referenceGrant", req.NamespacedName)
+
+	log.V(3).Info("Reconciling ReferenceGrant")
+
+	found := true
+	var rg v1alpha2.ReferenceGrant
+	err := r.Get(ctx, req.NamespacedName, &rg)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get ReferenceGrant")
+			return reconcile.Result{}, err
+		}
+		found = false
+	}
+
+	if !found {
+		log.V(3).Info("Removing ReferenceGrant")
+
+		r.impl.Remove(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	log.V(3).Info("Upserting ReferenceGrant")
+
+	r.impl.Upsert(&rg)
+	return reconcile.Result{}, nil
+}
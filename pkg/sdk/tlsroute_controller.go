@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctlr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type tlsRouteReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	impl   TLSRouteImpl
+}
+
+// RegisterTLSRouteController registers the TLSRouteController in the manager.
+func RegisterTLSRouteController(mgr manager.Manager, impl TLSRouteImpl) error {
+	r := &tlsRouteReconciler{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		impl:   impl,
+	}
+
+	return ctlr.NewControllerManagedBy(mgr).
+		For(&v1alpha2.TLSRoute{}).
+		Complete(r)
+}
+
+func (r *tlsRouteReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx).WithValues("tlsRoute", req.NamespacedName)
+
+	log.V(3).Info("Reconciling TLSRoute")
+
+	found := true
+	var tr v1alpha2.TLSRoute
+	err := r.Get(ctx, req.NamespacedName, &tr)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get TLSRoute")
+			return reconcile.Result{}, err
+		}
+		found = false
+	}
+
+	if !found {
+		log.V(3).Info("Removing TLSRoute")
+
+		r.impl.Remove(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	log.V(3).Info("Upserting TLSRoute")
+
+	r.impl.Upsert(&tr)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctlr "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+type udpRouteReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	impl   UDPRouteImpl
+}
+
+// RegisterUDPRouteController registers the UDPRouteController in the manager.
+func RegisterUDPRouteController(mgr manager.Manager, impl UDPRouteImpl) error {
+	r := &udpRouteReconciler{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		impl:   impl,
+	}
+
+	return ctlr.NewControllerManagedBy(mgr).
+		For(&v1alpha2.UDPRoute{}).
+		Complete(r)
+}
+
+func (r *udpRouteReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx).WithValues("udpRoute", req.NamespacedName)
+
+	log.V(3).Info("Reconciling UDPRoute")
+
+	found := true
+	var ur v1alpha2.UDPRoute
+	err := r.Get(ctx, req.NamespacedName, &ur)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to get UDPRoute")
+			return reconcile.Result{}, err
+		}
+		found = false
+	}
+
+	if !found {
+		log.V(3).Info("Removing UDPRoute")
+
+		r.impl.Remove(req.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	log.V(3).Info("Upserting UDPRoute")
+
+	r.impl.Upsert(&ur)
+	return reconcile.Result{}, nil
+}